@@ -19,6 +19,8 @@ func main() {
 	rootCmd.AddCommand(project.NewRemoveCmd())
 	rootCmd.AddCommand(project.NewValidateCmd())
 	rootCmd.AddCommand(project.NewSyncCmd())
+	rootCmd.AddCommand(project.NewMCPCmd())
+	rootCmd.AddCommand(project.NewStatsCmd())
 	rootCmd.AddCommand(cli.NewVersionCmd())
 
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,106 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aviator5/dnaspec/internal/core/authoring"
+	"github.com/aviator5/dnaspec/internal/ui"
+)
+
+// NewBuildCmd creates the manifest build subcommand
+func NewBuildCmd() *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Build the manifest from guideline and prompt frontmatter",
+		Long: `Generate or update dnaspec-manifest.yaml from the frontmatter of the markdown
+files in guidelines/ and prompts/.
+
+Each file with frontmatter gets a manifest entry. Its frontmatter keys are the
+manifest keys of the entry (description, applicable_scenarios, prompts, ...);
+file is taken from the path and name defaults to the file name without .md.
+Keys not set in the frontmatter, the order of entries and comments in the
+manifest are kept. Files without frontmatter are left alone, and entries whose
+file no longer exists are removed.
+
+With --check, the manifest isn't written; the command fails if it is out of
+sync with the files, which is useful in CI.`,
+		Example: `  # Update the manifest from the files in guidelines/ and prompts/
+  dnaspec manifest build
+
+  # Fail if the manifest is out of sync with the files
+  dnaspec manifest build --check`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBuild(check)
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Fail if the manifest is out of sync instead of writing it")
+
+	return cmd
+}
+
+func runBuild(check bool) error {
+	data, result, err := authoring.BuildManifest(manifestFileName)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), "Failed to build manifest:", err)
+		return err
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Println(ui.WarningStyle.Render("⚠"), warning)
+	}
+	for _, skipped := range result.Skipped {
+		fmt.Println(ui.InfoStyle.Render("ℹ"), "Skipped", skipped)
+	}
+
+	if !result.Changed() {
+		fmt.Println(ui.SuccessStyle.Render("✓ Manifest is up to date"))
+		return nil
+	}
+
+	if check {
+		fmt.Println(ui.ErrorStyle.Render("✗ Manifest is out of sync with the files:"))
+		fmt.Println()
+		displayBuildChanges(result)
+		fmt.Println()
+		fmt.Println(
+			ui.SubtleStyle.Render("Run"), ui.CodeStyle.Render("dnaspec manifest build"),
+			ui.SubtleStyle.Render("to update it."),
+		)
+		return fmt.Errorf("manifest is out of sync")
+	}
+
+	if err := os.WriteFile(manifestFileName, data, 0644); err != nil {
+		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), "Failed to write manifest:", err)
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render("✓ Success:"), "Updated", ui.CodeStyle.Render(manifestFileName))
+	fmt.Println()
+	displayBuildChanges(result)
+	fmt.Println()
+	fmt.Println("Run", ui.CodeStyle.Render("dnaspec manifest validate"), "to check your manifest")
+
+	return nil
+}
+
+// displayBuildChanges lists the entries a build added, updated and removed, and the references it dropped
+func displayBuildChanges(result authoring.BuildResult) {
+	for _, file := range result.Added {
+		fmt.Println("  "+ui.SuccessStyle.Render("+"), "Added", ui.CodeStyle.Render(file))
+	}
+	for _, file := range result.Updated {
+		fmt.Println("  "+ui.InfoStyle.Render("~"), "Updated", ui.CodeStyle.Render(file))
+	}
+	for _, file := range result.Removed {
+		fmt.Println("  "+ui.ErrorStyle.Render("-"), "Removed", ui.CodeStyle.Render(file), "(file no longer exists)")
+	}
+	for _, reference := range result.Unlinked {
+		fmt.Println("  "+ui.ErrorStyle.Render("-"), "Removed reference", reference)
+	}
+}
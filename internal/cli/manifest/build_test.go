@@ -0,0 +1,56 @@
+package manifest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCmd_WritesAndChecksManifest(t *testing.T) {
+	// Create temp directory and change to it
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+
+	err = os.MkdirAll("guidelines", 0755)
+	require.NoError(t, err)
+	err = os.WriteFile("guidelines/go-style.md", []byte(`---
+description: Go code style
+applicable_scenarios:
+  - Writing Go code
+---
+# Go Style
+`), 0644)
+	require.NoError(t, err)
+
+	// Out of sync before the first build
+	err = runBuild(true)
+	assert.ErrorContains(t, err, "manifest is out of sync")
+	_, err = os.Stat(manifestFileName)
+	assert.True(t, os.IsNotExist(err), "--check should not write the manifest")
+
+	// Build writes a valid manifest
+	err = runBuild(false)
+	require.NoError(t, err)
+	err = runValidate(0)
+	assert.NoError(t, err)
+
+	// In sync after the build
+	err = runBuild(true)
+	assert.NoError(t, err)
+
+	// Changing the frontmatter puts it out of sync again
+	err = os.WriteFile("guidelines/go-style.md", []byte("---\ndescription: Idiomatic Go\n---\n# Go Style\n"), 0644)
+	require.NoError(t, err)
+	err = runBuild(true)
+	assert.ErrorContains(t, err, "manifest is out of sync")
+}
@@ -0,0 +1,91 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aviator5/dnaspec/internal/core/authoring"
+	"github.com/aviator5/dnaspec/internal/ui"
+)
+
+// NewImportCmd creates the manifest import subcommand
+func NewImportCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "import <path>",
+		Short: "Import existing agent files as guidelines and prompts",
+		Long: `Harvest the hand-written agent files of a project into guidelines/ and
+prompts/ files plus dnaspec-manifest.yaml entries.
+
+Imported files:
+- CLAUDE.md, .cursorrules and .github/copilot-instructions.md become
+  guidelines, split into one guideline per section by heading
+- .cursor/rules/*.mdc become one guideline each
+- .claude/commands/*.md become one prompt each
+
+DNASpec managed blocks are skipped, so files generated by dnaspec
+update-agents don't import DNA back. Sections whose name is already used
+in the manifest, or whose file already exists, are skipped too.
+
+Descriptions come from the files' frontmatter or first sentence; applicable
+scenarios are left as TODO placeholders to fill in.`,
+		Example: `  # Preview what would be imported from a project
+  dnaspec manifest import ../payments-service --dry-run
+
+  # Import the agent files of a project
+  dnaspec manifest import ../payments-service`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(args[0], dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be imported without writing files")
+
+	return cmd
+}
+
+func runImport(path string, dryRun bool) error {
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), "Directory not found:", ui.CodeStyle.Render(path))
+		return fmt.Errorf("directory not found: %s", path)
+	}
+
+	result, err := authoring.Import(manifestFileName, path, dryRun)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), "Failed to import:", err)
+		return err
+	}
+
+	for _, skipped := range result.Skipped {
+		fmt.Println(ui.WarningStyle.Render("⚠"), "Skipped", skipped)
+	}
+
+	if len(result.Imported) == 0 {
+		fmt.Println(ui.InfoStyle.Render("ℹ"), "Nothing to import from", ui.CodeStyle.Render(path))
+		return nil
+	}
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle.Render("Would import:"))
+	} else {
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Imported %d guideline(s) and prompt(s):", len(result.Imported))))
+	}
+	for _, entry := range result.Imported {
+		fmt.Println("  •", ui.CodeStyle.Render(entry.File), ui.SubtleStyle.Render("from "+entry.Source))
+	}
+	if dryRun {
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(ui.InfoStyle.Render("Next steps:"))
+	fmt.Println("  1. Review the imported files and rename or merge guidelines as needed")
+	fmt.Println("  2. Replace the TODO placeholders in", ui.CodeStyle.Render(manifestFileName))
+	fmt.Println("  3. Run", ui.CodeStyle.Render("dnaspec manifest validate"), "to check your manifest")
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCmd(t *testing.T) {
+	project := t.TempDir()
+	err := os.WriteFile(filepath.Join(project, "CLAUDE.md"), []byte("## Style\n\nUse gofmt.\n\n## Testing\n\nWrite tests.\n"), 0644)
+	require.NoError(t, err)
+
+	// Create temp directory and change to it
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+
+	// Dry run writes nothing
+	err = runImport(project, true)
+	require.NoError(t, err)
+	assert.NoFileExists(t, manifestFileName)
+
+	err = runImport(project, false)
+	require.NoError(t, err)
+	assert.FileExists(t, "guidelines/style.md")
+	assert.FileExists(t, "guidelines/testing.md")
+
+	err = runValidate(0)
+	assert.NoError(t, err)
+
+	// Importing again skips what already exists
+	err = runImport(project, false)
+	assert.NoError(t, err)
+
+	err = runImport(filepath.Join(project, "missing"), false)
+	assert.ErrorContains(t, err, "directory not found")
+}
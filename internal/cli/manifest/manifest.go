@@ -9,7 +9,7 @@ func NewManifestCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "manifest",
 		Short: "Manage DNA repository manifest files",
-		Long: `Commands for creating and validating dnaspec-manifest.yaml files.
+		Long: `Commands for creating, building, scaffolding, importing and validating dnaspec-manifest.yaml files.
 
 The manifest file defines the guidelines and prompts available in a DNA repository.`,
 	}
@@ -17,6 +17,9 @@ The manifest file defines the guidelines and prompts available in a DNA reposito
 	// Add subcommands
 	cmd.AddCommand(NewInitCmd())
 	cmd.AddCommand(NewValidateCmd())
+	cmd.AddCommand(NewBuildCmd())
+	cmd.AddCommand(NewNewCmd())
+	cmd.AddCommand(NewImportCmd())
 
 	return cmd
 }
@@ -0,0 +1,128 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aviator5/dnaspec/internal/core/authoring"
+	"github.com/aviator5/dnaspec/internal/ui"
+)
+
+// NewNewCmd creates the manifest new subcommand
+func NewNewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "new",
+		Short: "Create a new guideline or prompt",
+		Long: `Create a guideline or prompt file from a template and add its entry to
+dnaspec-manifest.yaml. Comments and ordering in the manifest are kept.`,
+	}
+
+	cmd.AddCommand(newGuidelineCmd())
+	cmd.AddCommand(newPromptCmd())
+
+	return cmd
+}
+
+func newGuidelineCmd() *cobra.Command {
+	var opts authoring.GuidelineOptions
+
+	cmd := &cobra.Command{
+		Use:   "guideline <name>",
+		Short: "Create a new guideline",
+		Long: `Create guidelines/<name>.md from a template and add the guideline to
+dnaspec-manifest.yaml.
+
+The name must use spinal-case and must not be used by another guideline.
+Without --description and --scenario, the entry gets TODO placeholders
+to fill in.`,
+		Example: `  # Create a guideline and fill in the manifest entry later
+  dnaspec manifest new guideline go-style
+
+  # Create a guideline with its description and scenarios
+  dnaspec manifest new guideline go-style \
+    --description "Go code style conventions" \
+    --scenario "Writing Go code" --scenario "Reviewing Go code"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNewGuideline(args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the guideline")
+	cmd.Flags().StringArrayVar(&opts.Scenarios, "scenario", nil, "Scenario the guideline applies to (can be repeated)")
+
+	return cmd
+}
+
+func newPromptCmd() *cobra.Command {
+	var opts authoring.PromptOptions
+
+	cmd := &cobra.Command{
+		Use:   "prompt <name>",
+		Short: "Create a new prompt",
+		Long: `Create prompts/<name>.md from a template and add the prompt to
+dnaspec-manifest.yaml.
+
+The name must use spinal-case and must not be used by another prompt.
+With --for, the prompt is also added to the prompts of that guideline.`,
+		Example: `  # Create a prompt for the go-style guideline
+  dnaspec manifest new prompt go-code-review --for go-style
+
+  # Create a standalone prompt with its description
+  dnaspec manifest new prompt debugging --description "Systematic debugging"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNewPrompt(args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the prompt")
+	cmd.Flags().StringVar(&opts.For, "for", "", "Guideline that references the prompt")
+
+	return cmd
+}
+
+func runNewGuideline(name string, opts authoring.GuidelineOptions) error {
+	file, err := authoring.NewGuideline(manifestFileName, name, opts)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), "Failed to create guideline:", err)
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render("✓ Success:"), "Created", ui.CodeStyle.Render(file))
+	fmt.Println("  Added guideline", ui.CodeStyle.Render(name), "to", ui.CodeStyle.Render(manifestFileName))
+	displayNewNextSteps(file, opts.Description == "" || len(opts.Scenarios) == 0)
+
+	return nil
+}
+
+func runNewPrompt(name string, opts authoring.PromptOptions) error {
+	file, err := authoring.NewPrompt(manifestFileName, name, opts)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), "Failed to create prompt:", err)
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render("✓ Success:"), "Created", ui.CodeStyle.Render(file))
+	fmt.Println("  Added prompt", ui.CodeStyle.Render(name), "to", ui.CodeStyle.Render(manifestFileName))
+	if opts.For != "" {
+		fmt.Println("  Referenced it from guideline", ui.CodeStyle.Render(opts.For))
+	}
+	displayNewNextSteps(file, opts.Description == "")
+
+	return nil
+}
+
+// displayNewNextSteps prints what to do after creating a guideline or prompt
+func displayNewNextSteps(file string, placeholders bool) {
+	fmt.Println()
+	fmt.Println(ui.InfoStyle.Render("Next steps:"))
+	fmt.Println("  1. Write the content of", ui.CodeStyle.Render(file))
+	step := 2
+	if placeholders {
+		fmt.Println("  2. Replace the TODO placeholders of the entry in", ui.CodeStyle.Render(manifestFileName))
+		step = 3
+	}
+	fmt.Printf("  %d. Run %s to check your manifest\n", step, ui.CodeStyle.Render("dnaspec manifest validate"))
+}
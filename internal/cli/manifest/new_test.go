@@ -0,0 +1,57 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/authoring"
+)
+
+func TestNewCmd_GuidelineAndPrompt(t *testing.T) {
+	// Create temp directory and change to it
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+
+	err = runInit()
+	require.NoError(t, err)
+
+	err = runNewGuideline("rest-api", authoring.GuidelineOptions{
+		Description: "REST API design",
+		Scenarios:   []string{"Designing REST APIs"},
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, "guidelines/rest-api.md")
+
+	err = runNewPrompt("api-review", authoring.PromptOptions{For: "rest-api"})
+	require.NoError(t, err)
+	assert.FileExists(t, "prompts/api-review.md")
+
+	// The example manifest's comments survive
+	data, err := os.ReadFile(manifestFileName)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Add more guidelines here")
+
+	// The example manifest's files aren't written by init
+	for _, file := range []string{"guidelines/go-style.md", "prompts/code-review.md", "prompts/implementation.md"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte("# Example\n"), 0644))
+	}
+	err = runValidate(0)
+	assert.NoError(t, err)
+
+	// Names must be spinal-case
+	err = runNewGuideline("RestAPI", authoring.GuidelineOptions{})
+	assert.ErrorContains(t, err, "expected spinal-case")
+}
@@ -2,7 +2,11 @@ package manifest
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,29 +17,66 @@ import (
 
 // NewValidateCmd creates the manifest validate subcommand
 func NewValidateCmd() *cobra.Command {
+	var maxReviewAge int
+	var format string
+
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate the manifest file",
 		Long: `Validate the dnaspec-manifest.yaml file in the current directory.
 
 This command checks:
-- Manifest structure and required fields
+- Manifest structure and required fields, including partial manifests merged with include
 - Guideline and prompt definitions
 - File references (files must exist)
 - Cross-references (prompts referenced by guidelines must exist)
+- Templates (declared variables, include targets exist, no include cycles)
+- Relative links in guideline and prompt files (targets exist in the repository)
+- Guideline dependencies (required guidelines exist, no cycles)
+- Bundles (unique names, listed guidelines must exist)
+- Aliases and deprecations (replacements exist, deprecated guidelines aren't in bundles or required)
 - Naming conventions (spinal-case)
-- Path security (no absolute paths or path traversal)`,
+- Path security (no absolute paths or path traversal)
+- Guideline metadata (spinal-case tags, non-empty owners, last_reviewed dates)
+- File content (empty files, missing top-level headings, unclosed code fences,
+  oversized files, frontmatter colliding with generated frontmatter, DNASpec
+  managed block markers)
+
+Content checks are reported as errors or warnings according to the lint
+section of the manifest; warnings don't fail validation.
+
+With --max-review-age, guidelines not reviewed within that many days
+(according to last_reviewed) are reported as errors too.
+
+With --format json or --format sarif, the results are written to stdout for
+tools, with the file, line and column of each problem. SARIF output can be
+uploaded to GitHub code scanning to annotate the manifest.`,
 		Example: `  # Validate the manifest in the current directory
-  dnaspec manifest validate`,
+  dnaspec manifest validate
+
+  # Also require every guideline to be reviewed within the last 180 days
+  dnaspec manifest validate --max-review-age 180
+
+  # Write SARIF for GitHub code scanning
+  dnaspec manifest validate --format sarif > dnaspec.sarif`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runValidate()
+			if !slices.Contains(validate.Formats, format) {
+				return fmt.Errorf("invalid format '%s' (expected %s)", format, strings.Join(validate.Formats, ", "))
+			}
+			if format != validate.FormatText {
+				return runValidateReport(cmd.OutOrStdout(), maxReviewAge, format)
+			}
+			return runValidate(maxReviewAge)
 		},
 	}
 
+	cmd.Flags().IntVar(&maxReviewAge, "max-review-age", 0, "Flag guidelines not reviewed within this many days (0 disables the check)")
+	cmd.Flags().StringVar(&format, "format", validate.FormatText, "Output format: text, json or sarif")
+
 	return cmd
 }
 
-func runValidate() error {
+func runValidate(maxReviewAge int) error {
 	// Check if manifest exists
 	if _, err := os.Stat(manifestFileName); os.IsNotExist(err) {
 		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), ui.CodeStyle.Render(manifestFileName), "not found")
@@ -46,16 +87,22 @@ func runValidate() error {
 		return fmt.Errorf("manifest file not found")
 	}
 
-	// Load the manifest
-	manifest, err := config.LoadManifest(manifestFileName)
+	problems, err := collectProblems(maxReviewAge)
 	if err != nil {
 		fmt.Println(ui.ErrorStyle.Render("✗ Error:"), "Failed to load manifest:", err)
 		return err
 	}
+	errors, warnings := problems.Partition()
 
-	// Validate the manifest
-	baseDir, _ := os.Getwd()
-	errors := validate.ValidateManifest(manifest, baseDir)
+	// Display warnings, which don't fail validation
+	if len(warnings) > 0 {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ Found %d warning(s):", len(warnings))))
+		fmt.Println()
+		for _, warning := range warnings {
+			displayProblem(warning)
+		}
+		fmt.Println()
+	}
 
 	// Report results
 	if errors.IsEmpty() {
@@ -67,7 +114,7 @@ func runValidate() error {
 	fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("✗ Found %d validation error(s):", len(errors))))
 	fmt.Println()
 	for _, err := range errors {
-		fmt.Println("  •", ui.CodeStyle.Render(err.Field)+":", err.Message)
+		displayProblem(err)
 	}
 	fmt.Println()
 	fmt.Println(
@@ -78,3 +125,48 @@ func runValidate() error {
 
 	return fmt.Errorf("validation failed")
 }
+
+// runValidateReport validates the manifest and writes the results in a machine-readable format
+func runValidateReport(out io.Writer, maxReviewAge int, format string) error {
+	if _, err := os.Stat(manifestFileName); os.IsNotExist(err) {
+		return fmt.Errorf("manifest file not found: run 'dnaspec manifest init' first")
+	}
+
+	problems, err := collectProblems(maxReviewAge)
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	if err := validate.WriteReport(out, format, problems, manifestFileName); err != nil {
+		return err
+	}
+
+	if errors, _ := problems.Partition(); !errors.IsEmpty() {
+		return fmt.Errorf("validation failed")
+	}
+	return nil
+}
+
+// collectProblems loads the manifest in the current directory and returns its validation errors and warnings
+func collectProblems(maxReviewAge int) (validate.ValidationErrors, error) {
+	manifest, err := config.LoadManifest(manifestFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	baseDir, _ := os.Getwd()
+	problems := validate.ValidateManifest(manifest, baseDir)
+	if maxReviewAge > 0 {
+		problems = append(problems, validate.ValidateReviewAge(manifest, maxReviewAge, time.Now())...)
+	}
+	problems = append(problems, validate.LintManifest(manifest, baseDir)...)
+	return problems, nil
+}
+
+// displayProblem prints a validation error or warning, with the lint rule that reported it
+func displayProblem(problem validate.ValidationError) {
+	message := problem.Message
+	if problem.Rule != "" {
+		message += " " + ui.SubtleStyle.Render("["+problem.Rule+"]")
+	}
+	fmt.Println("  •", ui.CodeStyle.Render(problem.Location())+":", message)
+}
@@ -1,11 +1,16 @@
 package manifest
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/validate"
 )
 
 func TestValidateCmd_Success(t *testing.T) {
@@ -49,7 +54,7 @@ prompts:
 	require.NoError(t, err)
 
 	// Run validate
-	err = runValidate()
+	err = runValidate(0)
 	assert.NoError(t, err, "Valid manifest should pass validation")
 }
 
@@ -67,7 +72,7 @@ func TestValidateCmd_ManifestNotFound(t *testing.T) {
 	require.NoError(t, err)
 
 	// Run validate without a manifest file
-	err = runValidate()
+	err = runValidate(0)
 	assert.Error(t, err, "Should error when manifest file doesn't exist")
 }
 
@@ -89,7 +94,7 @@ func TestValidateCmd_InvalidYAML(t *testing.T) {
 	require.NoError(t, err)
 
 	// Run validate
-	err = runValidate()
+	err = runValidate(0)
 	assert.Error(t, err, "Should error on invalid YAML")
 }
 
@@ -120,7 +125,7 @@ prompts: []
 	require.NoError(t, err)
 
 	// Run validate (files don't exist)
-	err = runValidate()
+	err = runValidate(0)
 	assert.Error(t, err, "Should error when referenced files don't exist")
 }
 
@@ -157,7 +162,7 @@ prompts: []
 	require.NoError(t, err)
 
 	// Run validate
-	err = runValidate()
+	err = runValidate(0)
 	assert.Error(t, err, "Should error on invalid naming convention")
 }
 
@@ -193,7 +198,7 @@ prompts: []
 	require.NoError(t, err)
 
 	// Run validate
-	err = runValidate()
+	err = runValidate(0)
 	assert.Error(t, err, "Should error on empty applicable_scenarios")
 }
 
@@ -232,7 +237,7 @@ prompts: []
 	require.NoError(t, err)
 
 	// Run validate
-	err = runValidate()
+	err = runValidate(0)
 	assert.Error(t, err, "Should error on undefined prompt reference")
 }
 
@@ -263,7 +268,7 @@ prompts: []
 	require.NoError(t, err)
 
 	// Run validate
-	err = runValidate()
+	err = runValidate(0)
 	assert.Error(t, err, "Should error on path traversal attempt")
 }
 
@@ -318,10 +323,40 @@ prompts:
 	require.NoError(t, err)
 
 	// Run validate
-	err = runValidate()
+	err = runValidate(0)
 	assert.NoError(t, err, "Complex valid manifest should pass validation")
 }
 
+func TestValidateCmd_MaxReviewAge(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+	require.NoError(t, os.Chdir(tmpDir))
+
+	reviewed := time.Now().AddDate(0, 0, -30).Format(time.DateOnly)
+	manifestContent := `version: 1
+guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go coding style guidelines
+    applicable_scenarios:
+      - Writing Go code
+    last_reviewed: "` + reviewed + `"
+prompts: []
+`
+	require.NoError(t, os.WriteFile(manifestFileName, []byte(manifestContent), 0644))
+	require.NoError(t, os.MkdirAll("guidelines", 0755))
+	require.NoError(t, os.WriteFile("guidelines/go-style.md", []byte("# Go Style"), 0644))
+
+	assert.NoError(t, runValidate(0), "Review age is not checked by default")
+	assert.NoError(t, runValidate(90), "Guideline reviewed 30 days ago is within 90 days")
+	assert.Error(t, runValidate(7), "Guideline reviewed 30 days ago is older than 7 days")
+}
+
 func TestNewValidateCmd(t *testing.T) {
 	cmd := NewValidateCmd()
 	assert.NotNil(t, cmd)
@@ -330,3 +365,109 @@ func TestNewValidateCmd(t *testing.T) {
 	assert.NotEmpty(t, cmd.Long)
 	assert.NotEmpty(t, cmd.Example)
 }
+
+func TestValidateCmd_ContentLint(t *testing.T) {
+	// Create temp directory and change to it
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+
+	manifestContent := `version: 1
+guidelines:
+  - name: test-guideline
+    file: guidelines/test.md
+    description: Test guideline
+    applicable_scenarios:
+      - Testing
+prompts: []
+`
+	err = os.WriteFile(manifestFileName, []byte(manifestContent), 0644)
+	require.NoError(t, err)
+	err = os.MkdirAll("guidelines", 0755)
+	require.NoError(t, err)
+
+	// A missing heading is a warning by default
+	err = os.WriteFile("guidelines/test.md", []byte("Use gofmt.\n"), 0644)
+	require.NoError(t, err)
+	err = runValidate(0)
+	assert.NoError(t, err, "Warnings should not fail validation")
+
+	// Unless the manifest raises it to an error
+	err = os.WriteFile(manifestFileName, []byte(manifestContent+"lint:\n  rules:\n    missing-heading: error\n"), 0644)
+	require.NoError(t, err)
+	err = runValidate(0)
+	assert.Error(t, err)
+
+	// An empty file is an error by default
+	err = os.WriteFile(manifestFileName, []byte(manifestContent), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile("guidelines/test.md", []byte(""), 0644)
+	require.NoError(t, err)
+	err = runValidate(0)
+	assert.Error(t, err)
+}
+
+func TestValidateCmd_FormatJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err = os.Chdir(tmpDir)
+	require.NoError(t, err)
+
+	manifestContent := `version: 1
+guidelines:
+  - name: test-guideline
+    file: guidelines/missing.md
+    description: Test guideline
+    applicable_scenarios:
+      - Testing
+prompts: []
+`
+	err = os.WriteFile(manifestFileName, []byte(manifestContent), 0644)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = runValidateReport(&out, 0, validate.FormatJSON)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed")
+
+	var report struct {
+		Valid    bool `json:"valid"`
+		Problems []struct {
+			Severity string `json:"severity"`
+			File     string `json:"file"`
+			Line     int    `json:"line"`
+			Field    string `json:"field"`
+		} `json:"problems"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.False(t, report.Valid)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, "error", report.Problems[0].Severity)
+	assert.Equal(t, manifestFileName, report.Problems[0].File)
+	assert.Equal(t, "guidelines[0].file", report.Problems[0].Field)
+	assert.Equal(t, 4, report.Problems[0].Line)
+}
+
+func TestValidateCmd_InvalidFormat(t *testing.T) {
+	cmd := NewValidateCmd()
+	cmd.SetArgs([]string{"--format", "xml"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format 'xml'")
+}
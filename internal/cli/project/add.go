@@ -3,12 +3,16 @@ package project
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/aviator5/dnaspec/internal/core/links"
 	"github.com/aviator5/dnaspec/internal/core/paths"
 	"github.com/aviator5/dnaspec/internal/core/source"
 	"github.com/aviator5/dnaspec/internal/ui"
@@ -20,6 +24,7 @@ type addFlags struct {
 	name       string
 	all        bool
 	guidelines []string
+	bundles    []string
 	dryRun     bool
 }
 
@@ -33,7 +38,11 @@ func NewAddCmd() *cobra.Command {
 		Long: `Add a DNA source (git repository or local directory) to your project.
 
 This command fetches DNA guidelines from a source, lets you select which
-guidelines to include, and copies them to your project's dnaspec/ directory.`,
+guidelines to include, and copies them to your project's dnaspec/ directory.
+
+If the manifest defines bundles (named sets of guidelines), you can pick
+bundles first or pass --bundle. The project remembers the selected bundles,
+and 'dnaspec update' adds guidelines that are later added to them.`,
 		Example: `  # Add from git repository
   dnaspec add --git-repo https://github.com/company/dna
 
@@ -49,6 +58,9 @@ guidelines to include, and copies them to your project's dnaspec/ directory.`,
   # Add specific guidelines
   dnaspec add --git-repo https://github.com/company/dna --guideline go-style --guideline rest-api
 
+  # Add the guidelines of a bundle defined in the manifest
+  dnaspec add --git-repo https://github.com/company/dna --bundle backend-go
+
   # Specify custom source name
   dnaspec add --git-repo https://github.com/company/dna --name my-custom-name
 
@@ -65,6 +77,7 @@ guidelines to include, and copies them to your project's dnaspec/ directory.`,
 	cmd.Flags().StringVar(&flags.name, "name", "", "Custom source name (auto-derived if not specified)")
 	cmd.Flags().BoolVar(&flags.all, "all", false, "Add all guidelines without prompting")
 	cmd.Flags().StringSliceVar(&flags.guidelines, "guideline", []string{}, "Add specific guideline by name (repeatable)")
+	cmd.Flags().StringSliceVar(&flags.bundles, "bundle", []string{}, "Add the guidelines of a bundle by name (repeatable)")
 	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Preview changes without writing files")
 
 	return cmd
@@ -91,7 +104,7 @@ func runAdd(flags addFlags, args []string) error {
 	}
 	defer cleanup()
 
-	selectedGuidelines, err := selectGuidelines(flags, sourceInfo)
+	selectedGuidelines, bundles, err := selectGuidelines(flags, sourceInfo)
 	if err != nil {
 		return err
 	}
@@ -101,7 +114,7 @@ func runAdd(flags addFlags, args []string) error {
 		return nil
 	}
 
-	newSource, err := buildSourceEntry(flags, sourceInfo, selectedGuidelines, cfg)
+	newSource, err := buildSourceEntry(flags, sourceInfo, selectedGuidelines, bundles, cfg)
 	if err != nil {
 		return err
 	}
@@ -142,6 +155,7 @@ func buildSourceEntry(
 	flags addFlags,
 	sourceInfo *source.SourceInfo,
 	selectedGuidelines []config.ManifestGuideline,
+	bundles []config.ProjectBundle,
 	cfg *config.ProjectConfig,
 ) (config.ProjectSource, error) {
 	sourceName := flags.name
@@ -164,14 +178,17 @@ func buildSourceEntry(
 	}
 
 	return config.ProjectSource{
-		Name:       sourceName,
-		Type:       sourceInfo.SourceType,
-		URL:        sourceInfo.URL,
-		Path:       pathToStore,
-		Ref:        sourceInfo.Ref,
-		Commit:     sourceInfo.Commit,
-		Guidelines: config.ManifestGuidelinesToProject(selectedGuidelines),
-		Prompts:    selectedPrompts,
+		Name:               sourceName,
+		Type:               sourceInfo.SourceType,
+		URL:                sourceInfo.URL,
+		Path:               pathToStore,
+		Ref:                sourceInfo.Ref,
+		Commit:             sourceInfo.Commit,
+		Guidelines:         config.ManifestGuidelinesToProject(selectedGuidelines),
+		Prompts:            selectedPrompts,
+		Variables:          sourceInfo.Manifest.Variables,
+		Bundles:            bundles,
+		RequiredGuidelines: config.RequiredGuidelineNames(sourceInfo.Manifest.Guidelines),
 	}, nil
 }
 
@@ -188,6 +205,19 @@ func addSourceToProject(
 		return fmt.Errorf("failed to copy files: %w", err)
 	}
 
+	assets, err := files.ExpandAssets(sourceInfo.SourceDir, selectedGuidelines)
+	if err != nil {
+		return fmt.Errorf("failed to copy files: %w", err)
+	}
+	if err := files.CopyAssets(sourceInfo.SourceDir, destDir, assets); err != nil {
+		return fmt.Errorf("failed to copy files: %w", err)
+	}
+	newSource.AssetFiles = assets
+
+	if err := checkLinks(cfg, sourceInfo, destDir, selectedGuidelines, assets); err != nil {
+		return err
+	}
+
 	if err := config.AddSource(cfg, newSource); err != nil {
 		return fmt.Errorf("failed to add source: %w", err)
 	}
@@ -200,6 +230,50 @@ func addSourceToProject(
 	return nil
 }
 
+// checkLinks warns about links in copied guidelines and prompts to source files that weren't copied
+// With links.rewrite_upstream set, such links in git sources point at the upstream file at the pinned commit instead
+func checkLinks(
+	cfg *config.ProjectConfig,
+	sourceInfo *source.SourceInfo,
+	destDir string,
+	guidelines []config.ManifestGuideline,
+	assets []string,
+) error {
+	var documents []string
+	for _, g := range guidelines {
+		documents = append(documents, path.Clean(g.File))
+	}
+	for _, p := range sourceInfo.Manifest.Prompts {
+		documents = append(documents, path.Clean(p.File))
+	}
+	installed := append(slices.Clone(documents), assets...)
+
+	var upstream func(string) (string, bool)
+	if cfg.Links.RewriteUpstream && sourceInfo.SourceType == config.SourceTypeGitRepo {
+		upstream = func(file string) (string, bool) {
+			return links.UpstreamURL(sourceInfo.URL, sourceInfo.Commit, file)
+		}
+	}
+
+	missing, err := files.CheckLinks(sourceInfo.SourceDir, destDir, documents, installed, upstream)
+	if err != nil {
+		return fmt.Errorf("failed to check links: %w", err)
+	}
+
+	rewritten := 0
+	for _, link := range missing {
+		if link.Upstream != "" {
+			rewritten++
+			continue
+		}
+		fmt.Println(ui.WarningStyle.Render("⚠"), fmt.Sprintf("%s:%d links to %s, which is not installed", link.File, link.Line, link.Target))
+	}
+	if rewritten > 0 {
+		fmt.Println(ui.InfoStyle.Render("ℹ"), fmt.Sprintf("Rewrote %d links to files that aren't installed to upstream URLs", rewritten))
+	}
+	return nil
+}
+
 func validateAddFlags(flags addFlags, args []string) error {
 	if flags.gitRepo == "" && len(args) == 0 {
 		return fmt.Errorf("must specify either --git-repo or a local path")
@@ -212,6 +286,10 @@ func validateAddFlags(flags addFlags, args []string) error {
 	if flags.all && len(flags.guidelines) > 0 {
 		return fmt.Errorf("cannot use both --all and --guideline flags")
 	}
+
+	if flags.all && len(flags.bundles) > 0 {
+		return fmt.Errorf("cannot use both --all and --bundle flags")
+	}
 	return nil
 }
 
@@ -234,28 +312,59 @@ func fetchSource(flags addFlags, args []string) (*source.SourceInfo, func(), err
 	return sourceInfo, func() {}, nil
 }
 
-func selectGuidelines(flags addFlags, sourceInfo *source.SourceInfo) ([]config.ManifestGuideline, error) {
+func selectGuidelines(flags addFlags, sourceInfo *source.SourceInfo) ([]config.ManifestGuideline, []config.ProjectBundle, error) {
+	manifest := sourceInfo.Manifest
+
 	if flags.all {
-		selected := sourceInfo.Manifest.Guidelines
+		selected := manifest.Guidelines
 		fmt.Println(ui.InfoStyle.Render("ℹ"), "Selected all", len(selected), "guidelines")
-		return selected, nil
+		return selected, nil, nil
 	}
 
-	if len(flags.guidelines) > 0 {
-		selected, err := ui.SelectGuidelinesByName(sourceInfo.Manifest.Guidelines, flags.guidelines)
+	if len(flags.bundles) > 0 || len(flags.guidelines) > 0 {
+		names, bundles, err := config.ResolveBundles(manifest, flags.bundles)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to select bundles: %w", err)
+		}
+		for _, name := range flags.guidelines {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+
+		selected, err := ui.SelectGuidelinesByName(manifest.Guidelines, names)
 		if err != nil {
-			return nil, fmt.Errorf("failed to select guidelines: %w", err)
+			return nil, nil, fmt.Errorf("failed to select guidelines: %w", err)
 		}
 		fmt.Println(ui.InfoStyle.Render("ℹ"), "Selected", len(selected), "guidelines")
-		return selected, nil
+		return selected, bundles, nil
+	}
+
+	return selectGuidelinesInteractively(manifest)
+}
+
+// selectGuidelinesInteractively lets the user pick bundles, if the manifest defines any,
+// and then guidelines, with the guidelines of the chosen bundles pre-selected
+func selectGuidelinesInteractively(manifest *config.Manifest) ([]config.ManifestGuideline, []config.ProjectBundle, error) {
+	var bundleNames []string
+	if len(manifest.Bundles) > 0 {
+		names, err := ui.SelectBundles(manifest.Bundles)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to select bundles: %w", err)
+		}
+		bundleNames = names
+	}
+
+	preSelected, bundles, err := config.ResolveBundles(manifest, bundleNames)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to select bundles: %w", err)
 	}
 
-	// Interactive selection
-	selected, err := ui.SelectGuidelines(sourceInfo.Manifest.Guidelines)
+	selected, err := ui.SelectGuidelines(manifest.Guidelines, preSelected)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select guidelines: %w", err)
+		return nil, nil, fmt.Errorf("failed to select guidelines: %w", err)
 	}
-	return selected, nil
+	return selected, bundles, nil
 }
 
 func printDryRun(newSource config.ProjectSource) {
@@ -271,6 +380,19 @@ func printDryRun(newSource config.ProjectSource) {
 	}
 	fmt.Println("  Guidelines:", len(newSource.Guidelines))
 	fmt.Println("  Prompts:", len(newSource.Prompts))
+	printBundles(newSource.Bundles)
+}
+
+// printBundles lists the bundles remembered for a source, if any
+func printBundles(bundles []config.ProjectBundle) {
+	if len(bundles) == 0 {
+		return
+	}
+	names := make([]string, 0, len(bundles))
+	for _, bundle := range bundles {
+		names = append(names, bundle.Name)
+	}
+	fmt.Println("  Bundles:", strings.Join(names, ", "))
 }
 
 func convertToRelativePath(sourceInfo *source.SourceInfo) (string, error) {
@@ -339,7 +461,7 @@ func checkLocalPathBeforeLoad(flags addFlags, args []string) error {
 	fmt.Println()
 
 	// Ask for confirmation (auto-accept in non-interactive mode)
-	nonInteractive := flags.all || len(flags.guidelines) > 0
+	nonInteractive := flags.all || len(flags.guidelines) > 0 || len(flags.bundles) > 0
 	if !nonInteractive && !ui.Confirm("Continue with absolute path?") {
 		return fmt.Errorf("canceled by user")
 	}
@@ -352,6 +474,7 @@ func printSuccess(newSource config.ProjectSource, destDir string) {
 	fmt.Println(ui.SuccessStyle.Render("✓ Success:"), "Added source", ui.CodeStyle.Render(newSource.Name))
 	fmt.Println("  Guidelines:", len(newSource.Guidelines))
 	fmt.Println("  Prompts:", len(newSource.Prompts))
+	printBundles(newSource.Bundles)
 	fmt.Println("  Files copied to:", ui.CodeStyle.Render(destDir))
 	fmt.Println()
 	fmt.Println(ui.SubtleStyle.Render("Next steps:"))
@@ -165,3 +165,54 @@ func TestCheckLocalPathBeforeLoad_GitRepo(t *testing.T) {
 		t.Errorf("expected no error for git repo, got: %v", err)
 	}
 }
+
+func TestCheckLinks_RewriteUpstream(t *testing.T) {
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	content := "See [review](../prompts/review.md) and [rust](rust.md#traits).\n"
+	for file, data := range map[string]string{
+		filepath.Join(sourceDir, "guidelines", "go.md"):   content,
+		filepath.Join(sourceDir, "guidelines", "rust.md"): "# Rust",
+		filepath.Join(sourceDir, "prompts", "review.md"):  "# Review",
+		filepath.Join(destDir, "guidelines", "go.md"):     content,
+		filepath.Join(destDir, "prompts", "review.md"):    "# Review",
+	} {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sourceInfo := &source.SourceInfo{
+		SourceType: config.SourceTypeGitRepo,
+		URL:        "https://github.com/acme/dna.git",
+		Commit:     "abc123",
+		SourceDir:  sourceDir,
+		Manifest: &config.Manifest{
+			Prompts: []config.ManifestPrompt{{Name: "review", File: "prompts/review.md"}},
+		},
+	}
+	guidelines := []config.ManifestGuideline{{Name: "go-style", File: "guidelines/go.md"}}
+
+	// Without the option links are only reported
+	if err := checkLinks(&config.ProjectConfig{}, sourceInfo, destDir, guidelines, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(destDir, "guidelines", "go.md"))
+	if string(got) != content {
+		t.Errorf("expected file unchanged, got %q", got)
+	}
+
+	cfg := &config.ProjectConfig{Links: config.LinkOptions{RewriteUpstream: true}}
+	if err := checkLinks(cfg, sourceInfo, destDir, guidelines, nil); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	got, _ = os.ReadFile(filepath.Join(destDir, "guidelines", "go.md"))
+	expected := "See [review](../prompts/review.md) and [rust](https://github.com/acme/dna/blob/abc123/guidelines/rust.md#traits).\n"
+	if string(got) != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -4,36 +4,47 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aviator5/dnaspec/internal/core/agents"
 	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/paths"
 	"github.com/aviator5/dnaspec/internal/ui"
 )
 
 // NewListCmd creates the list command for displaying project configuration
 func NewListCmd() *cobra.Command {
+	var tag string
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Display configured DNA sources, guidelines, prompts, and agents",
 		Long: `Display all configured DNA sources with their metadata, including:
-- Configured AI agents (Antigravity, Claude Code, Cursor, GitHub Copilot, Windsurf)
+- Configured AI agents (Antigravity, Claude Code, Cline, Cursor,
+  Gemini CLI, GitHub Copilot, Kilo Code, Kiro, Roo Code, Windsurf)
 - DNA sources with type-specific metadata (URL/path, ref, commit)
-- Guidelines and prompts for each source
+- Guidelines grouped by tag (with owners, version, review date, asset files and
+  deprecation notices) and prompts for each source
 
 This command provides a quick overview of the current DNA configuration.`,
 		Example: `  # Display current configuration
-  dnaspec list`,
+  dnaspec list
+
+  # Only show guidelines tagged security
+  dnaspec list --tag security`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList()
+			return runList(tag)
 		},
 	}
 
+	cmd.Flags().StringVar(&tag, "tag", "", "Only show guidelines with this tag")
+
 	return cmd
 }
 
-func runList() error {
+func runList(tag string) error {
 	// Load project configuration
 	cfg, err := config.LoadProjectConfig(projectConfigFileName)
 	if err != nil {
@@ -54,7 +65,7 @@ func runList() error {
 
 	// Display sources
 	fmt.Println()
-	displaySources(cfg)
+	displaySources(cfg, tag)
 
 	return nil
 }
@@ -77,7 +88,7 @@ func displayAgents(cfg *config.ProjectConfig) {
 	}
 }
 
-func displaySources(cfg *config.ProjectConfig) {
+func displaySources(cfg *config.ProjectConfig, tag string) {
 	fmt.Println("Sources:")
 	if len(cfg.Sources) == 0 {
 		fmt.Println("  No sources configured")
@@ -115,7 +126,7 @@ func displaySources(cfg *config.ProjectConfig) {
 		}
 
 		// Display guidelines
-		displayGuidelines(*source)
+		displayGuidelines(*source, tag)
 
 		// Display prompts
 		displayPrompts(*source)
@@ -127,15 +138,63 @@ func displaySources(cfg *config.ProjectConfig) {
 	}
 }
 
-func displayGuidelines(source config.ProjectSource) {
+// displayGuidelines lists the guidelines of a source grouped by their first tag, optionally only those with tag
+func displayGuidelines(source config.ProjectSource, tag string) {
+	guidelines := config.ProjectGuidelinesToManifest(source.Guidelines)
+	if tag != "" {
+		guidelines = config.FilterByTag(guidelines, tag)
+	}
+
 	fmt.Println()
 	fmt.Println("  Guidelines:")
-	if len(source.Guidelines) == 0 {
+	if len(guidelines) == 0 {
 		fmt.Println("    None")
-	} else {
-		for _, guideline := range source.Guidelines {
-			fmt.Printf("    - %s: %s\n", guideline.Name, guideline.Description)
+		return
+	}
+
+	groups := config.GroupByTag(guidelines)
+	for _, group := range groups {
+		// Only show group headings when some guidelines are tagged
+		if len(groups) > 1 || group.Tag != config.Untagged {
+			fmt.Println("    " + ui.SubtleStyle.Render(group.Tag+":"))
+		}
+		for _, guideline := range group.Guidelines {
+			displayGuideline(source, config.ManifestGuidelineToProject(guideline))
+		}
+	}
+}
+
+// displayGuideline prints a guideline with its metadata
+func displayGuideline(source config.ProjectSource, guideline config.ProjectGuideline) {
+	fmt.Printf("    - %s: %s\n", guideline.Name, guideline.Description)
+	if guideline.Deprecated != nil {
+		fmt.Printf("      %s\n", ui.WarningStyle.Render("⚠ "+config.DeprecationNotice(guideline.Name, guideline.Deprecated)))
+	}
+	if len(guideline.Tags) > 0 {
+		fmt.Printf("      Tags: %s\n", strings.Join(guideline.Tags, ", "))
+	}
+	if len(guideline.Owners) > 0 {
+		fmt.Printf("      Owners: %s\n", strings.Join(guideline.Owners, ", "))
+	}
+	if guideline.Version != "" {
+		fmt.Printf("      Version: %s\n", guideline.Version)
+	}
+	if guideline.LastReviewed != "" {
+		fmt.Printf("      Last reviewed: %s\n", guideline.LastReviewed)
+	}
+	displayAssets(source, guideline)
+}
+
+// displayAssets lists the asset patterns of a guideline with the number of files each copied
+func displayAssets(source config.ProjectSource, guideline config.ProjectGuideline) {
+	for _, pattern := range guideline.Assets {
+		count := 0
+		for _, file := range source.AssetFiles {
+			if paths.MatchGlob(pattern, file) {
+				count++
+			}
 		}
+		fmt.Printf("      Assets: %s %s\n", pattern, ui.SubtleStyle.Render(fmt.Sprintf("(%d files)", count)))
 	}
 }
 
@@ -354,3 +354,79 @@ func TestListCommand_Integration(t *testing.T) {
 	err = cmd.Execute()
 	assert.NoError(t, err)
 }
+
+func TestListCommand_GuidelineAssets(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "company-dna",
+				Type: config.SourceTypeLocalPath,
+				Path: "company-dna",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:        "go-style",
+						File:        "guidelines/go-style.md",
+						Description: "Go code style conventions",
+						Assets:      []string{"guidelines/go-style/**/*.go"},
+					},
+					{
+						Name:        "go-legacy",
+						File:        "guidelines/go-legacy.md",
+						Description: "Legacy Go conventions",
+						Deprecated:  &config.Deprecation{Message: "superseded", Replacement: "go-style"},
+					},
+				},
+				AssetFiles: []string{"guidelines/go-style/examples/main.go", "guidelines/go-style/diagram.png"},
+			},
+		},
+	}
+
+	err := config.SaveProjectConfig(projectConfigFileName, cfg)
+	require.NoError(t, err)
+
+	cmd := NewListCmd()
+	err = cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestListCommand_GuidelineTags(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "company-dna",
+				Type: config.SourceTypeLocalPath,
+				Path: "company-dna",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:         "go-style",
+						File:         "guidelines/go-style.md",
+						Description:  "Go code style conventions",
+						Tags:         []string{"go", "style"},
+						Owners:       []string{"@platform-team"},
+						Version:      "2.1",
+						LastReviewed: "2026-09-01",
+					},
+					{Name: "sql", File: "guidelines/sql.md", Description: "SQL conventions", Tags: []string{"data"}},
+					{Name: "readme", File: "guidelines/readme.md", Description: "README conventions"},
+				},
+			},
+		},
+	}
+	require.NoError(t, config.SaveProjectConfig(projectConfigFileName, cfg))
+
+	assert.NoError(t, runList(""))
+	assert.NoError(t, runList("style"))
+	assert.NoError(t, runList("security"))
+}
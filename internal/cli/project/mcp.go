@@ -0,0 +1,50 @@
+package project
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/aviator5/dnaspec/internal/cli"
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/mcp"
+	"github.com/spf13/cobra"
+)
+
+// NewMCPCmd creates the mcp command for serving guidelines over the Model Context Protocol
+func NewMCPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve project guidelines and prompts as an MCP server over stdio",
+		Long: `Serve the guidelines and prompts configured in dnaspec.yaml as a Model Context Protocol
+(MCP) server over stdio.
+
+Instead of reading generated agent files, MCP-capable tools can connect to this server to:
+- List and read guidelines as resources (descriptions include applicable scenarios)
+- Use prompts as MCP prompts, with an optional "input" argument
+- Call the find_guidelines tool to discover guidelines by task description or file path
+
+The server reads files from the dnaspec/ directory and must be started from the project root.
+Stdout carries protocol messages only; errors are written to stderr.`,
+		Example: `  # Start the server (normally launched by the MCP client)
+  dnaspec mcp
+
+  # Example MCP client configuration
+  {"mcpServers": {"dnaspec": {"command": "dnaspec", "args": ["mcp"]}}}`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMCP(cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	return cmd
+}
+
+func runMCP(in io.Reader, out io.Writer) error {
+	// Stdout is the protocol channel, so nothing else may be printed to it
+	cfg, err := config.LoadProjectConfig(projectConfigFileName)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", projectConfigFileName, err)
+	}
+
+	server := mcp.NewServer(cfg, ".", cli.Version)
+	return server.Serve(in, out)
+}
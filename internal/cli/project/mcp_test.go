@@ -0,0 +1,77 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPCommand_ServesProjectGuidelines(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	err := os.MkdirAll(filepath.Join("dnaspec", "test-source", "guidelines"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join("dnaspec", "test-source", "guidelines", "test.md"), []byte("# Test\n"), 0644)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Type: config.SourceTypeLocalPath,
+				Guidelines: []config.ProjectGuideline{
+					{Name: "test-guideline", File: "guidelines/test.md", Description: "Test", ApplicableScenarios: []string{"testing"}},
+				},
+			},
+		},
+	}
+	err = config.SaveProjectConfig("dnaspec.yaml", cfg)
+	require.NoError(t, err)
+
+	in := strings.NewReader(strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"dnaspec://test-source/guidelines/test-guideline"}}`,
+	}, "\n"))
+	var out bytes.Buffer
+
+	err = runMCP(in, &out)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2, "notifications must not get a response")
+
+	var resp struct {
+		Result struct {
+			Contents []struct {
+				Text string `json:"text"`
+			} `json:"contents"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &resp))
+	require.Len(t, resp.Result.Contents, 1)
+	assert.Equal(t, "# Test\n", resp.Result.Contents[0].Text)
+}
+
+func TestMCPCommand_MissingConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	var out bytes.Buffer
+	err := runMCP(strings.NewReader(""), &out)
+	assert.Error(t, err)
+	assert.Empty(t, out.String(), "nothing may be written to the protocol channel")
+}
@@ -24,8 +24,8 @@ func NewRemoveCmd() *cobra.Command {
 
 This command removes the source from dnaspec.yaml, deletes the source
 directory and all guideline files, and cleans up generated agent files
-for all supported agents (Antigravity, Claude Code, Cursor, GitHub Copilot,
-and Windsurf).
+for all supported agents (Antigravity, Claude Code, Cline, Cursor, Gemini CLI,
+GitHub Copilot, Kilo Code, Kiro, Roo Code, and Windsurf).
 
 By default, this command will show what will be deleted and ask for
 confirmation before proceeding. Use --force to skip the confirmation.`,
@@ -54,7 +54,7 @@ func runRemove(sourceName string, force bool) error {
 	}
 
 	// Display impact
-	displayImpact(sourceName)
+	displayImpact(cfg, sourceName)
 
 	// Confirmation prompt (unless --force is set)
 	if !force {
@@ -130,7 +130,7 @@ func confirmRemoval() (bool, error) {
 
 func performRemoval(cfg *config.ProjectConfig, sourceName string, sourceIndex int) error {
 	// Delete generated agent files
-	agentDeletedCount, err := deleteAgentGeneratedFiles(sourceName)
+	agentDeletedCount, err := deleteAgentGeneratedFiles(cfg, sourceName)
 	if err != nil {
 		return fmt.Errorf("failed to delete generated files: %w", err)
 	}
@@ -161,7 +161,7 @@ func performRemoval(cfg *config.ProjectConfig, sourceName string, sourceIndex in
 	return nil
 }
 
-func displayImpact(sourceName string) {
+func displayImpact(cfg *config.ProjectConfig, sourceName string) {
 	fmt.Println(ui.SubtleStyle.Render("\nThe following will be deleted:"))
 
 	// Config entry
@@ -193,8 +193,7 @@ func displayImpact(sourceName string) {
 
 	// Agent-generated files
 	for _, pattern := range agents.AgentFilePatterns {
-		globPattern := pattern.GetFilePatternForSource(sourceName)
-		files, err := filepath.Glob(globPattern)
+		files, err := pattern.GetFilesForSource(cfg, sourceName)
 		if err == nil && len(files) > 0 {
 			displayPattern := pattern.GetDisplayPatternForSource(sourceName)
 			fmt.Printf("  - %s (%d files)\n", displayPattern, len(files))
@@ -202,16 +201,16 @@ func displayImpact(sourceName string) {
 	}
 }
 
-func deleteAgentGeneratedFiles(sourceName string) (int, error) {
+func deleteAgentGeneratedFiles(cfg *config.ProjectConfig, sourceName string) (int, error) {
 	deletedCount := 0
 
-	// Delete all agent-generated files
+	// Delete all agent-generated files, leaving those of sources whose name extends this one
 	for _, pattern := range agents.AgentFilePatterns {
-		globPattern := pattern.GetFilePatternForSource(sourceName)
-		files, err := filepath.Glob(globPattern)
+		files, err := pattern.GetFilesForSource(cfg, sourceName)
 		if err == nil {
 			for _, file := range files {
-				if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				// RemoveAll also handles generated directories such as Claude skills
+				if err := os.RemoveAll(file); err != nil {
 					return deletedCount, fmt.Errorf("failed to delete %s: %w", file, err)
 				}
 				deletedCount++
@@ -431,8 +431,18 @@ func TestRemoveCommand_AllAgentFiles(t *testing.T) {
 	}{
 		{".agent/workflows", "dnaspec-test-source-workflow.md"},
 		{".claude/commands/dnaspec", "test-source-command.md"},
+		{".claude/skills/dnaspec-test-source-guideline", "SKILL.md"},
+		{".clinerules", "dnaspec-test-source-rule.md"},
+		{".clinerules/workflows", "dnaspec-test-source-workflow.md"},
 		{".cursor/commands", "dnaspec-test-source-cursor.md"},
+		{".gemini/commands/dnaspec", "test-source-command.toml"},
 		{".github/prompts", "dnaspec-test-source-prompt.prompt.md"},
+		{".github/instructions", "dnaspec-test-source-guideline.instructions.md"},
+		{".kilocode/rules", "dnaspec-test-source-rule.md"},
+		{".kilocode/workflows", "dnaspec-test-source-workflow.md"},
+		{".kiro/steering", "dnaspec-test-source-steering.md"},
+		{".roo/rules", "dnaspec-test-source-rule.md"},
+		{".roo/commands", "dnaspec-test-source-command.md"},
 		{".windsurf/workflows", "dnaspec-test-source-windsurf.md"},
 	}
 
@@ -452,6 +462,7 @@ func TestRemoveCommand_AllAgentFiles(t *testing.T) {
 		_, err = os.Stat(filepath.Join(af.dir, af.file))
 		assert.True(t, os.IsNotExist(err), "File %s should be deleted", filepath.Join(af.dir, af.file))
 	}
+	assert.NoDirExists(t, ".claude/skills/dnaspec-test-source-guideline", "Skill directory should be deleted")
 }
 
 func TestRemoveCommand_CursorFiles(t *testing.T) {
@@ -570,3 +581,46 @@ func TestRemoveCommand_AntigravityFiles(t *testing.T) {
 	files, _ := filepath.Glob(filepath.Join(antigravityDir, "dnaspec-test-source-*.md"))
 	assert.Equal(t, 0, len(files))
 }
+
+func TestRemoveCommand_PreservesSourceWithExtendedName(t *testing.T) {
+	// Create temp directory
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	// Create configuration where one source name extends the other
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "foo",
+				Type: "git-repo",
+				URL:  "https://github.com/test/foo",
+			},
+			{
+				Name: "foo-bar",
+				Type: "git-repo",
+				URL:  "https://github.com/test/foo-bar",
+			},
+		},
+	}
+	err := config.SaveProjectConfig(projectConfigFileName, cfg)
+	require.NoError(t, err)
+
+	// Create Claude skills for both sources
+	for _, dir := range []string{".claude/skills/dnaspec-foo-style", ".claude/skills/dnaspec-foo-bar-style"} {
+		err = os.MkdirAll(dir, 0755)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("skill"), 0644)
+		require.NoError(t, err)
+	}
+
+	// Run remove with --force
+	err = runRemove("foo", true)
+	assert.NoError(t, err)
+
+	// Verify only the skill of the removed source was deleted
+	assert.NoDirExists(t, ".claude/skills/dnaspec-foo-style")
+	assert.FileExists(t, ".claude/skills/dnaspec-foo-bar-style/SKILL.md")
+}
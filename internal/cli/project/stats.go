@@ -0,0 +1,102 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/stats"
+	"github.com/aviator5/dnaspec/internal/ui"
+)
+
+// NewStatsCmd creates the stats command for reporting context size
+func NewStatsCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Estimate tokens and bytes of guidelines, prompts and generated agent files",
+		Long: `Estimate the size of the context DNASpec adds to AI agents.
+
+Reports bytes and approximate tokens for:
+- Each guideline and prompt in dnaspec/
+- Each generated agent file (AGENTS.md, CLAUDE.md, rules, commands, ...)
+
+Token counts come from a built-in approximate tokenizer (about four characters
+per token for English text and code), so they are suitable for budgeting but
+differ slightly from any specific model's tokenizer.
+
+Entries above the budgets configured in dnaspec.yaml are flagged; 'dnaspec validate'
+reports them as warnings.`,
+		Example: `  # Show a size report
+  dnaspec stats
+
+  # Emit the report as JSON, e.g. to track growth in CI
+  dnaspec stats --json > dnaspec-stats.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(cmd.OutOrStdout(), jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the report as JSON")
+
+	return cmd
+}
+
+func runStats(out io.Writer, jsonOutput bool) error {
+	cfg, err := config.LoadProjectConfig(projectConfigFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("project configuration not found: run 'dnaspec init' first")
+		}
+		return fmt.Errorf("failed to load project configuration: %w", err)
+	}
+
+	report, err := stats.Collect(cfg)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	displayStats(report, cfg.Budgets)
+	return nil
+}
+
+func displayStats(report *stats.Report, budgets config.BudgetOptions) {
+	displayStatsSection("Guidelines", report.Guidelines, report.Totals.Guidelines, budgets.GuidelineLimit())
+	displayStatsSection("Prompts", report.Prompts, report.Totals.Prompts, budgets.PromptLimit())
+	displayStatsSection("Agent files", report.AgentFiles, report.Totals.AgentFiles, budgets.AgentFileLimit())
+
+	warnings := report.Warnings(budgets)
+	if len(warnings) > 0 {
+		fmt.Println(ui.WarningStyle.Render("⚠"), len(warnings), "item(s) over budget")
+	}
+}
+
+func displayStatsSection(title string, entries []stats.Entry, total stats.Size, limit int) {
+	fmt.Printf("%s (budget ~%d tokens each):\n", title, limit)
+	if len(entries) == 0 {
+		fmt.Println("  None")
+		fmt.Println()
+		return
+	}
+
+	for _, entry := range entries {
+		line := fmt.Sprintf("  %8d tokens %9d bytes  %s", entry.Tokens, entry.Bytes, entry.Path)
+		if entry.Tokens > limit {
+			line = ui.WarningStyle.Render(line + "  ⚠ over budget")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("  %8d tokens %9d bytes  total", total.Tokens, total.Bytes)))
+	fmt.Println()
+}
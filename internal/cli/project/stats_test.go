@@ -0,0 +1,69 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/stats"
+)
+
+func TestStatsCommand_JSON(t *testing.T) {
+	// Create temp directory for test
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	err := os.MkdirAll(filepath.Join("dnaspec", "test-source", "guidelines"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join("dnaspec", "test-source", "guidelines", "test.md"), []byte("# Test\n"), 0644)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name:       "test-source",
+				Type:       config.SourceTypeLocalPath,
+				Guidelines: []config.ProjectGuideline{{Name: "test-guideline", File: "guidelines/test.md"}},
+			},
+		},
+	}
+	err = config.SaveProjectConfig("dnaspec.yaml", cfg)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = runStats(&out, true)
+	require.NoError(t, err)
+
+	var report stats.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Guidelines, 1)
+	assert.Equal(t, "test-guideline", report.Guidelines[0].Name)
+	assert.Equal(t, 7, report.Guidelines[0].Bytes)
+	assert.Equal(t, 7, report.Totals.Guidelines.Bytes)
+
+	// Text output goes to stdout
+	err = runStats(&out, false)
+	assert.NoError(t, err)
+}
+
+func TestStatsCommand_MissingConfig(t *testing.T) {
+	// Create temp directory for test
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	var out bytes.Buffer
+	err := runStats(&out, true)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "project configuration not found")
+}
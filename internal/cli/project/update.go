@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/aviator5/dnaspec/internal/core/files"
@@ -27,7 +28,12 @@ func NewUpdateCmd() *cobra.Command {
 		Long: `Update a DNA source from its origin (git repository or local directory).
 
 This command fetches the latest manifest from the source and presents an interactive
-multi-select UI to choose which guidelines to keep, add, or remove.`,
+multi-select UI to choose which guidelines to keep, add, or remove.
+
+Guidelines added to a bundle the source was added with (dnaspec add --bundle)
+are selected automatically, as are guidelines that selected guidelines require.
+Guidelines the manifest renamed (listed under aliases) are migrated to their new
+name, and selected guidelines that are deprecated are reported.`,
 		Example: `  # Update a source with interactive selection
   dnaspec update my-company-dna
 
@@ -81,22 +87,23 @@ func updateSingleSource(cfg *config.ProjectConfig, sourceName string, flags upda
 	if cleanup != nil {
 		defer cleanup()
 	}
-	if upToDate {
+	if upToDate && len(missingRequiredGuidelines(src)) == 0 {
 		showAlreadyUpToDate(src)
 		return nil
 	}
 
 	// Compare current vs latest
 	comparison := config.CompareGuidelines(src.Guidelines, sourceInfo.Manifest.Guidelines)
+	bundleAdditions := config.NewBundleGuidelines(src, sourceInfo.Manifest)
 
 	// Dry run check - show preview without interactive selection
 	if flags.dryRun {
-		showDryRunPreview(sourceInfo, comparison)
+		showDryRunPreview(sourceInfo, comparison, bundleAdditions)
 		return nil
 	}
 
 	// Interactive guideline selection
-	selectedNames, err := performGuidelineSelection(src, comparison, sourceInfo)
+	selectedNames, err := performGuidelineSelection(src, comparison, sourceInfo, bundleAdditions)
 	if err != nil {
 		return fmt.Errorf("guideline selection canceled or failed: %w", err)
 	}
@@ -120,7 +127,7 @@ func showAlreadyUpToDate(src *config.ProjectSource) {
 	fmt.Println("\nAll guidelines up to date.")
 }
 
-func showDryRunPreview(sourceInfo *source.SourceInfo, comparison config.GuidelineComparison) {
+func showDryRunPreview(sourceInfo *source.SourceInfo, comparison config.GuidelineComparison, bundleAdditions []config.BundleAddition) {
 	fmt.Println(ui.InfoStyle.Render("\n=== Dry Run - Preview ==="))
 	fmt.Println("\nAvailable guidelines in source:")
 	for _, g := range sourceInfo.Manifest.Guidelines {
@@ -137,6 +144,20 @@ func showDryRunPreview(sourceInfo *source.SourceInfo, comparison config.Guidelin
 		}
 	}
 
+	if len(comparison.Renamed) > 0 {
+		fmt.Println("\nRenamed (would be migrated):")
+		for _, rename := range comparison.Renamed {
+			fmt.Println(ui.InfoStyle.Render("  →"), rename.From, "→", rename.To)
+		}
+	}
+
+	if len(bundleAdditions) > 0 {
+		fmt.Println("\nNew in bundles (would be added):")
+		for _, addition := range bundleAdditions {
+			fmt.Println(ui.InfoStyle.Render("  +"), addition.Guideline, ui.SubtleStyle.Render("(bundle "+addition.Bundle+")"))
+		}
+	}
+
 	if len(comparison.Removed) > 0 {
 		fmt.Println("\nOrphaned (in config but not in source):")
 		for _, name := range comparison.Removed {
@@ -151,12 +172,27 @@ func performGuidelineSelection(
 	src *config.ProjectSource,
 	comparison config.GuidelineComparison,
 	sourceInfo *source.SourceInfo,
+	bundleAdditions []config.BundleAddition,
 ) ([]string, error) {
 	// Build lists for selection
 	var existingNames []string
 	existingNames = append(existingNames, comparison.Unchanged...)
 	existingNames = append(existingNames, comparison.Updated...)
 
+	// Select renamed guidelines under their new name
+	for _, rename := range comparison.Renamed {
+		fmt.Println(ui.InfoStyle.Render("ℹ"), fmt.Sprintf("Migrating '%s' to '%s' (renamed in the manifest)", rename.From, rename.To))
+		if !slices.Contains(existingNames, rename.To) {
+			existingNames = append(existingNames, rename.To)
+		}
+	}
+
+	// Pre-select guidelines newly added to remembered bundles
+	for _, addition := range bundleAdditions {
+		fmt.Println(ui.InfoStyle.Render("ℹ"), fmt.Sprintf("Adding '%s' (new in bundle '%s')", addition.Guideline, addition.Bundle))
+		existingNames = append(existingNames, addition.Guideline)
+	}
+
 	var orphanedGuidelines []config.ProjectGuideline
 	for _, g := range src.Guidelines {
 		for _, removedName := range comparison.Removed {
@@ -168,11 +204,17 @@ func performGuidelineSelection(
 	}
 
 	// Call interactive selection
-	return ui.SelectGuidelinesWithStatus(
+	selected, err := ui.SelectGuidelinesWithStatus(
 		sourceInfo.Manifest.Guidelines,
 		existingNames,
 		orphanedGuidelines,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the guidelines the selection requires, including ones the latest manifest made required
+	return ui.AddRequiredGuidelines(sourceInfo.Manifest.Guidelines, selected, existingNames), nil
 }
 
 func fetchAndCheckSource(src *config.ProjectSource) (info *source.SourceInfo, cleanup func(), upToDate bool, err error) {
@@ -185,7 +227,7 @@ func fetchAndCheckSource(src *config.ProjectSource) (info *source.SourceInfo, cl
 
 		// Check if commit changed
 		if info.Commit == src.Commit {
-			return nil, cleanup, true, nil
+			return info, cleanup, true, nil
 		}
 
 		fmt.Println(ui.SuccessStyle.Render("✓ Current commit:"), src.Commit[:8])
@@ -222,28 +264,30 @@ func applyUpdate(cfg *config.ProjectConfig, src *config.ProjectSource, sourceInf
 	updatedSource := *src
 
 	// Build updated guidelines list from selected names
-	var updatedGuidelines []config.ProjectGuideline
+	var manifestGuidelines []config.ManifestGuideline
 	for _, name := range selectedNames {
 		manifestGuideline := findManifestGuideline(sourceInfo.Manifest, name)
 		if manifestGuideline != nil {
-			updatedGuidelines = append(updatedGuidelines, config.ProjectGuideline{
-				Name:                manifestGuideline.Name,
-				File:                manifestGuideline.File,
-				Description:         manifestGuideline.Description,
-				ApplicableScenarios: manifestGuideline.ApplicableScenarios,
-				Prompts:             manifestGuideline.Prompts,
-			})
+			manifestGuidelines = append(manifestGuidelines, *manifestGuideline)
+			if manifestGuideline.Deprecated != nil {
+				fmt.Println(ui.WarningStyle.Render("⚠"), config.DeprecationNotice(name, manifestGuideline.Deprecated))
+			}
 		}
 	}
 
-	updatedSource.Guidelines = updatedGuidelines
+	updatedSource.Guidelines = config.ManifestGuidelinesToProject(manifestGuidelines)
 
 	// Extract and update prompts
-	manifestGuidelines := make([]config.ManifestGuideline, 0, len(updatedGuidelines))
-	for _, g := range updatedGuidelines {
-		manifestGuidelines = append(manifestGuidelines, config.ManifestGuideline(g))
-	}
 	updatedSource.Prompts = config.ExtractReferencedPrompts(manifestGuidelines, sourceInfo.Manifest.Prompts)
+	updatedSource.Variables = sourceInfo.Manifest.Variables
+	updatedSource.RequiredGuidelines = config.RequiredGuidelineNames(sourceInfo.Manifest.Guidelines)
+
+	// Record the current contents of the remembered bundles
+	bundles, removedBundles := config.RefreshBundles(src.Bundles, sourceInfo.Manifest)
+	updatedSource.Bundles = bundles
+	for _, name := range removedBundles {
+		fmt.Println(ui.WarningStyle.Render("⚠"), fmt.Sprintf("Bundle '%s' is no longer in the manifest, its guidelines are kept", name))
+	}
 
 	// Update commit hash for git sources
 	if src.Type == "git-repo" {
@@ -251,9 +295,13 @@ func applyUpdate(cfg *config.ProjectConfig, src *config.ProjectSource, sourceInf
 	}
 
 	// Copy files
-	destDir := filepath.Join("dnaspec", src.Name)
-	if err := files.CopyGuidelineFiles(sourceInfo.SourceDir, destDir, manifestGuidelines, sourceInfo.Manifest.Prompts); err != nil {
-		return fmt.Errorf("failed to copy files: %w", err)
+	assets, err := copySourceFiles(src, sourceInfo, manifestGuidelines)
+	if err != nil {
+		return err
+	}
+	updatedSource.AssetFiles = assets
+	if err := checkLinks(cfg, sourceInfo, filepath.Join("dnaspec", src.Name), manifestGuidelines, assets); err != nil {
+		return err
 	}
 
 	// Update config
@@ -277,6 +325,40 @@ func applyUpdate(cfg *config.ProjectConfig, src *config.ProjectSource, sourceInf
 
 // Helper functions
 
+// copySourceFiles copies the guideline, prompt and asset files of an updated source
+// Assets copied by earlier updates that are no longer needed are removed first
+// Returns the copied asset files
+func copySourceFiles(src *config.ProjectSource, sourceInfo *source.SourceInfo, guidelines []config.ManifestGuideline) ([]string, error) {
+	destDir := filepath.Join("dnaspec", src.Name)
+
+	assets, err := files.ExpandAssets(sourceInfo.SourceDir, guidelines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy files: %w", err)
+	}
+	if err := files.PruneAssets(destDir, src.AssetFiles, assets); err != nil {
+		return nil, fmt.Errorf("failed to prune assets: %w", err)
+	}
+
+	if err := files.CopyGuidelineFiles(sourceInfo.SourceDir, destDir, guidelines, sourceInfo.Manifest.Prompts); err != nil {
+		return nil, fmt.Errorf("failed to copy files: %w", err)
+	}
+	if err := files.CopyAssets(sourceInfo.SourceDir, destDir, assets); err != nil {
+		return nil, fmt.Errorf("failed to copy files: %w", err)
+	}
+	return assets, nil
+}
+
+// missingRequiredGuidelines returns the guidelines the source requires in every project that aren't selected
+func missingRequiredGuidelines(src *config.ProjectSource) []string {
+	var missing []string
+	for _, name := range src.RequiredGuidelines {
+		if !slices.ContainsFunc(src.Guidelines, func(g config.ProjectGuideline) bool { return g.Name == name }) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
 func findManifestGuideline(manifest *config.Manifest, name string) *config.ManifestGuideline {
 	for i := range manifest.Guidelines {
 		if manifest.Guidelines[i].Name == name {
@@ -23,15 +23,24 @@ func NewUpdateAgentsCmd() *cobra.Command {
 		Long: `Configure AI agents and generate agent integration files.
 
 This command allows you to select which AI agents to integrate with (Antigravity, Claude Code,
-Cursor, GitHub Copilot, Windsurf) and generates the necessary files for each agent:
+Cline, Cursor, Gemini CLI, GitHub Copilot, Kilo Code, Kiro, Roo Code, Windsurf) and generates the
+necessary files for each agent:
 
 - AGENTS.md: Context-aware guideline references for all AI agents
 - CLAUDE.md: Same as AGENTS.md, for Claude Code discovery
 - Claude commands: Slash commands in .claude/commands/dnaspec/
+- Claude skills: Skill directories in .claude/skills/ (when claude_code.skills is enabled)
 - Copilot prompts: Prompt files in .github/prompts/
+- Copilot instructions: .github/copilot-instructions.md and scoped guideline files in .github/instructions/
 - Antigravity prompts: Workflow files in .agent/workflows/
 - Windsurf workflows: Workflow files in .windsurf/workflows/
 - Cursor commands: Command files in .cursor/commands/
+- GEMINI.md: Same as AGENTS.md, for Gemini CLI discovery
+- Gemini commands: TOML command files in .gemini/commands/dnaspec/
+- Cline: Rules in .clinerules/ and workflows in .clinerules/workflows/
+- Kilo Code: Rules in .kilocode/rules/ and workflows in .kilocode/workflows/
+- Kiro: Steering files in .kiro/steering/ (guidelines by inclusion mode, prompts as manual steering)
+- Roo Code: Rules in .roo/rules/ and commands in .roo/commands/
 
 Use --no-ask to skip agent selection and use saved configuration.`,
 		RunE: runUpdateAgents,
@@ -64,7 +73,8 @@ func runUpdateAgents(cmd *cobra.Command, args []string) error {
 		}
 
 		// Check if any files were cleaned
-		if !summary.AgentsMDCleaned && !summary.ClaudeMDCleaned {
+		if !summary.AgentsMDCleaned && !summary.ClaudeMDCleaned &&
+			!summary.CopilotInstructionsMDCleaned && !summary.GeminiMDCleaned {
 			fmt.Println(ui.InfoStyle.Render("No DNASPEC blocks found to remove."))
 			fmt.Println(ui.InfoStyle.Render("Run 'dnaspec add' to add guidelines first."))
 			return nil
@@ -78,6 +88,12 @@ func runUpdateAgents(cmd *cobra.Command, args []string) error {
 		if summary.ClaudeMDCleaned {
 			fmt.Println(ui.SuccessStyle.Render("  ✓ CLAUDE.md"))
 		}
+		if summary.CopilotInstructionsMDCleaned {
+			fmt.Println(ui.SuccessStyle.Render("  ✓ .github/copilot-instructions.md"))
+		}
+		if summary.GeminiMDCleaned {
+			fmt.Println(ui.SuccessStyle.Render("  ✓ GEMINI.md"))
+		}
 
 		return nil
 	}
@@ -136,14 +152,26 @@ func displaySummary(summary *agents.GenerationSummary) {
 		fmt.Println(successStyle.Render("  ✓ CLAUDE.md"))
 	}
 
+	if summary.CopilotInstructionsMD {
+		fmt.Println(successStyle.Render("  ✓ .github/copilot-instructions.md"))
+	}
+
 	if summary.ClaudeCommands > 0 {
 		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Claude command(s)", summary.ClaudeCommands)))
 	}
 
+	if summary.ClaudeSkills > 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Claude skill(s)", summary.ClaudeSkills)))
+	}
+
 	if summary.CopilotPrompts > 0 {
 		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Copilot prompt(s)", summary.CopilotPrompts)))
 	}
 
+	if summary.CopilotInstructions > 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Copilot instruction file(s)", summary.CopilotInstructions)))
+	}
+
 	if summary.AntigravityPrompts > 0 {
 		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Antigravity prompt(s)", summary.AntigravityPrompts)))
 	}
@@ -156,6 +184,35 @@ func displaySummary(summary *agents.GenerationSummary) {
 		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Cursor command(s)", summary.CursorCommands)))
 	}
 
+	if summary.GeminiMD {
+		fmt.Println(successStyle.Render("  ✓ GEMINI.md"))
+	}
+
+	if summary.GeminiCommands > 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Gemini command(s)", summary.GeminiCommands)))
+	}
+
+	if summary.KiroSteering > 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Kiro steering file(s)", summary.KiroSteering)))
+	}
+
+	if summary.KiroPromptSteering > 0 {
+		fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d Kiro prompt steering file(s)", summary.KiroPromptSteering)))
+	}
+
+	for _, ruleDirAgent := range agents.RuleDirAgents {
+		agentName := ruleDirAgent.AgentID
+		if agent := agents.GetAgent(ruleDirAgent.AgentID); agent != nil {
+			agentName = agent.DisplayName
+		}
+		if count := summary.RuleFiles[ruleDirAgent.AgentID]; count > 0 {
+			fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d %s rule(s)", count, agentName)))
+		}
+		if count := summary.WorkflowFiles[ruleDirAgent.AgentID]; count > 0 {
+			fmt.Println(successStyle.Render(fmt.Sprintf("  ✓ Generated %d %s workflow(s)", count, agentName)))
+		}
+	}
+
 	if len(summary.Errors) > 0 {
 		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 		fmt.Println(errorStyle.Render(fmt.Sprintf("\n  %d error(s) occurred:", len(summary.Errors))))
@@ -3,22 +3,26 @@ package project
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/aviator5/dnaspec/internal/ui"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func init() {
 	// Mock UI selection for all tests - select all available guidelines
-	ui.SetTestMockSelection(func(available []config.ManifestGuideline, existing []string, orphaned []config.ProjectGuideline) ([]string, error) {
-		var selected []string
-		for _, g := range available {
-			selected = append(selected, g.Name)
-		}
-		return selected, nil
-	})
+	ui.SetTestMockSelection(selectAllGuidelines)
+}
+
+func selectAllGuidelines(available []config.ManifestGuideline, existing []string, orphaned []config.ProjectGuideline) ([]string, error) {
+	var selected []string
+	for _, g := range available {
+		selected = append(selected, g.Name)
+	}
+	return selected, nil
 }
 
 func TestUpdateCommand_Integration(t *testing.T) {
@@ -266,3 +270,203 @@ func TestUpdateCommand_ErrorCases(t *testing.T) {
 		}
 	})
 }
+
+// writeBundleSource writes a local source whose backend-go bundle holds the given guidelines
+func writeBundleSource(t *testing.T, dir string, bundleGuidelines ...string) {
+	t.Helper()
+
+	manifest := "version: 1\nguidelines:\n"
+	for _, name := range []string{"go-style", "go-testing", "grpc", "sql"} {
+		file := filepath.Join(dir, "guidelines", name+".md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte("# "+name+"\n"), 0644))
+		manifest += "  - name: " + name + "\n    file: guidelines/" + name + ".md\n" +
+			"    description: " + name + "\n    applicable_scenarios: [\"testing\"]\n"
+	}
+	manifest += "prompts: []\nbundles:\n  - name: backend-go\n    description: Go services\n    guidelines:\n"
+	for _, name := range bundleGuidelines {
+		manifest += "      - " + name + "\n"
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dnaspec-manifest.yaml"), []byte(manifest), 0644))
+}
+
+func TestUpdateCommand_Bundles(t *testing.T) {
+	projectDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	require.NoError(t, os.Chdir(projectDir))
+	require.NoError(t, runInit())
+
+	sourceDir := filepath.Join(projectDir, "company-dna")
+	writeBundleSource(t, sourceDir, "go-style", "go-testing")
+
+	// Add the bundle together with a guideline outside it
+	err := runAdd(addFlags{bundles: []string{"backend-go"}, guidelines: []string{"sql"}}, []string{sourceDir})
+	require.NoError(t, err)
+
+	cfg, err := config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	src := cfg.Sources[0]
+	assert.Equal(t, []string{"go-style", "go-testing", "sql"}, guidelineNames(src.Guidelines))
+	assert.Equal(t, []config.ProjectBundle{{Name: "backend-go", Guidelines: []string{"go-style", "go-testing"}}}, src.Bundles)
+
+	// Add grpc to the bundle, keep whatever the selection UI pre-selects
+	writeBundleSource(t, sourceDir, "go-style", "go-testing", "grpc")
+	ui.SetTestMockSelection(func(_ []config.ManifestGuideline, existing []string, _ []config.ProjectGuideline) ([]string, error) {
+		return existing, nil
+	})
+	defer ui.SetTestMockSelection(selectAllGuidelines)
+
+	require.NoError(t, updateSingleSource(cfg, src.Name, updateFlags{}))
+
+	cfg, err = config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	src = cfg.Sources[0]
+	assert.ElementsMatch(t, []string{"go-style", "go-testing", "sql", "grpc"}, guidelineNames(src.Guidelines))
+	assert.Equal(t, []string{"go-style", "go-testing", "grpc"}, src.Bundles[0].Guidelines)
+	assert.FileExists(t, filepath.Join("dnaspec", src.Name, "guidelines", "grpc.md"))
+}
+
+func guidelineNames(guidelines []config.ProjectGuideline) []string {
+	names := make([]string, 0, len(guidelines))
+	for _, g := range guidelines {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+func TestUpdateCommand_RequiredGuidelines(t *testing.T) {
+	projectDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	require.NoError(t, os.Chdir(projectDir))
+	require.NoError(t, runInit())
+
+	sourceDir := filepath.Join(projectDir, "company-dna")
+	writeBundleSource(t, sourceDir, "go-style")
+	require.NoError(t, runAdd(addFlags{guidelines: []string{"go-style"}}, []string{sourceDir}))
+
+	// The source starts requiring sql in every project
+	manifestPath := filepath.Join(sourceDir, "dnaspec-manifest.yaml")
+	manifest, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	manifest = []byte(strings.Replace(string(manifest), "file: guidelines/sql.md\n", "file: guidelines/sql.md\n    required: true\n", 1))
+	require.NoError(t, os.WriteFile(manifestPath, manifest, 0644))
+
+	// Even a selection that leaves sql out keeps it
+	ui.SetTestMockSelection(func(_ []config.ManifestGuideline, existing []string, _ []config.ProjectGuideline) ([]string, error) {
+		return existing, nil
+	})
+	defer ui.SetTestMockSelection(selectAllGuidelines)
+
+	cfg, err := config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	require.NoError(t, updateSingleSource(cfg, cfg.Sources[0].Name, updateFlags{}))
+
+	cfg, err = config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	src := cfg.Sources[0]
+	assert.Equal(t, []string{"go-style", "sql"}, guidelineNames(src.Guidelines))
+	assert.Equal(t, []string{"sql"}, src.RequiredGuidelines)
+	assert.Empty(t, missingRequiredGuidelines(&src))
+}
+
+func TestUpdateCommand_RenamedAndDeprecated(t *testing.T) {
+	projectDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	require.NoError(t, os.Chdir(projectDir))
+	require.NoError(t, runInit())
+
+	sourceDir := filepath.Join(projectDir, "company-dna")
+	writeBundleSource(t, sourceDir, "go-style")
+	require.NoError(t, runAdd(addFlags{guidelines: []string{"grpc", "sql"}}, []string{sourceDir}))
+
+	// grpc becomes rpc, sql is deprecated
+	manifestPath := filepath.Join(sourceDir, "dnaspec-manifest.yaml")
+	manifest, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	content := strings.Replace(string(manifest), "- name: grpc\n", "- name: rpc\n    aliases: [grpc]\n", 1)
+	content = strings.Replace(content, "file: guidelines/sql.md\n",
+		"file: guidelines/sql.md\n    deprecated:\n      message: use the data guidelines\n", 1)
+	require.NoError(t, os.WriteFile(manifestPath, []byte(content), 0644))
+
+	cfg, err := config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	comparison := config.CompareGuidelines(cfg.Sources[0].Guidelines, mustLoadManifest(t, manifestPath).Guidelines)
+	assert.Equal(t, []config.Rename{{From: "grpc", To: "rpc"}}, comparison.Renamed)
+	assert.Empty(t, comparison.Removed)
+
+	// Keep what the selection UI pre-selects
+	ui.SetTestMockSelection(func(_ []config.ManifestGuideline, existing []string, _ []config.ProjectGuideline) ([]string, error) {
+		return existing, nil
+	})
+	defer ui.SetTestMockSelection(selectAllGuidelines)
+	require.NoError(t, updateSingleSource(cfg, cfg.Sources[0].Name, updateFlags{}))
+
+	cfg, err = config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	src := cfg.Sources[0]
+	assert.ElementsMatch(t, []string{"rpc", "sql"}, guidelineNames(src.Guidelines))
+	for _, g := range src.Guidelines {
+		if g.Name == "sql" {
+			require.NotNil(t, g.Deprecated)
+			assert.Equal(t, "use the data guidelines", g.Deprecated.Message)
+		}
+	}
+}
+
+func mustLoadManifest(t *testing.T, path string) *config.Manifest {
+	t.Helper()
+	manifest, err := config.LoadManifest(path)
+	require.NoError(t, err)
+	return manifest
+}
+
+func TestUpdateCommand_Assets(t *testing.T) {
+	projectDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	require.NoError(t, os.Chdir(projectDir))
+	require.NoError(t, runInit())
+
+	sourceDir := filepath.Join(projectDir, "company-dna")
+	writeBundleSource(t, sourceDir, "go-style")
+	for _, file := range []string{"examples/main.go", "examples/server/handler.go", "diagram.png"} {
+		path := filepath.Join(sourceDir, "guidelines", "go-style", filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(file), 0644))
+	}
+
+	setAssets := func(assets string) {
+		manifestPath := filepath.Join(sourceDir, "dnaspec-manifest.yaml")
+		manifest, err := os.ReadFile(manifestPath)
+		require.NoError(t, err)
+		content := strings.Replace(string(manifest), "file: guidelines/go-style.md\n", "file: guidelines/go-style.md\n    assets: "+assets+"\n", 1)
+		require.NoError(t, os.WriteFile(manifestPath, []byte(content), 0644))
+	}
+
+	setAssets(`["guidelines/go-style/examples/**"]`)
+	require.NoError(t, runAdd(addFlags{guidelines: []string{"go-style"}}, []string{sourceDir}))
+
+	cfg, err := config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	destDir := filepath.Join("dnaspec", cfg.Sources[0].Name, "guidelines", "go-style")
+	assert.Equal(t, []string{
+		"guidelines/go-style/examples/main.go",
+		"guidelines/go-style/examples/server/handler.go",
+	}, cfg.Sources[0].AssetFiles)
+	assert.FileExists(t, filepath.Join(destDir, "examples", "server", "handler.go"))
+
+	// Switching the assets prunes the files that are no longer matched
+	writeBundleSource(t, sourceDir, "go-style")
+	setAssets(`["guidelines/go-style/*.png"]`)
+	require.NoError(t, updateSingleSource(cfg, cfg.Sources[0].Name, updateFlags{}))
+
+	cfg, err = config.LoadProjectConfig(projectConfigFileName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"guidelines/go-style/diagram.png"}, cfg.Sources[0].AssetFiles)
+	assert.FileExists(t, filepath.Join(destDir, "diagram.png"))
+	assert.NoDirExists(t, filepath.Join(destDir, "examples"))
+	assert.FileExists(t, filepath.Join("dnaspec", cfg.Sources[0].Name, "guidelines", "go-style.md"))
+}
@@ -2,18 +2,27 @@ package project
 
 import (
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/aviator5/dnaspec/internal/core/agents"
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/aviator5/dnaspec/internal/core/paths"
+	"github.com/aviator5/dnaspec/internal/core/render"
+	"github.com/aviator5/dnaspec/internal/core/stats"
+	"github.com/aviator5/dnaspec/internal/core/validate"
 	"github.com/aviator5/dnaspec/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 // NewValidateCmd creates the validate command for validating project configuration
 func NewValidateCmd() *cobra.Command {
+	var format string
+
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate the project configuration",
@@ -24,16 +33,35 @@ This command checks:
 - Config version is supported (currently version 1)
 - All sources have required fields
 - File references exist in dnaspec/ directory (guidelines and prompts)
+- Template variables used by guidelines and prompts are defined
+- Guidelines included by prompts are installed
+- Guidelines required by their source or by installed guidelines are installed
 - Agent IDs are recognized
+- Deprecated guidelines (warning only)
 - No duplicate source names
-- Symlinked sources with missing paths (warning only)`,
+- Symlinked sources with missing paths (warning only)
+- Guidelines, prompts and agent files over their token budgets (warning only)
+
+With --format json or --format sarif, the results are written to stdout for
+tools, with the line and column of each problem in dnaspec.yaml.`,
 		Example: `  # Validate the project configuration
-  dnaspec validate`,
+  dnaspec validate
+
+  # Write the results as JSON, e.g. for a review bot
+  dnaspec validate --format json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(validate.Formats, format) {
+				return fmt.Errorf("invalid format '%s' (expected %s)", format, formatList(validate.Formats))
+			}
+			if format != validate.FormatText {
+				return runValidateReport(cmd.OutOrStdout(), format)
+			}
 			return runValidate()
 		},
 	}
 
+	cmd.Flags().StringVar(&format, "format", validate.FormatText, "Output format: text, json or sarif")
+
 	return cmd
 }
 
@@ -45,23 +73,79 @@ func runValidate() error {
 
 	fmt.Println(ui.InfoStyle.Render("Validating"), ui.CodeStyle.Render(projectConfigFileName)+"...")
 
-	// Collect all validation errors and warnings
-	var errors []string
-	var warnings []string
-	var validatedFiles []string
+	errors, warnings, validatedFiles := collectValidation(cfg)
+
+	if cfg.Version == 1 {
+		fmt.Println(ui.SuccessStyle.Render("✓"), "YAML syntax valid")
+		fmt.Println(ui.SuccessStyle.Render("✓"), "Version 1 schema valid")
+	}
+	fmt.Printf(ui.SuccessStyle.Render("✓")+" %d sources configured\n", len(cfg.Sources))
+	if len(cfg.Agents) > 0 {
+		fmt.Println(ui.SuccessStyle.Render("✓"), "All agent IDs recognized:", formatList(cfg.Agents))
+	}
+
+	// Report results
+	return reportValidationResults(errors, warnings, validatedFiles)
+}
+
+// runValidateReport validates the project configuration and writes the results in a machine-readable format
+func runValidateReport(out io.Writer, format string) error {
+	cfg, err := config.LoadProjectConfig(projectConfigFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("project configuration not found: run 'dnaspec init' first")
+		}
+		return fmt.Errorf("failed to load project configuration: %w", err)
+	}
+
+	errors, warnings, _ := collectValidation(cfg)
+	problems := slices.Concat(errors, warnings)
+
+	// Point problems at the line of their field in dnaspec.yaml
+	if doc, err := config.LoadYAMLNode(projectConfigFileName); err == nil {
+		for i := range problems {
+			if problems[i].Field != "" {
+				problems[i].Line, problems[i].Column = config.FieldPosition(doc, problems[i].Field)
+			}
+		}
+	}
+
+	if err := validate.WriteReport(out, format, problems, projectConfigFileName); err != nil {
+		return err
+	}
+	if !errors.IsEmpty() {
+		return fmt.Errorf("validation failed")
+	}
+	return nil
+}
 
+// collectValidation checks the project configuration and returns its errors, its warnings and the files it found
+func collectValidation(cfg *config.ProjectConfig) (errors, warnings validate.ValidationErrors, validatedFiles []string) {
 	// Validate config version
 	errors = validateConfigVersion(cfg, errors)
 
 	// Validate sources
-	fmt.Printf(ui.SuccessStyle.Render("✓")+" %d sources configured\n", len(cfg.Sources))
 	errors, warnings, validatedFiles = validateAllSources(cfg.Sources, errors, warnings, validatedFiles)
 
+	// Validate template variables used by guidelines and prompts
+	errors = validateTemplateReferences(cfg, errors)
+
+	// Validate that guidelines required by sources and by installed guidelines are installed
+	errors = validateRequiredGuidelines(cfg, errors)
+
+	// Flag deprecated guidelines
+	warnings = checkDeprecatedGuidelines(cfg, warnings)
+
 	// Validate agent IDs
 	errors = validateAgentIDs(cfg.Agents, errors)
 
-	// Report results
-	return reportValidationResults(errors, warnings, validatedFiles)
+	// Validate AGENTS.md rendering options
+	errors, warnings = validateAgentsMDOptions(cfg, errors, warnings)
+
+	// Check context size budgets
+	warnings = checkBudgets(cfg, warnings)
+
+	return errors, warnings, validatedFiles
 }
 
 func loadAndCheckConfig() (*config.ProjectConfig, error) {
@@ -79,28 +163,27 @@ func loadAndCheckConfig() (*config.ProjectConfig, error) {
 	return cfg, nil
 }
 
-func validateConfigVersion(cfg *config.ProjectConfig, errors []string) []string {
+func validateConfigVersion(cfg *config.ProjectConfig, errors validate.ValidationErrors) validate.ValidationErrors {
 	if cfg.Version != 1 {
-		return append(errors, fmt.Sprintf("Unsupported config version: %d (only version 1 is supported)", cfg.Version))
+		errors.Add("version", fmt.Sprintf("Unsupported config version: %d (only version 1 is supported)", cfg.Version))
 	}
-	fmt.Println(ui.SuccessStyle.Render("✓"), "YAML syntax valid")
-	fmt.Println(ui.SuccessStyle.Render("✓"), "Version 1 schema valid")
 	return errors
 }
 
 func validateAllSources(
 	sources []config.ProjectSource,
-	errors, warnings, validatedFiles []string,
-) (outErrors, outWarnings, outValidatedFiles []string) {
+	errors, warnings validate.ValidationErrors,
+	validatedFiles []string,
+) (outErrors, outWarnings validate.ValidationErrors, outValidatedFiles []string) {
 	sourceNames := make(map[string]bool)
 	for i := range sources {
 		src := &sources[i]
 		if sourceNames[src.Name] {
-			errors = append(errors, fmt.Sprintf("Duplicate source name: '%s'", src.Name))
+			errors.Add(fmt.Sprintf("sources[%d].name", i), fmt.Sprintf("Duplicate source name: '%s'", src.Name))
 		}
 		sourceNames[src.Name] = true
 
-		sourceErrors, sourceWarnings, sourceFiles := validateSource(src)
+		sourceErrors, sourceWarnings, sourceFiles := validateSource(src, fmt.Sprintf("sources[%d]", i))
 		errors = append(errors, sourceErrors...)
 		warnings = append(warnings, sourceWarnings...)
 		validatedFiles = append(validatedFiles, sourceFiles...)
@@ -108,7 +191,101 @@ func validateAllSources(
 	return errors, warnings, validatedFiles
 }
 
-func validateAgentIDs(agentIDs []string, errors []string) []string {
+func validateTemplateReferences(cfg *config.ProjectConfig, errors validate.ValidationErrors) validate.ValidationErrors {
+	for i := range cfg.Sources {
+		src := &cfg.Sources[i]
+		vars := render.Vars(cfg, src)
+
+		// Files marked as templates, and their fields
+		fields := make(map[string]string, len(src.Guidelines)+len(src.Prompts))
+		files := make([]string, 0, len(src.Guidelines)+len(src.Prompts))
+		for j, guideline := range src.Guidelines {
+			if guideline.Template {
+				files = append(files, guideline.File)
+				fields[guideline.File] = fmt.Sprintf("sources[%d].guidelines[%d].file", i, j)
+			}
+		}
+		for j, prompt := range src.Prompts {
+			if prompt.Template {
+				files = append(files, prompt.File)
+				fields[prompt.File] = fmt.Sprintf("sources[%d].prompts[%d].file", i, j)
+			}
+		}
+
+		for _, file := range files {
+			field := fields[file]
+			filePath := filepath.Join("dnaspec", src.Name, file)
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				// Missing files are reported by validateSource
+				continue
+			}
+
+			refs, err := render.ParseReferences(filePath, string(content))
+			if err != nil {
+				errors.Add(field, fmt.Sprintf("Invalid template in %s: %v", filePath, err))
+				continue
+			}
+			for _, variable := range refs.Variables {
+				if _, ok := vars[variable]; !ok {
+					errors.Add(field, fmt.Sprintf(
+						"Variable '%s' used by %s is not defined (set it under vars in %s)",
+						variable, filePath, projectConfigFileName,
+					))
+				}
+			}
+			for _, target := range refs.Includes {
+				if !hasGuideline(cfg, src.Name+"/"+target) {
+					errors.Add(field, fmt.Sprintf(
+						"Guideline '%s' included by %s is not installed (select it with 'dnaspec update %s')",
+						target, filePath, src.Name,
+					))
+				}
+			}
+		}
+	}
+	return errors
+}
+
+func validateRequiredGuidelines(cfg *config.ProjectConfig, errors validate.ValidationErrors) validate.ValidationErrors {
+	for i, src := range cfg.Sources {
+		for j, name := range src.RequiredGuidelines {
+			if !hasGuideline(cfg, src.Name+"/"+name) {
+				errors.Add(fmt.Sprintf("sources[%d].required_guidelines[%d]", i, j), fmt.Sprintf(
+					"Guideline '%s/%s' is required by the source but not installed (add it with 'dnaspec update %s')",
+					src.Name, name, src.Name,
+				))
+			}
+		}
+		for j, guideline := range src.Guidelines {
+			for k, required := range guideline.Requires {
+				if !hasGuideline(cfg, src.Name+"/"+required) {
+					errors.Add(fmt.Sprintf("sources[%d].guidelines[%d].requires[%d]", i, j, k), fmt.Sprintf(
+						"Guideline '%s/%s' requires '%s', which is not installed (select it with 'dnaspec update %s')",
+						src.Name, guideline.Name, required, src.Name,
+					))
+				}
+			}
+		}
+	}
+	return errors
+}
+
+func checkDeprecatedGuidelines(cfg *config.ProjectConfig, warnings validate.ValidationErrors) validate.ValidationErrors {
+	for i, src := range cfg.Sources {
+		for j, guideline := range src.Guidelines {
+			if guideline.Deprecated != nil {
+				warnings.AddWarning(
+					fmt.Sprintf("sources[%d].guidelines[%d]", i, j),
+					"Guideline "+config.DeprecationNotice(src.Name+"/"+guideline.Name, guideline.Deprecated),
+				)
+			}
+		}
+	}
+	return warnings
+}
+
+func validateAgentIDs(agentIDs []string, errors validate.ValidationErrors) validate.ValidationErrors {
 	availableAgents := agents.GetAvailableAgents()
 	recognizedAgents := make(map[string]bool, len(availableAgents))
 	agentNames := make([]string, 0, len(availableAgents))
@@ -117,45 +294,109 @@ func validateAgentIDs(agentIDs []string, errors []string) []string {
 		agentNames = append(agentNames, agent.ID)
 	}
 
-	for _, agentID := range agentIDs {
+	for i, agentID := range agentIDs {
 		if !recognizedAgents[agentID] {
-			errors = append(errors, fmt.Sprintf("Unknown agent ID: '%s' (recognized: %s)", agentID, formatList(agentNames)))
+			errors.Add(fmt.Sprintf("agents[%d]", i), fmt.Sprintf("Unknown agent ID: '%s' (recognized: %s)", agentID, formatList(agentNames)))
 		}
 	}
+	return errors
+}
 
-	if len(agentIDs) > 0 {
-		fmt.Println(ui.SuccessStyle.Render("✓"), "All agent IDs recognized:", formatList(agentIDs))
+func validateAgentsMDOptions(
+	cfg *config.ProjectConfig,
+	errors, warnings validate.ValidationErrors,
+) (outErrors, outWarnings validate.ValidationErrors) {
+	options := cfg.AgentsMD
+	validModes := []string{config.AgentsMDModePointer, config.AgentsMDModeInline}
+
+	if options.Mode != "" && !slices.Contains(validModes, options.Mode) {
+		errors.Add("agents_md.mode", fmt.Sprintf("Invalid agents_md.mode: '%s' (valid: %s)", options.Mode, formatList(validModes)))
 	}
-	return errors
+	if options.InlineBudget < 0 {
+		errors.Add("agents_md.inline_budget", fmt.Sprintf("Invalid agents_md.inline_budget: %d (must not be negative)", options.InlineBudget))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(options.Guidelines)) {
+		mode := options.Guidelines[key]
+		field := "agents_md.guidelines." + key
+		if !slices.Contains(validModes, mode) {
+			errors.Add(field, fmt.Sprintf("Invalid agents_md.guidelines['%s']: '%s' (valid: %s)", key, mode, formatList(validModes)))
+		}
+		if !hasGuideline(cfg, key) {
+			warnings.AddWarning(field, fmt.Sprintf("agents_md.guidelines['%s'] does not match any configured guideline", key))
+		}
+	}
+
+	return errors, warnings
+}
+
+// checkBudgets warns about guidelines, prompts and agent files whose estimated tokens exceed the budgets
+func checkBudgets(cfg *config.ProjectConfig, warnings validate.ValidationErrors) validate.ValidationErrors {
+	report, err := stats.Collect(cfg)
+	if err != nil {
+		warnings.AddWarning("budgets", fmt.Sprintf("Could not check token budgets: %v", err))
+		return warnings
+	}
+	for _, warning := range report.Warnings(cfg.Budgets) {
+		warnings.AddWarning("budgets", warning)
+	}
+	return warnings
+}
+
+// hasGuideline reports whether a "<source-name>/<guideline-name>" key names a configured guideline
+func hasGuideline(cfg *config.ProjectConfig, key string) bool {
+	sourceName, guidelineName, ok := strings.Cut(key, "/")
+	if !ok {
+		return false
+	}
+	source := config.FindSourceByName(cfg, sourceName)
+	if source == nil {
+		return false
+	}
+	for _, guideline := range source.Guidelines {
+		if guideline.Name == guidelineName {
+			return true
+		}
+	}
+	return false
 }
 
-func reportValidationResults(errors, warnings, validatedFiles []string) error {
+func reportValidationResults(errors, warnings validate.ValidationErrors, validatedFiles []string) error {
 	if len(errors) == 0 {
 		printSuccessResults(validatedFiles, warnings)
 		return nil
 	}
 
+	// Display warnings before errors; they don't fail validation but are worth fixing alongside
+	printWarnings(warnings)
+
 	fmt.Println()
 	fmt.Println(ui.ErrorStyle.Render("✗"), "Validation found", len(errors), "errors:")
 	for _, err := range errors {
-		fmt.Println("  -", err)
+		fmt.Println("  -", err.Message)
 	}
 	return fmt.Errorf("validation failed")
 }
 
-func printSuccessResults(validatedFiles, warnings []string) {
+func printWarnings(warnings validate.ValidationErrors) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.WarningStyle.Render("⚠"), "Found", len(warnings), "warning(s):")
+	for _, warning := range warnings {
+		fmt.Println("  -", warning.Message)
+	}
+}
+
+func printSuccessResults(validatedFiles []string, warnings validate.ValidationErrors) {
 	fmt.Println(ui.SuccessStyle.Render("✓"), "All referenced files exist:")
 	for _, file := range validatedFiles {
 		fmt.Println("  -", ui.CodeStyle.Render(file))
 	}
 
-	if len(warnings) > 0 {
-		fmt.Println()
-		fmt.Println(ui.WarningStyle.Render("⚠"), "Found", len(warnings), "warning(s):")
-		for _, warning := range warnings {
-			fmt.Println("  -", warning)
-		}
-	}
+	printWarnings(warnings)
 
 	fmt.Println()
 	if len(warnings) > 0 {
@@ -165,31 +406,31 @@ func printSuccessResults(validatedFiles, warnings []string) {
 	}
 }
 
-func validateSource(src *config.ProjectSource) (errors []string, warnings []string, validatedFiles []string) {
+func validateSource(src *config.ProjectSource, prefix string) (errors, warnings validate.ValidationErrors, validatedFiles []string) {
 	// Check required fields based on source type
 	if src.Name == "" {
-		errors = append(errors, "Source missing required field: name")
+		errors.Add(prefix, "Source missing required field: name")
 	}
 
 	if src.Type == "" {
-		errors = append(errors, fmt.Sprintf("Source '%s' missing required field: type", src.Name))
+		errors.Add(prefix, fmt.Sprintf("Source '%s' missing required field: type", src.Name))
 	}
 
 	switch src.Type {
 	case config.SourceTypeGitRepo:
 		if src.URL == "" {
-			errors = append(errors, fmt.Sprintf("Source '%s' (%s) missing required field: url", src.Name, config.SourceTypeGitRepo))
+			errors.Add(prefix, fmt.Sprintf("Source '%s' (%s) missing required field: url", src.Name, config.SourceTypeGitRepo))
 		}
 		if src.Commit == "" {
-			errors = append(errors, fmt.Sprintf("Source '%s' (%s) missing required field: commit", src.Name, config.SourceTypeGitRepo))
+			errors.Add(prefix, fmt.Sprintf("Source '%s' (%s) missing required field: commit", src.Name, config.SourceTypeGitRepo))
 		}
 	case config.SourceTypeLocalPath:
 		if src.Path == "" {
-			errors = append(errors, fmt.Sprintf("Source '%s' (%s) missing required field: path", src.Name, config.SourceTypeLocalPath))
+			errors.Add(prefix, fmt.Sprintf("Source '%s' (%s) missing required field: path", src.Name, config.SourceTypeLocalPath))
 		} else {
 			// Warn on absolute paths (not error - maintains backward compatibility)
 			if filepath.IsAbs(src.Path) {
-				warnings = append(warnings, fmt.Sprintf(
+				warnings.AddWarning(prefix+".path", fmt.Sprintf(
 					"Source '%s' uses absolute path: %s\n"+
 						"    Consider manually editing dnaspec.yaml to use a relative path",
 					src.Name, src.Path,
@@ -198,9 +439,9 @@ func validateSource(src *config.ProjectSource) (errors []string, warnings []stri
 				// Validate relative path resolves within project
 				projectRoot, err := filepath.Abs(filepath.Dir(projectConfigFileName))
 				if err != nil {
-					errors = append(errors, fmt.Sprintf("Failed to resolve project root: %v", err))
+					errors.Add(prefix+".path", fmt.Sprintf("Failed to resolve project root: %v", err))
 				} else if err := paths.ValidateLocalPath(projectRoot, src.Path); err != nil {
-					errors = append(errors, fmt.Sprintf(
+					errors.Add(prefix+".path", fmt.Sprintf(
 						"Source '%s' path validation failed: %v",
 						src.Name, err,
 					))
@@ -210,20 +451,20 @@ func validateSource(src *config.ProjectSource) (errors []string, warnings []stri
 	}
 
 	// Validate guideline file references
-	for _, guideline := range src.Guidelines {
+	for i, guideline := range src.Guidelines {
 		filePath := filepath.Join("dnaspec", src.Name, guideline.File)
 		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			errors = append(errors, fmt.Sprintf("File not found: %s", filePath))
+			errors.Add(fmt.Sprintf("%s.guidelines[%d].file", prefix, i), fmt.Sprintf("File not found: %s", filePath))
 		} else {
 			validatedFiles = append(validatedFiles, filePath)
 		}
 	}
 
 	// Validate prompt file references
-	for _, prompt := range src.Prompts {
+	for i, prompt := range src.Prompts {
 		filePath := filepath.Join("dnaspec", src.Name, prompt.File)
 		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			errors = append(errors, fmt.Sprintf("File not found: %s", filePath))
+			errors.Add(fmt.Sprintf("%s.prompts[%d].file", prefix, i), fmt.Sprintf("File not found: %s", filePath))
 		} else {
 			validatedFiles = append(validatedFiles, filePath)
 		}
@@ -1,11 +1,15 @@
 package project
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/validate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -189,6 +193,179 @@ func TestValidateCommand_InvalidAgentID(t *testing.T) {
 	assert.Contains(t, err.Error(), "validation failed")
 }
 
+func TestValidateCommand_InvalidAgentsMDMode(t *testing.T) {
+	// Create temp directory for test
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	cfg := &config.ProjectConfig{
+		Version:  1,
+		AgentsMD: config.AgentsMDOptions{Mode: "embedded"},
+		Sources:  []config.ProjectSource{},
+	}
+
+	err := config.SaveProjectConfig("dnaspec.yaml", cfg)
+	require.NoError(t, err)
+
+	err = runValidate()
+	assert.Error(t, err)
+
+	errors, warnings := validateAgentsMDOptions(cfg, nil, nil)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Message, "agents_md.mode")
+	assert.Empty(t, warnings)
+}
+
+func TestValidateAgentsMDOptions_GuidelineOverrides(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		AgentsMD: config.AgentsMDOptions{
+			Guidelines: map[string]string{
+				"company/go-style": "inline",
+				"company/unknown":  "pointer",
+				"company/rest-api": "full",
+			},
+		},
+		Sources: []config.ProjectSource{
+			{
+				Name: "company",
+				Guidelines: []config.ProjectGuideline{
+					{Name: "go-style"},
+					{Name: "rest-api"},
+				},
+			},
+		},
+	}
+
+	errors, warnings := validateAgentsMDOptions(cfg, nil, nil)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Message, "company/rest-api")
+	assert.Equal(t, "agents_md.guidelines.company/rest-api", errors[0].Field)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Message, "company/unknown")
+	assert.True(t, warnings[0].IsWarning())
+}
+
+func TestValidateTemplateVariables(t *testing.T) {
+	// Create temp directory for test
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	sourceDir := filepath.Join("dnaspec", "test-source", "prompts")
+	require.NoError(t, os.MkdirAll(sourceDir, 0755))
+	content := "Run {{.test_command}} in {{.module_path}}, then {{.service_name"
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "broken.md"), []byte(content), 0644))
+	content = "Run {{.test_command}} in {{.module_path}} for {{.service_name}}"
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "review.md"), []byte(content), 0644))
+	content = "Review against:\n\n{{include \"go-style\"}}"
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "go-review.md"), []byte(content), 0644))
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Vars:    map[string]string{"module_path": "github.com/acme/app"},
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Variables: []config.Variable{
+					{Name: "module_path"},
+					{Name: "test_command", Default: "make test"},
+					{Name: "service_name"},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "broken", File: "prompts/broken.md", Template: true},
+					{Name: "review", File: "prompts/review.md", Template: true},
+					{Name: "missing", File: "prompts/missing.md", Template: true},
+					{Name: "go-review", File: "prompts/go-review.md", Template: true},
+				},
+			},
+		},
+	}
+
+	errors := validateTemplateReferences(cfg, nil)
+	require.Len(t, errors, 3)
+	assert.Contains(t, errors[0].Message, "Invalid template in "+filepath.Join(sourceDir, "broken.md"))
+	assert.Contains(t, errors[1].Message, "Variable 'service_name' used by "+filepath.Join(sourceDir, "review.md")+" is not defined")
+	assert.Contains(t, errors[2].Message, "Guideline 'go-style' included by "+filepath.Join(sourceDir, "go-review.md")+" is not installed")
+
+	cfg.Vars["service_name"] = "billing"
+	cfg.Sources[0].Prompts = cfg.Sources[0].Prompts[1:3]
+	assert.Empty(t, validateTemplateReferences(cfg, nil))
+
+	// Files that aren't templates are used verbatim and not parsed
+	cfg.Sources[0].Prompts = []config.ProjectPrompt{{Name: "broken", File: "prompts/broken.md"}}
+	assert.Empty(t, validateTemplateReferences(cfg, nil))
+}
+
+func TestValidateRequiredGuidelines(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "company",
+				Guidelines: []config.ProjectGuideline{
+					{Name: "rest-api", Requires: []string{"error-handling", "go-style"}},
+					{Name: "go-style"},
+				},
+			},
+		},
+	}
+
+	errors := validateRequiredGuidelines(cfg, nil)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Message, "Guideline 'company/rest-api' requires 'error-handling', which is not installed")
+	assert.Equal(t, "sources[0].guidelines[0].requires[0]", errors[0].Field)
+
+	cfg.Sources[0].Guidelines = append(cfg.Sources[0].Guidelines, config.ProjectGuideline{Name: "error-handling"})
+	assert.Empty(t, validateRequiredGuidelines(cfg, nil))
+
+	cfg.Sources[0].RequiredGuidelines = []string{"security"}
+	errors = validateRequiredGuidelines(cfg, nil)
+	require.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Message, "Guideline 'company/security' is required by the source but not installed")
+}
+
+func TestCheckDeprecatedGuidelines(t *testing.T) {
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "company",
+				Guidelines: []config.ProjectGuideline{
+					{Name: "go-style", Deprecated: &config.Deprecation{Message: "superseded", Replacement: "go-conventions"}},
+					{Name: "rest-api"},
+				},
+			},
+		},
+	}
+
+	warnings := checkDeprecatedGuidelines(cfg, nil)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "Guideline 'company/go-style' is deprecated: superseded (use 'go-conventions' instead)", warnings[0].Message)
+}
+
+func TestCheckBudgets(t *testing.T) {
+	// Create temp directory for test
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	err := os.WriteFile("AGENTS.md", []byte(strings.Repeat("word ", 20)), 0644)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{Version: 1}
+	assert.Empty(t, checkBudgets(cfg, nil))
+
+	cfg.Budgets.AgentFileTokens = 10
+	warnings := checkBudgets(cfg, nil)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Message, "AGENTS.md is ~20 tokens (budget 10)")
+}
+
 func TestValidateCommand_DuplicateSourceNames(t *testing.T) {
 	// Create temp directory for test
 	tmpDir := t.TempDir()
@@ -259,3 +436,53 @@ func TestValidateCommand_MultipleErrors(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "validation failed")
 }
+
+func TestRunValidateReport_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(tmpDir)
+
+	content := `version: 1
+agents:
+  - claude-code
+  - bogus
+sources: []
+`
+	require.NoError(t, os.WriteFile("dnaspec.yaml", []byte(content), 0o644))
+
+	var out bytes.Buffer
+	err := runValidateReport(&out, validate.FormatJSON)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed")
+
+	var report struct {
+		Valid    bool `json:"valid"`
+		Problems []struct {
+			Severity string `json:"severity"`
+			File     string `json:"file"`
+			Line     int    `json:"line"`
+			Column   int    `json:"column"`
+			Field    string `json:"field"`
+		} `json:"problems"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.False(t, report.Valid)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, "error", report.Problems[0].Severity)
+	assert.Equal(t, "dnaspec.yaml", report.Problems[0].File)
+	assert.Equal(t, "agents[1]", report.Problems[0].Field)
+	assert.Equal(t, 4, report.Problems[0].Line)
+	assert.Equal(t, 5, report.Problems[0].Column)
+}
+
+func TestValidateCmd_InvalidFormat(t *testing.T) {
+	cmd := NewValidateCmd()
+	cmd.SetArgs([]string{"--format", "xml"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format 'xml'")
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/aviator5/dnaspec/internal/core/markdown"
+	"github.com/aviator5/dnaspec/internal/core/render"
 )
 
 // GenerateAgentsMD generates or updates AGENTS.md with DNA guideline instructions
@@ -49,30 +51,100 @@ func generateAgentsMDContent(cfg *config.ProjectConfig) string {
 		return sb.String()
 	}
 
-	sb.WriteString("When working on the codebase, open and refer to the following DNA guidelines as needed:\n")
+	pointers, inlined := planAgentsMDGuidelines(cfg)
+
+	if len(pointers) > 0 {
+		sb.WriteString("When working on the codebase, open and refer to the following DNA guidelines as needed:\n")
+		for _, ref := range pointers {
+			writeGuidelinePointer(&sb, ref.sourceName, ref.guideline)
+		}
+	}
+
+	if len(inlined) > 0 {
+		if len(pointers) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("The following DNA guidelines are included in full. Follow them when they apply:\n")
+		for _, ref := range inlined {
+			writeInlineGuideline(&sb, ref)
+		}
+	}
+
+	sb.WriteString("\nKeep this managed block so 'dnaspec update-agents' can refresh the instructions.\n")
+
+	return sb.String()
+}
+
+// agentsMDGuideline is a guideline planned for rendering in AGENTS.md
+type agentsMDGuideline struct {
+	sourceName string
+	guideline  config.ProjectGuideline
+	content    string // Demoted guideline markdown, only set for inlined guidelines
+}
+
+// planAgentsMDGuidelines splits guidelines into pointer and inline renderings
+// Inline guidelines are admitted in order until the size budget is exhausted; the rest fall back to pointers,
+// as do guidelines whose file can't be read
+func planAgentsMDGuidelines(cfg *config.ProjectConfig) (pointers, inlined []agentsMDGuideline) {
+	budget := cfg.AgentsMD.Budget()
+	used := 0
 
 	for i := range cfg.Sources {
 		source := &cfg.Sources[i]
+		renderer := render.ForSource(cfg, source, "")
 		for _, guideline := range source.Guidelines {
-			// Format: @/dnaspec/<source-name>/<file>
-			path := fmt.Sprintf("@/dnaspec/%s/%s", source.Name, guideline.File)
-			sb.WriteString(fmt.Sprintf("- `%s` for\n", path))
-
-			// Add applicable scenarios as bullet points
-			if len(guideline.ApplicableScenarios) > 0 {
-				for _, scenario := range guideline.ApplicableScenarios {
-					sb.WriteString(fmt.Sprintf("   * %s\n", scenario))
+			ref := agentsMDGuideline{sourceName: source.Name, guideline: guideline}
+
+			if cfg.AgentsMD.GuidelineMode(source.Name, guideline.Name) == config.AgentsMDModeInline {
+				data, err := renderer.RenderFile(guideline.File)
+				// Content is nested below the "### <guideline>" heading, so "#" becomes "####"
+				content := markdown.ShiftHeadings(strings.TrimSpace(data), 3)
+				if err == nil && used+len(content) <= budget {
+					used += len(content)
+					ref.content = content
+					inlined = append(inlined, ref)
+					continue
 				}
-			} else {
-				// Fallback if scenarios somehow missing (should be prevented by validation)
-				sb.WriteString(fmt.Sprintf("   * %s\n", guideline.Description))
 			}
+
+			pointers = append(pointers, ref)
 		}
 	}
 
-	sb.WriteString("\nKeep this managed block so 'dnaspec update-agents' can refresh the instructions.\n")
+	return pointers, inlined
+}
 
-	return sb.String()
+// guidelinePointer returns the @/dnaspec/<source-name>/<file> reference of a guideline
+func guidelinePointer(sourceName string, guideline config.ProjectGuideline) string {
+	return fmt.Sprintf("@/dnaspec/%s/%s", sourceName, guideline.File)
+}
+
+// writeGuidelinePointer writes a guideline reference with its applicable scenarios
+func writeGuidelinePointer(sb *strings.Builder, sourceName string, guideline config.ProjectGuideline) {
+	sb.WriteString(fmt.Sprintf("- `%s` for\n", guidelinePointer(sourceName, guideline)))
+	writeGuidelineScenarios(sb, guideline)
+}
+
+// writeGuidelineScenarios writes applicable scenarios as bullet points
+func writeGuidelineScenarios(sb *strings.Builder, guideline config.ProjectGuideline) {
+	if len(guideline.ApplicableScenarios) > 0 {
+		for _, scenario := range guideline.ApplicableScenarios {
+			sb.WriteString(fmt.Sprintf("   * %s\n", scenario))
+		}
+	} else {
+		// Fallback if scenarios somehow missing (should be prevented by validation)
+		sb.WriteString(fmt.Sprintf("   * %s\n", guideline.Description))
+	}
+}
+
+// writeInlineGuideline writes a guideline section with its full content
+func writeInlineGuideline(sb *strings.Builder, ref agentsMDGuideline) {
+	sb.WriteString(fmt.Sprintf("\n### %s\n\n", ref.guideline.Name))
+	sb.WriteString(fmt.Sprintf("Source: `%s`. Applies when:\n", guidelinePointer(ref.sourceName, ref.guideline)))
+	writeGuidelineScenarios(sb, ref.guideline)
+	sb.WriteString("\n")
+	sb.WriteString(ref.content)
+	sb.WriteString("\n")
 }
 
 // writeFileAtomic writes content to file atomically using temp file + rename
@@ -231,6 +231,76 @@ User content after managed block.`
 	})
 }
 
+func TestGenerateAgentsMDContent_InlineMode(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	guidelinesDir := filepath.Join("dnaspec", "company", "guidelines")
+	require.NoError(t, os.MkdirAll(guidelinesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(guidelinesDir, "go-style.md"), []byte("# Go Style\n\n## Naming\n\nUse MixedCaps.\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(guidelinesDir, "rest-api.md"), []byte("# REST API\n\nUse nouns.\n"), 0644))
+
+	newConfig := func(options config.AgentsMDOptions) *config.ProjectConfig {
+		return &config.ProjectConfig{
+			Version:  1,
+			AgentsMD: options,
+			Sources: []config.ProjectSource{
+				{
+					Name: "company",
+					Guidelines: []config.ProjectGuideline{
+						{Name: "go-style", File: "guidelines/go-style.md", ApplicableScenarios: []string{"writing Go code"}},
+						{Name: "rest-api", File: "guidelines/rest-api.md", ApplicableScenarios: []string{"designing APIs"}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("project inline mode", func(t *testing.T) {
+		content := generateAgentsMDContent(newConfig(config.AgentsMDOptions{Mode: config.AgentsMDModeInline}))
+
+		assert.NotContains(t, content, "open and refer to the following DNA guidelines")
+		assert.Contains(t, content, "### go-style\n\nSource: `@/dnaspec/company/guidelines/go-style.md`. Applies when:\n   * writing Go code\n")
+		assert.Contains(t, content, "#### Go Style\n\n##### Naming\n\nUse MixedCaps.\n")
+		assert.Contains(t, content, "#### REST API\n\nUse nouns.\n")
+	})
+
+	t.Run("per-guideline override", func(t *testing.T) {
+		content := generateAgentsMDContent(newConfig(config.AgentsMDOptions{
+			Guidelines: map[string]string{"company/rest-api": config.AgentsMDModeInline},
+		}))
+
+		assert.Contains(t, content, "- `@/dnaspec/company/guidelines/go-style.md` for\n")
+		assert.NotContains(t, content, "Use MixedCaps.")
+		assert.Contains(t, content, "Use nouns.")
+	})
+
+	t.Run("budget falls back to pointer mode", func(t *testing.T) {
+		content := generateAgentsMDContent(newConfig(config.AgentsMDOptions{Mode: config.AgentsMDModeInline, InlineBudget: 50}))
+
+		// go-style fits the budget, rest-api would exceed it
+		assert.Contains(t, content, "Use MixedCaps.")
+		assert.NotContains(t, content, "Use nouns.")
+		assert.Contains(t, content, "- `@/dnaspec/company/guidelines/rest-api.md` for\n")
+	})
+
+	t.Run("missing file falls back to pointer mode", func(t *testing.T) {
+		cfg := newConfig(config.AgentsMDOptions{Mode: config.AgentsMDModeInline})
+		cfg.Sources[0].Guidelines[0].File = "guidelines/missing.md"
+		content := generateAgentsMDContent(cfg)
+
+		assert.Contains(t, content, "- `@/dnaspec/company/guidelines/missing.md` for\n")
+		assert.Contains(t, content, "Use nouns.")
+	})
+}
+
 func TestWriteFileAtomic(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -40,15 +40,15 @@ func GenerateAntigravityPrompt(sourceName string, prompt config.ProjectPrompt, s
 func generateAntigravityPromptContent(prompt config.ProjectPrompt, promptContent string) string {
 	var sb strings.Builder
 
-	// Frontmatter
-	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("description: %s\n", prompt.Description))
-	sb.WriteString("---\n")
+	// Frontmatter, merged with the fields set for Antigravity in the manifest
+	writeFrontmatter(&sb, []frontmatterField{
+		{"description", prompt.Description},
+	}, prompt.Agents["antigravity"])
 
 	// Managed block with prompt content
 	sb.WriteString(files.ManagedBlockStart)
 	sb.WriteString("\n")
-	sb.WriteString(strings.TrimSpace(promptContent))
+	sb.WriteString(expandPromptArguments(promptContent, prompt.Arguments, argumentsPlain))
 	sb.WriteString("\n")
 	sb.WriteString(files.ManagedBlockEnd)
 	sb.WriteString("\n")
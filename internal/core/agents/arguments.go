@@ -0,0 +1,94 @@
+package agents
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/render"
+)
+
+// argumentStyle is the way an agent passes command arguments to a prompt
+type argumentStyle int
+
+const (
+	argumentsPlain   argumentStyle = iota // No argument support: <name>, the agent asks for missing values
+	argumentsClaude                       // Positional $1, $2, ... with an argument-hint
+	argumentsCopilot                      // ${input:name:description} with an argument-hint
+	argumentsGemini                       // All arguments as a single {{args}} string
+)
+
+// expandPromptArguments replaces the {{arg "name"}} markers of a rendered prompt with the agent's placeholders
+// Declared arguments the prompt doesn't reference are listed after the content
+func expandPromptArguments(content string, arguments []config.PromptArgument, style argumentStyle) string {
+	content = strings.TrimSpace(content)
+	if len(arguments) == 0 {
+		return content
+	}
+
+	expanded, unreferenced := render.ExpandArguments(content, arguments, func(i int, argument config.PromptArgument) string {
+		return argumentPlaceholder(style, i, argument)
+	})
+	if len(unreferenced) == 0 {
+		return expanded
+	}
+
+	var sb strings.Builder
+	sb.WriteString(expanded)
+	if style == argumentsPlain || style == argumentsGemini {
+		sb.WriteString("\n\nArguments (ask for required ones that were not provided):\n")
+	} else {
+		sb.WriteString("\n\nArguments:\n")
+	}
+	for _, argument := range unreferenced {
+		sb.WriteString(fmt.Sprintf("- %s (%s)", argument.Name, argumentRequirement(argument)))
+		if argument.Description != "" {
+			sb.WriteString(": " + argument.Description)
+		}
+		if style == argumentsClaude || style == argumentsCopilot {
+			i := slices.IndexFunc(arguments, func(a config.PromptArgument) bool { return a.Name == argument.Name })
+			sb.WriteString(" → " + argumentPlaceholder(style, i, argument))
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// argumentPlaceholder returns the agent's placeholder for the argument at index i
+func argumentPlaceholder(style argumentStyle, i int, argument config.PromptArgument) string {
+	switch style {
+	case argumentsClaude:
+		return fmt.Sprintf("$%d", i+1)
+	case argumentsCopilot:
+		label := argument.Description
+		if label == "" {
+			label = argument.Name
+		}
+		// The label ends at the closing brace
+		return fmt.Sprintf("${input:%s:%s}", argument.Name, strings.ReplaceAll(label, "}", ")"))
+	default:
+		return "<" + argument.Name + ">"
+	}
+}
+
+// argumentHint summarizes the arguments for autocompletion, e.g. "<ticket> [scope]"
+func argumentHint(arguments []config.PromptArgument) string {
+	hints := make([]string, len(arguments))
+	for i, argument := range arguments {
+		if argument.Required {
+			hints[i] = "<" + argument.Name + ">"
+		} else {
+			hints[i] = "[" + argument.Name + "]"
+		}
+	}
+	return strings.Join(hints, " ")
+}
+
+// argumentRequirement describes whether an argument is required
+func argumentRequirement(argument config.PromptArgument) string {
+	if argument.Required {
+		return "required"
+	}
+	return "optional"
+}
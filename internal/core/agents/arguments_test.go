@@ -0,0 +1,82 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func testArgumentsPrompt() config.ProjectPrompt {
+	return config.ProjectPrompt{
+		Name:        "fix-ticket",
+		File:        "prompts/fix-ticket.md",
+		Description: "Fix a ticket",
+		Arguments: []config.PromptArgument{
+			{Name: "ticket", Description: "Ticket ID", Required: true},
+			{Name: "scope", Description: "Package to focus on"},
+		},
+	}
+}
+
+func TestExpandPromptArguments(t *testing.T) {
+	arguments := testArgumentsPrompt().Arguments
+	content := "Fix {{arg \"ticket\"}}.\n"
+
+	tests := []struct {
+		name  string
+		style argumentStyle
+		want  string
+	}{
+		{
+			name:  "claude positional",
+			style: argumentsClaude,
+			want:  "Fix $1.\n\nArguments:\n- scope (optional): Package to focus on → $2",
+		},
+		{
+			name:  "copilot input variables",
+			style: argumentsCopilot,
+			want:  "Fix ${input:ticket:Ticket ID}.\n\nArguments:\n- scope (optional): Package to focus on → ${input:scope:Package to focus on}",
+		},
+		{
+			name:  "plain",
+			style: argumentsPlain,
+			want:  "Fix <ticket>.\n\nArguments (ask for required ones that were not provided):\n- scope (optional): Package to focus on",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, expandPromptArguments(content, arguments, tt.style))
+		})
+	}
+
+	t.Run("no declared arguments", func(t *testing.T) {
+		assert.Equal(t, "Review the code.", expandPromptArguments("Review the code.\n", nil, argumentsClaude))
+	})
+}
+
+func TestArgumentHint(t *testing.T) {
+	assert.Equal(t, "<ticket> [scope]", argumentHint(testArgumentsPrompt().Arguments))
+}
+
+func TestPromptArgumentsPerAgent(t *testing.T) {
+	prompt := testArgumentsPrompt()
+	content := "Fix {{arg \"ticket\"}} in {{arg \"scope\"}}."
+
+	claude := generateClaudeCommandContent("company", prompt, content)
+	assert.Contains(t, claude, "argument-hint: \"<ticket> [scope]\"\n")
+	assert.Contains(t, claude, "Fix $1 in $2.")
+
+	copilot := generateCopilotPromptContent(prompt, content)
+	assert.Contains(t, copilot, "argument-hint: \"<ticket> [scope]\"\n")
+	assert.Contains(t, copilot, "Fix ${input:ticket:Ticket ID} in ${input:scope:Package to focus on}.")
+	assert.NotContains(t, copilot, "$ARGUMENTS")
+
+	gemini := generateGeminiCommandContent(prompt, content)
+	assert.Contains(t, gemini, "Fix <ticket> in <scope>.\n\nArguments (<ticket> [scope]): {{args}}")
+
+	cursor := generateCursorCommandContent("company", prompt, content)
+	assert.Contains(t, cursor, "Fix <ticket> in <scope>.")
+	assert.NotContains(t, cursor, "{{arg")
+}
@@ -40,18 +40,24 @@ func GenerateClaudeCommand(sourceName string, prompt config.ProjectPrompt, sourc
 func generateClaudeCommandContent(sourceName string, prompt config.ProjectPrompt, promptContent string) string {
 	var sb strings.Builder
 
-	// Frontmatter
-	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("name: DNASpec: %s %s\n", formatSourceName(sourceName), formatPromptName(prompt.Name)))
-	sb.WriteString(fmt.Sprintf("description: %s\n", prompt.Description))
-	sb.WriteString("category: DNASpec\n")
-	sb.WriteString(fmt.Sprintf("tags: [dnaspec, \"%s-%s\"]\n", sourceName, prompt.Name))
-	sb.WriteString("---\n")
+	// Frontmatter, merged with the fields set for Claude Code in the manifest
+	fields := []frontmatterField{
+		{"name", fmt.Sprintf("DNASpec: %s %s", formatSourceName(sourceName), formatPromptName(prompt.Name))},
+		{"description", prompt.Description},
+	}
+	if len(prompt.Arguments) > 0 {
+		fields = append(fields, frontmatterField{"argument-hint", fmt.Sprintf("%q", argumentHint(prompt.Arguments))})
+	}
+	fields = append(fields,
+		frontmatterField{"category", "DNASpec"},
+		frontmatterField{"tags", fmt.Sprintf("[dnaspec, \"%s-%s\"]", sourceName, prompt.Name)},
+	)
+	writeFrontmatter(&sb, fields, prompt.Agents["claude-code"])
 
 	// Managed block with prompt content
 	sb.WriteString(files.ManagedBlockStart)
 	sb.WriteString("\n")
-	sb.WriteString(strings.TrimSpace(promptContent))
+	sb.WriteString(expandPromptArguments(promptContent, prompt.Arguments, argumentsClaude))
 	sb.WriteString("\n")
 	sb.WriteString(files.ManagedBlockEnd)
 	sb.WriteString("\n")
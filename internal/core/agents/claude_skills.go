@@ -0,0 +1,145 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+)
+
+// GenerateClaudeSkill generates a Claude Code skill directory for a guideline
+// The skill bundles the guideline content in SKILL.md and copies its linked prompts and assets alongside
+func GenerateClaudeSkill(source *config.ProjectSource, guideline config.ProjectGuideline, sourceDir string) error {
+	// Generate directory name: dnaspec-<source-name>-<guideline-name>
+	skillName := fmt.Sprintf("dnaspec-%s-%s", source.Name, guideline.Name)
+	skillDir := filepath.Join(".claude", "skills", skillName)
+
+	// Read guideline content
+	guidelinePath := filepath.Join(sourceDir, guideline.File)
+	guidelineContent, err := os.ReadFile(guidelinePath)
+	if err != nil {
+		return fmt.Errorf("failed to read guideline file %s: %w", guidelinePath, err)
+	}
+
+	// Resolve linked prompts and read their content before touching the skill directory
+	prompts := findGuidelinePrompts(source, guideline)
+	promptContents := make([]string, len(prompts))
+	for i, prompt := range prompts {
+		promptPath := filepath.Join(sourceDir, prompt.File)
+		data, err := os.ReadFile(promptPath)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt file %s: %w", promptPath, err)
+		}
+		promptContents[i] = string(data)
+	}
+
+	assets, err := skillAssets(sourceDir, guideline)
+	if err != nil {
+		return err
+	}
+
+	// The skill directory is fully generated, so start from a clean slate
+	if err := os.RemoveAll(skillDir); err != nil {
+		return fmt.Errorf("failed to clean directory %s: %w", skillDir, err)
+	}
+	if err := os.MkdirAll(filepath.Join(skillDir, "prompts"), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", skillDir, err)
+	}
+
+	// Write bundled prompts
+	for i, prompt := range prompts {
+		promptPath := filepath.Join(skillDir, "prompts", prompt.Name+".md")
+		content := expandPromptArguments(promptContents[i], prompt.Arguments, argumentsPlain) + "\n"
+		if err := writeFileAtomic(promptPath, []byte(content)); err != nil {
+			return err
+		}
+	}
+
+	// Copy assets next to SKILL.md, where the guideline's relative links resolve
+	if err := files.CopyAssets(filepath.Join(sourceDir, filepath.Dir(guideline.File)), skillDir, assets); err != nil {
+		return err
+	}
+
+	// Generate SKILL.md
+	content := generateClaudeSkillContent(skillName, guideline, string(guidelineContent), prompts)
+	return writeFileAtomic(filepath.Join(skillDir, "SKILL.md"), []byte(content))
+}
+
+// skillAssets returns the asset files of a guideline relative to the guideline file's directory
+// Assets outside that directory are left out, since links to them would point outside the skill
+func skillAssets(sourceDir string, guideline config.ProjectGuideline) ([]string, error) {
+	assets, err := files.ExpandAssets(sourceDir, config.ProjectGuidelinesToManifest([]config.ProjectGuideline{guideline}))
+	if err != nil {
+		return nil, err
+	}
+
+	guidelineDir := path.Dir(guideline.File)
+	var result []string
+	for _, asset := range assets {
+		if rel, ok := strings.CutPrefix(asset, guidelineDir+"/"); ok {
+			result = append(result, rel)
+		}
+	}
+	return result, nil
+}
+
+// generateClaudeSkillContent creates the full content of a SKILL.md file
+func generateClaudeSkillContent(
+	skillName string,
+	guideline config.ProjectGuideline,
+	guidelineContent string,
+	prompts []config.ProjectPrompt,
+) string {
+	var sb strings.Builder
+
+	// Frontmatter, merged with the fields set for Claude Code in the manifest
+	writeFrontmatter(&sb, []frontmatterField{
+		{"name", skillName},
+		{"description", fmt.Sprintf("%q", formatSkillDescription(guideline))},
+	}, guideline.Agents["claude-code"])
+
+	// Managed block with guideline content and prompt references
+	sb.WriteString(files.ManagedBlockStart)
+	sb.WriteString("\n")
+	sb.WriteString(strings.TrimSpace(guidelineContent))
+	sb.WriteString("\n")
+
+	if len(prompts) > 0 {
+		sb.WriteString("\n## Related Prompts\n\n")
+		for _, prompt := range prompts {
+			sb.WriteString(fmt.Sprintf("- [%s](prompts/%s.md): %s\n", formatPromptName(prompt.Name), prompt.Name, prompt.Description))
+		}
+	}
+
+	sb.WriteString(files.ManagedBlockEnd)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// formatSkillDescription builds the skill description Claude uses to decide when to load it
+func formatSkillDescription(guideline config.ProjectGuideline) string {
+	description := strings.TrimSuffix(strings.TrimSpace(guideline.Description), ".")
+	if len(guideline.ApplicableScenarios) == 0 {
+		return description + "."
+	}
+	return fmt.Sprintf("%s. Use when %s.", description, strings.Join(guideline.ApplicableScenarios, "; "))
+}
+
+// findGuidelinePrompts returns the source prompts referenced by a guideline, in reference order
+func findGuidelinePrompts(source *config.ProjectSource, guideline config.ProjectGuideline) []config.ProjectPrompt {
+	var result []config.ProjectPrompt
+	for _, name := range guideline.Prompts {
+		for _, prompt := range source.Prompts {
+			if prompt.Name == name {
+				result = append(result, prompt)
+				break
+			}
+		}
+	}
+	return result
+}
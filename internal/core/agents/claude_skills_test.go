@@ -0,0 +1,151 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateClaudeSkillContent(t *testing.T) {
+	guideline := config.ProjectGuideline{
+		Name:                "go-style",
+		File:                "guidelines/go-style.md",
+		Description:         "Go style conventions",
+		ApplicableScenarios: []string{"writing Go code", "reviewing Go code"},
+		Prompts:             []string{"code-review"},
+	}
+	prompts := []config.ProjectPrompt{
+		{Name: "code-review", File: "prompts/code-review.md", Description: "Review Go code"},
+	}
+
+	content := generateClaudeSkillContent("dnaspec-company-go-style", guideline, "# Go Style\n\nUse gofmt.\n", prompts)
+
+	assert.Contains(t, content, "name: dnaspec-company-go-style\n")
+	assert.Contains(t, content, `description: "Go style conventions. Use when writing Go code; reviewing Go code."`)
+	assert.Contains(t, content, files.ManagedBlockStart+"\n# Go Style\n\nUse gofmt.\n")
+	assert.Contains(t, content, "- [Code Review](prompts/code-review.md): Review Go code\n")
+	assert.Contains(t, content, files.ManagedBlockEnd)
+}
+
+func TestFormatSkillDescription(t *testing.T) {
+	tests := []struct {
+		name      string
+		guideline config.ProjectGuideline
+		expected  string
+	}{
+		{
+			name:      "with scenarios",
+			guideline: config.ProjectGuideline{Description: "REST API design.", ApplicableScenarios: []string{"designing APIs"}},
+			expected:  "REST API design. Use when designing APIs.",
+		},
+		{
+			name:      "without scenarios",
+			guideline: config.ProjectGuideline{Description: "REST API design"},
+			expected:  "REST API design.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatSkillDescription(tt.guideline))
+		})
+	}
+}
+
+func TestGenerateClaudeSkill(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+	sourceDir := filepath.Join("dnaspec", "test-source")
+
+	source := &config.ProjectSource{
+		Name: "test-source",
+		Guidelines: []config.ProjectGuideline{
+			{
+				Name:                "test-guideline",
+				File:                "guidelines/test.md",
+				Description:         "Test guideline",
+				ApplicableScenarios: []string{"testing code"},
+				Prompts:             []string{"review"},
+			},
+		},
+		Prompts: []config.ProjectPrompt{
+			{Name: "review", File: "prompts/review.md", Description: "Review code"},
+			{Name: "lint", File: "prompts/lint.md", Description: "Lint code"},
+		},
+	}
+	skillDir := filepath.Join(".claude", "skills", "dnaspec-test-source-test-guideline")
+
+	t.Run("generate skill directory", func(t *testing.T) {
+		err := GenerateClaudeSkill(source, source.Guidelines[0], sourceDir)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "name: dnaspec-test-source-test-guideline")
+		assert.Contains(t, string(content), "This is a test guideline.")
+
+		// Only linked prompts are bundled
+		assert.FileExists(t, filepath.Join(skillDir, "prompts", "review.md"))
+		assert.NoFileExists(t, filepath.Join(skillDir, "prompts", "lint.md"))
+	})
+
+	t.Run("bundles assets relative to the guideline", func(t *testing.T) {
+		for _, asset := range []string{"guidelines/test/examples/main.go", "guidelines/shared/diagram.png"} {
+			assetPath := filepath.Join(sourceDir, filepath.FromSlash(asset))
+			require.NoError(t, os.MkdirAll(filepath.Dir(assetPath), 0755))
+			require.NoError(t, os.WriteFile(assetPath, []byte(asset), 0644))
+		}
+		guideline := source.Guidelines[0]
+		guideline.Assets = []string{"guidelines/test/examples/*.go", "guidelines/shared/*.png"}
+
+		err := GenerateClaudeSkill(source, guideline, sourceDir)
+		require.NoError(t, err)
+
+		// guidelines/test.md links to test/examples/main.go, which resolves the same way from SKILL.md
+		content, err := os.ReadFile(filepath.Join(skillDir, "test", "examples", "main.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "guidelines/test/examples/main.go", string(content))
+		assert.FileExists(t, filepath.Join(skillDir, "shared", "diagram.png"))
+	})
+
+	t.Run("regeneration removes stale files", func(t *testing.T) {
+		stale := filepath.Join(skillDir, "prompts", "stale.md")
+		err := os.WriteFile(stale, []byte("stale"), 0644)
+		require.NoError(t, err)
+
+		err = GenerateClaudeSkill(source, source.Guidelines[0], sourceDir)
+		require.NoError(t, err)
+		assert.NoFileExists(t, stale)
+	})
+
+	t.Run("skills only generated when enabled", func(t *testing.T) {
+		err := os.RemoveAll(".claude")
+		require.NoError(t, err)
+
+		cfg := &config.ProjectConfig{Version: 1, Sources: []config.ProjectSource{*source}}
+		summary, err := GenerateAgentFiles(cfg, []string{"claude-code"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, summary.ClaudeSkills)
+		assert.NoDirExists(t, skillDir)
+
+		cfg.ClaudeCode.Skills = true
+		summary, err = GenerateAgentFiles(cfg, []string{"claude-code"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.ClaudeSkills)
+		assert.DirExists(t, skillDir)
+	})
+}
@@ -0,0 +1,196 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/aviator5/dnaspec/internal/core/markdown"
+	"github.com/aviator5/dnaspec/internal/core/render"
+)
+
+// copilotInstructionsPath is the repository-wide custom instructions file read by GitHub Copilot
+var copilotInstructionsPath = filepath.Join(".github", "copilot-instructions.md")
+
+// GenerateCopilotInstructionsMD generates or updates .github/copilot-instructions.md
+// with the full content of the always-on DNA guidelines
+func GenerateCopilotInstructionsMD(cfg *config.ProjectConfig) error {
+	content := generateCopilotInstructionsMDContent(cfg)
+
+	// Create directory if needed
+	dir := filepath.Dir(copilotInstructionsPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Read existing file if it exists
+	existingContent, err := os.ReadFile(copilotInstructionsPath)
+	var finalContent string
+
+	switch {
+	case err == nil:
+		// File exists, replace or append managed block
+		finalContent = files.ReplaceManagedBlock(string(existingContent), content)
+	case os.IsNotExist(err):
+		// File doesn't exist, create new with header
+		finalContent = files.CreateFileWithManagedBlock(content)
+	default:
+		return fmt.Errorf("failed to read %s: %w", copilotInstructionsPath, err)
+	}
+
+	// Write atomically
+	return writeFileAtomic(copilotInstructionsPath, []byte(finalContent))
+}
+
+// generateCopilotInstructionsMDContent creates the managed block content for .github/copilot-instructions.md
+// Copilot doesn't resolve @/dnaspec references, so always-on guidelines are included in full;
+// scoped and manual-only guidelines are left to their .instructions.md files
+func generateCopilotInstructionsMDContent(cfg *config.ProjectConfig) string {
+	var sb strings.Builder
+
+	sb.WriteString("## DNASpec Instructions\n\n")
+	sb.WriteString("The project MUST follow shared DNA (Development Norms & Architecture) ")
+	sb.WriteString("guidelines. DNA contains reusable patterns and best practices applicable ")
+	sb.WriteString("across different projects.\n\n")
+
+	if len(cfg.Sources) == 0 {
+		sb.WriteString("No DNA sources configured yet. Run 'dnaspec add' to add guidelines.\n\n")
+		sb.WriteString("Keep this managed block so 'dnaspec update-agents' can refresh the instructions.\n")
+		return sb.String()
+	}
+
+	inlined, scoped := planCopilotGuidelines(cfg)
+
+	if len(inlined) > 0 {
+		sb.WriteString("The following DNA guidelines are included in full. Follow them when they apply:\n")
+		for _, ref := range inlined {
+			writeCopilotGuideline(&sb, ref)
+		}
+	}
+
+	if scoped > 0 {
+		if len(inlined) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("Guidelines that apply to specific files, or are attached on demand, ")
+		sb.WriteString("are provided in `.github/instructions/`.\n")
+	}
+
+	sb.WriteString("\nKeep this managed block so 'dnaspec update-agents' can refresh the instructions.\n")
+
+	return sb.String()
+}
+
+// planCopilotGuidelines collects the always-on guidelines with their rendered content,
+// and counts the guidelines that get their own .instructions.md file instead
+func planCopilotGuidelines(cfg *config.ProjectConfig) (inlined []agentsMDGuideline, scoped int) {
+	for i := range cfg.Sources {
+		source := &cfg.Sources[i]
+		renderer := render.ForSource(cfg, source, "")
+		for _, guideline := range source.Guidelines {
+			if copilotScoped(guideline) {
+				scoped++
+				continue
+			}
+
+			ref := agentsMDGuideline{sourceName: source.Name, guideline: guideline}
+			if data, err := renderer.RenderFile(guideline.File); err == nil {
+				// Content is nested below the "### <guideline>" heading, so "#" becomes "####"
+				ref.content = markdown.ShiftHeadings(strings.TrimSpace(data), 3)
+			}
+			inlined = append(inlined, ref)
+		}
+	}
+
+	return inlined, scoped
+}
+
+// writeCopilotGuideline writes an always-on guideline section, falling back to its
+// repository path when the guideline file can't be read
+func writeCopilotGuideline(sb *strings.Builder, ref agentsMDGuideline) {
+	sb.WriteString(fmt.Sprintf("\n### %s\n\n", ref.guideline.Name))
+	if ref.content == "" {
+		sb.WriteString(fmt.Sprintf("Read `dnaspec/%s/%s` when working on:\n", ref.sourceName, ref.guideline.File))
+		writeGuidelineScenarios(sb, ref.guideline)
+		return
+	}
+	sb.WriteString("Applies when:\n")
+	writeGuidelineScenarios(sb, ref.guideline)
+	sb.WriteString("\n")
+	sb.WriteString(ref.content)
+	sb.WriteString("\n")
+}
+
+// copilotScoped reports whether a guideline gets its own .instructions.md file rather than
+// being included in copilot-instructions.md: guidelines scoped by file patterns or attached manually
+func copilotScoped(guideline config.ProjectGuideline) bool {
+	return guideline.ManualOnly || len(guideline.FilePatterns) > 0
+}
+
+// copilotInstructionPath returns the path of a guideline's path-specific Copilot instructions file
+func copilotInstructionPath(sourceName, guidelineName string) string {
+	// Generate filename: dnaspec-<source-name>-<guideline-name>.instructions.md
+	filename := fmt.Sprintf("dnaspec-%s-%s.instructions.md", sourceName, guidelineName)
+	return filepath.Join(".github", "instructions", filename)
+}
+
+// RemoveCopilotInstruction removes a guideline's path-specific Copilot instructions file if it exists,
+// so always-on guidelines aren't duplicated next to copilot-instructions.md
+func RemoveCopilotInstruction(sourceName, guidelineName string) error {
+	err := os.Remove(copilotInstructionPath(sourceName, guidelineName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GenerateCopilotInstruction generates a path-specific GitHub Copilot instructions file for a guideline
+func GenerateCopilotInstruction(sourceName string, guideline config.ProjectGuideline, sourceDir string) error {
+	outputPath := copilotInstructionPath(sourceName, guideline.Name)
+
+	// Create directory if needed
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Read guideline content
+	guidelinePath := filepath.Join(sourceDir, guideline.File)
+	guidelineContent, err := os.ReadFile(guidelinePath)
+	if err != nil {
+		return fmt.Errorf("failed to read guideline file %s: %w", guidelinePath, err)
+	}
+
+	// Generate frontmatter and content
+	content := generateCopilotInstructionContent(guideline, string(guidelineContent))
+
+	// Write atomically
+	return writeFileAtomic(outputPath, []byte(content))
+}
+
+// generateCopilotInstructionContent creates the full content of a Copilot instructions file
+func generateCopilotInstructionContent(guideline config.ProjectGuideline, guidelineContent string) string {
+	var sb strings.Builder
+
+	// Frontmatter, merged with the fields set for GitHub Copilot in the manifest
+	// Manual-only guidelines have no applyTo, so Copilot only uses them when attached to a chat
+	var fields []frontmatterField
+	if !guideline.ManualOnly && len(guideline.FilePatterns) > 0 {
+		fields = append(fields, frontmatterField{"applyTo", fmt.Sprintf("%q", strings.Join(guideline.FilePatterns, ","))})
+	}
+	fields = append(fields, frontmatterField{"description", fmt.Sprintf("%q", guideline.Description)})
+	writeFrontmatter(&sb, fields, guideline.Agents["github-copilot"])
+
+	// Managed block with guideline content
+	sb.WriteString(files.ManagedBlockStart)
+	sb.WriteString("\n")
+	sb.WriteString(strings.TrimSpace(guidelineContent))
+	sb.WriteString("\n")
+	sb.WriteString(files.ManagedBlockEnd)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
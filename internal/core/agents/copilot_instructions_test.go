@@ -0,0 +1,246 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCopilotInstructionContent(t *testing.T) {
+	t.Run("applyTo from file patterns", func(t *testing.T) {
+		guideline := config.ProjectGuideline{
+			Name:                "go-style",
+			File:                "guidelines/go-style.md",
+			Description:         "Go style conventions",
+			ApplicableScenarios: []string{"writing Go code"},
+			FilePatterns:        []string{"**/*.go", "go.mod"},
+		}
+
+		content := generateCopilotInstructionContent(guideline, "# Go Style\n\nUse gofmt.\n\n")
+
+		assert.True(t, strings.HasPrefix(content, "---\napplyTo: \"**/*.go,go.mod\"\n"))
+		assert.Contains(t, content, "description: \"Go style conventions\"")
+		assert.Contains(t, content, files.ManagedBlockStart+"\n# Go Style\n\nUse gofmt.\n"+files.ManagedBlockEnd)
+	})
+
+	t.Run("manual-only guideline has no applyTo", func(t *testing.T) {
+		guideline := config.ProjectGuideline{
+			Name:         "migrations",
+			File:         "guidelines/migrations.md",
+			Description:  "Database migrations",
+			FilePatterns: []string{"migrations/**"},
+			ManualOnly:   true,
+		}
+
+		content := generateCopilotInstructionContent(guideline, "# Migrations")
+
+		assert.True(t, strings.HasPrefix(content, "---\ndescription: \"Database migrations\"\n---\n"))
+		assert.NotContains(t, content, "applyTo")
+	})
+}
+
+func TestGenerateCopilotInstruction(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	// Create source directory with guideline file
+	sourceDir := filepath.Join(tempDir, "dnaspec", "test-source")
+	err = os.MkdirAll(filepath.Join(sourceDir, "guidelines"), 0755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(sourceDir, "guidelines", "go-style.md"), []byte("# Go Style\n\nUse gofmt."), 0644)
+	require.NoError(t, err)
+
+	guideline := config.ProjectGuideline{
+		Name:         "go-style",
+		File:         "guidelines/go-style.md",
+		Description:  "Go style conventions",
+		FilePatterns: []string{"**/*.go"},
+	}
+
+	t.Run("generate new instructions file", func(t *testing.T) {
+		err := GenerateCopilotInstruction("test-source", guideline, sourceDir)
+		require.NoError(t, err)
+
+		expectedPath := filepath.Join(".github", "instructions", "dnaspec-test-source-go-style.instructions.md")
+		content, err := os.ReadFile(expectedPath)
+		require.NoError(t, err)
+
+		contentStr := string(content)
+		assert.Contains(t, contentStr, "applyTo: \"**/*.go\"")
+		assert.Contains(t, contentStr, "description: \"Go style conventions\"")
+		assert.Contains(t, contentStr, "Use gofmt.")
+	})
+
+	t.Run("error on missing guideline file", func(t *testing.T) {
+		missing := config.ProjectGuideline{
+			Name:        "missing",
+			File:        "guidelines/missing.md",
+			Description: "Missing guideline",
+		}
+
+		err := GenerateCopilotInstruction("test-source", missing, sourceDir)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read guideline file")
+	})
+}
+
+func TestGenerateCopilotInstructionsMD(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	err = os.MkdirAll(filepath.Join("dnaspec", "test-source", "guidelines"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join("dnaspec", "test-source", "guidelines", "test.md"), []byte("# Testing\n\nWrite table tests."), 0644)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+					},
+					{
+						Name:                "go-style",
+						File:                "guidelines/go-style.md",
+						Description:         "Go style",
+						ApplicableScenarios: []string{"writing Go code"},
+						FilePatterns:        []string{"**/*.go"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("create new copilot-instructions.md", func(t *testing.T) {
+		err := GenerateCopilotInstructionsMD(cfg)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(".github", "copilot-instructions.md"))
+		require.NoError(t, err)
+
+		contentStr := string(content)
+		assert.Contains(t, contentStr, files.ManagedBlockStart)
+		assert.Contains(t, contentStr, "### test-guideline")
+		assert.Contains(t, contentStr, "testing code")
+		assert.Contains(t, contentStr, "#### Testing\n\nWrite table tests.")
+		assert.Contains(t, contentStr, "`.github/instructions/`")
+		assert.NotContains(t, contentStr, "@/dnaspec")
+		assert.NotContains(t, contentStr, "go-style")
+	})
+
+	t.Run("update existing file preserving user content", func(t *testing.T) {
+		userContent := "# Team Instructions\n\nAlways write tests.\n\n" +
+			files.ManagedBlockStart + "\nOld content\n" + files.ManagedBlockEnd + "\n"
+		err := os.WriteFile(filepath.Join(".github", "copilot-instructions.md"), []byte(userContent), 0644)
+		require.NoError(t, err)
+
+		err = GenerateCopilotInstructionsMD(cfg)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(".github", "copilot-instructions.md"))
+		require.NoError(t, err)
+
+		contentStr := string(content)
+		assert.Contains(t, contentStr, "Always write tests.")
+		assert.Contains(t, contentStr, "Write table tests.")
+		assert.NotContains(t, contentStr, "Old content")
+	})
+
+	t.Run("cleanup removes managed block", func(t *testing.T) {
+		summary, err := CleanupAgentFiles()
+		require.NoError(t, err)
+		assert.True(t, summary.CopilotInstructionsMDCleaned)
+
+		content, err := os.ReadFile(filepath.Join(".github", "copilot-instructions.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Always write tests.")
+		assert.NotContains(t, string(content), files.ManagedBlockStart)
+	})
+}
+
+func TestGenerateAgentFilesCopilotScoping(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+						FilePatterns:        []string{"**/*_test.go"},
+					},
+				},
+			},
+		},
+	}
+	instructionPath := filepath.Join(".github", "instructions", "dnaspec-test-source-test-guideline.instructions.md")
+
+	t.Run("scoped guideline gets its own instructions file", func(t *testing.T) {
+		summary, err := GenerateAgentFiles(cfg, []string{"github-copilot"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.CopilotInstructions)
+
+		content, err := os.ReadFile(instructionPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "applyTo: \"**/*_test.go\"")
+
+		md, err := os.ReadFile(copilotInstructionsPath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(md), "This is a test guideline.")
+	})
+
+	t.Run("always-on guideline replaces its stale instructions file", func(t *testing.T) {
+		cfg.Sources[0].Guidelines[0].FilePatterns = nil
+
+		summary, err := GenerateAgentFiles(cfg, []string{"github-copilot"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, summary.CopilotInstructions)
+		assert.NoFileExists(t, instructionPath)
+
+		md, err := os.ReadFile(copilotInstructionsPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(md), "This is a test guideline.")
+	})
+}
@@ -42,18 +42,23 @@ func GenerateCopilotPrompt(sourceName string, prompt config.ProjectPrompt, sourc
 func generateCopilotPromptContent(prompt config.ProjectPrompt, promptContent string) string {
 	var sb strings.Builder
 
-	// Frontmatter
-	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("description: %s\n", prompt.Description))
-	sb.WriteString("---\n\n")
+	// Frontmatter, merged with the fields set for GitHub Copilot in the manifest
+	fields := []frontmatterField{{"description", prompt.Description}}
+	if len(prompt.Arguments) > 0 {
+		fields = append(fields, frontmatterField{"argument-hint", fmt.Sprintf("%q", argumentHint(prompt.Arguments))})
+	}
+	writeFrontmatter(&sb, fields, prompt.Agents["github-copilot"])
+	sb.WriteString("\n")
 
-	// $ARGUMENTS placeholder
-	sb.WriteString("$ARGUMENTS\n\n")
+	// $ARGUMENTS placeholder, declared arguments are placed as ${input:...} variables instead
+	if len(prompt.Arguments) == 0 {
+		sb.WriteString("$ARGUMENTS\n\n")
+	}
 
 	// Managed block with prompt content
 	sb.WriteString(files.ManagedBlockStart)
 	sb.WriteString("\n")
-	sb.WriteString(strings.TrimSpace(promptContent))
+	sb.WriteString(expandPromptArguments(promptContent, prompt.Arguments, argumentsCopilot))
 	sb.WriteString("\n")
 	sb.WriteString(files.ManagedBlockEnd)
 	sb.WriteString("\n")
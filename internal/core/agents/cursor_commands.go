@@ -45,18 +45,18 @@ func generateCursorCommandContent(sourceName string, prompt config.ProjectPrompt
 	// ID: dnaspec-<source-name>-<prompt-name>
 	commandID := fmt.Sprintf("dnaspec-%s-%s", sourceName, prompt.Name)
 
-	// Frontmatter
-	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("name: %s\n", commandName))
-	sb.WriteString(fmt.Sprintf("id: %s\n", commandID))
-	sb.WriteString("category: DNASpec\n")
-	sb.WriteString(fmt.Sprintf("description: %s\n", prompt.Description))
-	sb.WriteString("---\n")
+	// Frontmatter, merged with the fields set for Cursor in the manifest
+	writeFrontmatter(&sb, []frontmatterField{
+		{"name", commandName},
+		{"id", commandID},
+		{"category", "DNASpec"},
+		{"description", prompt.Description},
+	}, prompt.Agents["cursor"])
 
 	// Managed block with prompt content
 	sb.WriteString(files.ManagedBlockStart)
 	sb.WriteString("\n")
-	sb.WriteString(strings.TrimSpace(promptContent))
+	sb.WriteString(expandPromptArguments(promptContent, prompt.Arguments, argumentsPlain))
 	sb.WriteString("\n")
 	sb.WriteString(files.ManagedBlockEnd)
 	sb.WriteString("\n")
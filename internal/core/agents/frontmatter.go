@@ -0,0 +1,223 @@
+package agents
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Entry kinds that can carry agent frontmatter
+const (
+	EntryGuideline = "guideline"
+	EntryPrompt    = "prompt"
+)
+
+// frontmatterType is the kind of value a frontmatter key accepts
+type frontmatterType int
+
+const (
+	frontmatterString frontmatterType = iota
+	frontmatterBool
+	frontmatterStringList
+	frontmatterStringOrList // A single string or a list of strings
+)
+
+// frontmatterKey describes a frontmatter key an agent accepts
+type frontmatterKey struct {
+	valueType frontmatterType
+	allowed   []string // Permitted string values, empty for any
+}
+
+// frontmatterSchemas lists the frontmatter keys each agent accepts in the files generated per entry kind
+// Keys DNASpec derives itself, such as name, can't be set
+var frontmatterSchemas = map[string]map[string]map[string]frontmatterKey{
+	"claude-code": {
+		EntryPrompt: { // .claude/commands/dnaspec/<source>-<prompt>.md
+			"allowed-tools":            {valueType: frontmatterStringOrList},
+			"argument-hint":            {valueType: frontmatterString},
+			"description":              {valueType: frontmatterString},
+			"disable-model-invocation": {valueType: frontmatterBool},
+			"model":                    {valueType: frontmatterString},
+		},
+		EntryGuideline: { // .claude/skills/dnaspec-<source>-<guideline>/SKILL.md
+			"allowed-tools": {valueType: frontmatterStringOrList},
+			"description":   {valueType: frontmatterString},
+		},
+	},
+	"antigravity": {
+		EntryPrompt: { // .agent/workflows/dnaspec-<source>-<prompt>.md
+			"description": {valueType: frontmatterString},
+		},
+	},
+	"cursor": {
+		EntryPrompt: { // .cursor/commands/dnaspec-<source>-<prompt>.md
+			"description": {valueType: frontmatterString},
+		},
+	},
+	"github-copilot": {
+		EntryPrompt: { // .github/prompts/dnaspec-<source>-<prompt>.prompt.md
+			"argument-hint": {valueType: frontmatterString},
+			"description":   {valueType: frontmatterString},
+			"mode":          {valueType: frontmatterString, allowed: []string{"ask", "edit", "agent"}},
+			"model":         {valueType: frontmatterString},
+			"tools":         {valueType: frontmatterStringList},
+		},
+		EntryGuideline: { // .github/instructions/dnaspec-<source>-<guideline>.instructions.md
+			"applyTo":     {valueType: frontmatterString},
+			"description": {valueType: frontmatterString},
+		},
+	},
+	"kiro": {
+		EntryPrompt: { // .kiro/steering/dnaspec-<source>-<prompt>.prompt.md
+			"description": {valueType: frontmatterString},
+		},
+		EntryGuideline: { // .kiro/steering/dnaspec-<source>-<guideline>.md
+			"description":      {valueType: frontmatterString},
+			"fileMatchPattern": {valueType: frontmatterStringOrList},
+			"inclusion":        {valueType: frontmatterString, allowed: []string{"always", "fileMatch", "manual", "auto"}},
+		},
+	},
+	"roo-code": {
+		EntryPrompt: { // .roo/commands/dnaspec-<source>-<prompt>.md
+			"argument-hint": {valueType: frontmatterString},
+			"description":   {valueType: frontmatterString},
+		},
+	},
+	"windsurf": {
+		EntryPrompt: { // .windsurf/workflows/dnaspec-<source>-<prompt>.md
+			"description": {valueType: frontmatterString},
+		},
+	},
+}
+
+// derivedFrontmatterKeys lists the frontmatter keys DNASpec fills in itself in the files generated per entry kind
+var derivedFrontmatterKeys = map[string][]string{
+	EntryPrompt:    {"name", "id", "category", "description", "tags", "argument-hint", "auto_execution_mode"},
+	EntryGuideline: {"name", "description", "applyTo", "inclusion", "fileMatchPattern"},
+}
+
+// GeneratedFrontmatterKeys returns the keys that can appear in the frontmatter of the files generated for an
+// entry kind, whether DNASpec derives them or they are set under agents in the manifest
+func GeneratedFrontmatterKeys(kind string) []string {
+	keys := slices.Clone(derivedFrontmatterKeys[kind])
+	for _, schemas := range frontmatterSchemas {
+		keys = append(keys, slices.Collect(maps.Keys(schemas[kind]))...)
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// CheckFrontmatter validates the frontmatter a guideline or prompt sets for an agent against the agent's schema
+// It returns a description of each problem
+func CheckFrontmatter(agentID, kind string, fields map[string]any) []string {
+	schema, ok := frontmatterSchemas[agentID][kind]
+	if !ok {
+		var supported []string
+		for _, id := range slices.Sorted(maps.Keys(frontmatterSchemas)) {
+			if _, ok := frontmatterSchemas[id][kind]; ok {
+				supported = append(supported, id)
+			}
+		}
+		return []string{fmt.Sprintf(
+			"%s has no frontmatter for %ss, since the files generated for them carry none (supported agents: %s)",
+			agentID, kind, strings.Join(supported, ", "),
+		)}
+	}
+
+	var problems []string
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		spec, known := schema[key]
+		if !known {
+			problems = append(problems, fmt.Sprintf(
+				"unsupported %s frontmatter key '%s' for %ss (supported: %s)",
+				agentID, key, kind, strings.Join(slices.Sorted(maps.Keys(schema)), ", "),
+			))
+			continue
+		}
+		if problem := checkFrontmatterValue(spec, fields[key]); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s frontmatter key '%s' %s", agentID, key, problem))
+		}
+	}
+	return problems
+}
+
+// checkFrontmatterValue returns a description of why value doesn't match spec, or "" if it does
+func checkFrontmatterValue(spec frontmatterKey, value any) string {
+	switch spec.valueType {
+	case frontmatterBool:
+		if _, ok := value.(bool); !ok {
+			return "must be true or false"
+		}
+	case frontmatterString:
+		s, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if len(spec.allowed) > 0 && !slices.Contains(spec.allowed, s) {
+			return fmt.Sprintf("must be one of %s", strings.Join(spec.allowed, ", "))
+		}
+	case frontmatterStringList:
+		if !isStringList(value) {
+			return "must be a list of strings"
+		}
+	case frontmatterStringOrList:
+		if _, ok := value.(string); !ok && !isStringList(value) {
+			return "must be a string or a list of strings"
+		}
+	}
+	return ""
+}
+
+// isStringList reports whether a decoded YAML value is a list of strings
+func isStringList(value any) bool {
+	list, ok := value.([]any)
+	if !ok {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// frontmatterField is a generated frontmatter line, with the value already formatted as YAML
+type frontmatterField struct {
+	key   string
+	value string
+}
+
+// writeFrontmatter writes a frontmatter block of generated fields merged with fields set in the manifest
+// Set fields replace generated fields with the same key in place; the rest follow in key order
+func writeFrontmatter(sb *strings.Builder, generated []frontmatterField, fields map[string]any) {
+	sb.WriteString("---\n")
+	for _, field := range generated {
+		if value, ok := fields[field.key]; ok {
+			sb.WriteString(formatFrontmatterField(field.key, value))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", field.key, field.value))
+	}
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if !slices.ContainsFunc(generated, func(f frontmatterField) bool { return f.key == key }) {
+			sb.WriteString(formatFrontmatterField(key, fields[key]))
+		}
+	}
+	sb.WriteString("---\n")
+}
+
+// formatFrontmatterField encodes a single key and value as YAML
+func formatFrontmatterField(key string, value any) string {
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(map[string]any{key: value}); err != nil {
+		// Values come from YAML, so they always encode; fall back to the Go representation
+		return fmt.Sprintf("%s: %v\n", key, value)
+	}
+	return sb.String()
+}
@@ -0,0 +1,159 @@
+package agents
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/frontmatter"
+)
+
+func TestCheckFrontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		agentID string
+		kind    string
+		fields  map[string]any
+		want    []string
+	}{
+		{
+			name:    "valid claude command fields",
+			agentID: "claude-code",
+			kind:    EntryPrompt,
+			fields:  map[string]any{"allowed-tools": "Bash(git diff:*)", "model": "claude-sonnet-4-5", "disable-model-invocation": true},
+		},
+		{
+			name:    "valid copilot prompt fields",
+			agentID: "github-copilot",
+			kind:    EntryPrompt,
+			fields:  map[string]any{"mode": "agent", "tools": []any{"codebase", "terminal"}},
+		},
+		{
+			name:    "unsupported key",
+			agentID: "claude-code",
+			kind:    EntryPrompt,
+			fields:  map[string]any{"name": "custom"},
+			want:    []string{"unsupported claude-code frontmatter key 'name' for prompts"},
+		},
+		{
+			name:    "wrong types",
+			agentID: "github-copilot",
+			kind:    EntryPrompt,
+			fields:  map[string]any{"mode": "review", "tools": "codebase"},
+			want:    []string{"'mode' must be one of ask, edit, agent", "'tools' must be a list of strings"},
+		},
+		{
+			name:    "valid kiro steering fields",
+			agentID: "kiro",
+			kind:    EntryGuideline,
+			fields:  map[string]any{"inclusion": "auto", "description": "Go conventions"},
+		},
+		{
+			name:    "valid roo command fields",
+			agentID: "roo-code",
+			kind:    EntryPrompt,
+			fields:  map[string]any{"argument-hint": "<ticket>"},
+		},
+		{
+			name:    "agent without frontmatter",
+			agentID: "cursor",
+			kind:    EntryGuideline,
+			fields:  map[string]any{"alwaysApply": true},
+			want: []string{
+				"cursor has no frontmatter for guidelines, since the files generated for them carry none " +
+					"(supported agents: claude-code, github-copilot, kiro)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := CheckFrontmatter(tt.agentID, tt.kind, tt.fields)
+			require.Len(t, problems, len(tt.want), strings.Join(problems, "\n"))
+			for i, want := range tt.want {
+				assert.Contains(t, problems[i], want)
+			}
+		})
+	}
+}
+
+func TestWriteFrontmatter(t *testing.T) {
+	var fields map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte("description: Custom description\ntools: [codebase, terminal]\nmode: agent\n"), &fields))
+
+	var sb strings.Builder
+	writeFrontmatter(&sb, []frontmatterField{
+		{"description", "Generated description"},
+		{"argument-hint", `"<ticket>"`},
+	}, fields)
+
+	assert.Equal(t, `---
+description: Custom description
+argument-hint: "<ticket>"
+mode: agent
+tools:
+  - codebase
+  - terminal
+---
+`, sb.String())
+}
+
+func TestAgentFrontmatterInGeneratedFiles(t *testing.T) {
+	prompt := config.ProjectPrompt{
+		Name:        "code-review",
+		File:        "prompts/code-review.md",
+		Description: "Review code",
+		Agents: config.AgentFrontmatter{
+			"claude-code":    {"allowed-tools": []any{"Read", "Grep"}, "model": "opus"},
+			"github-copilot": {"mode": "ask"},
+		},
+	}
+
+	claude := generateClaudeCommandContent("company", prompt, "Review the code.")
+	assert.Contains(t, claude, "description: Review code\ncategory: DNASpec\n")
+	assert.Contains(t, claude, "allowed-tools:\n  - Read\n  - Grep\nmodel: opus\n---\n")
+	assert.NotContains(t, claude, "mode: ask")
+
+	copilot := generateCopilotPromptContent(prompt, "Review the code.")
+	assert.Contains(t, copilot, "---\ndescription: Review code\nmode: ask\n---\n\n$ARGUMENTS")
+
+	guideline := config.ProjectGuideline{
+		Name:         "go-style",
+		File:         "guidelines/go-style.md",
+		Description:  "Go style",
+		FilePatterns: []string{"*.go"},
+		Agents:       config.AgentFrontmatter{"github-copilot": {"applyTo": "**/*.go"}},
+	}
+	instruction := generateCopilotInstructionContent(guideline, "# Go Style")
+	assert.Contains(t, instruction, "---\napplyTo: '**/*.go'\ndescription: \"Go style\"\n---\n")
+
+	steering := generateKiroSteeringContent(kiroInclusionAlways, nil, "# Go Style",
+		map[string]any{"inclusion": "auto", "description": "Go conventions"})
+	assert.True(t, strings.HasPrefix(steering, "---\ninclusion: auto\ndescription: Go conventions\n---\n"))
+
+	prompt.Agents["roo-code"] = map[string]any{"argument-hint": "<scope>"}
+	roo := generateWorkflowFileContent(GetRuleDirAgent("roo-code"), prompt, "Review the code.")
+	assert.True(t, strings.HasPrefix(roo, "---\ndescription: \"Review code\"\nargument-hint: <scope>\n---\n"))
+}
+
+func TestGeneratedDescriptionsAreQuoted(t *testing.T) {
+	description := "Review: code #carefully"
+	guideline := config.ProjectGuideline{Name: "review", File: "guidelines/review.md", Description: description}
+	prompt := config.ProjectPrompt{Name: "review", File: "prompts/review.md", Description: description}
+
+	for name, content := range map[string]string{
+		"copilot instruction": generateCopilotInstructionContent(guideline, "# Review"),
+		"roo-code workflow":   generateWorkflowFileContent(GetRuleDirAgent("roo-code"), prompt, "Review the code."),
+	} {
+		head, _, ok := frontmatter.Split(content)
+		require.True(t, ok, name)
+
+		var fields map[string]any
+		require.NoError(t, yaml.Unmarshal([]byte(head), &fields), name)
+		assert.Equal(t, description, fields["description"], name)
+	}
+}
@@ -0,0 +1,124 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+)
+
+// geminiArgsPlaceholder is replaced by Gemini CLI with the arguments passed to a custom command
+const geminiArgsPlaceholder = "{{args}}"
+
+// GenerateGeminiMD generates or updates GEMINI.md with DNA guideline instructions
+// This file has the same content as AGENTS.md but specifically for Gemini CLI
+func GenerateGeminiMD(cfg *config.ProjectConfig) error {
+	// Reuse the same content generation as AGENTS.md
+	content := generateAgentsMDContent(cfg)
+
+	// Read existing file if it exists
+	existingContent, err := os.ReadFile("GEMINI.md")
+	var finalContent string
+
+	switch {
+	case err == nil:
+		// File exists, replace or append managed block
+		finalContent = files.ReplaceManagedBlock(string(existingContent), content)
+	case os.IsNotExist(err):
+		// File doesn't exist, create new with header
+		finalContent = files.CreateFileWithManagedBlock(content)
+	default:
+		return fmt.Errorf("failed to read GEMINI.md: %w", err)
+	}
+
+	// Write atomically
+	return writeFileAtomic("GEMINI.md", []byte(finalContent))
+}
+
+// GenerateGeminiCommand generates a Gemini CLI custom command (TOML) for a prompt
+func GenerateGeminiCommand(sourceName string, prompt config.ProjectPrompt, sourceDir string) error {
+	// Generate filename: <source-name>-<prompt-name>.toml, invoked as /dnaspec:<source-name>-<prompt-name>
+	filename := fmt.Sprintf("%s-%s.toml", sourceName, prompt.Name)
+	outputPath := filepath.Join(".gemini", "commands", "dnaspec", filename)
+
+	// Create directory if needed
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Read prompt content
+	promptPath := filepath.Join(sourceDir, prompt.File)
+	promptContent, err := os.ReadFile(promptPath)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file %s: %w", promptPath, err)
+	}
+
+	// Generate TOML content
+	content := generateGeminiCommandContent(prompt, string(promptContent))
+
+	// Write atomically
+	return writeFileAtomic(outputPath, []byte(content))
+}
+
+// generateGeminiCommandContent creates the full content of a Gemini CLI command file
+func generateGeminiCommandContent(prompt config.ProjectPrompt, promptContent string) string {
+	var sb strings.Builder
+
+	// TOML has no managed block markers, the whole file is owned by DNASpec
+	sb.WriteString("# Generated by DNASpec. Do not edit; run 'dnaspec update-agents' to refresh.\n")
+	sb.WriteString(fmt.Sprintf("description = %s\n", tomlBasicString(prompt.Description)))
+
+	body := expandPromptArguments(promptContent, prompt.Arguments, argumentsGemini)
+	if !strings.Contains(body, geminiArgsPlaceholder) {
+		if len(prompt.Arguments) > 0 {
+			body += fmt.Sprintf("\n\nArguments (%s): %s", argumentHint(prompt.Arguments), geminiArgsPlaceholder)
+		} else {
+			body += "\n\n" + geminiArgsPlaceholder
+		}
+	}
+	sb.WriteString(fmt.Sprintf("prompt = %s\n", tomlMultilineString(body)))
+
+	return sb.String()
+}
+
+// tomlBasicString encodes s as a single-line TOML basic string
+func tomlBasicString(s string) string {
+	return `"` + escapeTOML(s, false) + `"`
+}
+
+// tomlMultilineString encodes s as a TOML multi-line basic string
+// The newline after the opening delimiter is trimmed by TOML parsers
+func tomlMultilineString(s string) string {
+	return "\"\"\"\n" + escapeTOML(s, true) + "\"\"\""
+}
+
+// escapeTOML escapes s for use inside a TOML basic string
+// Quotes are always escaped so that no run of three quotes can terminate a multi-line string early
+func escapeTOML(s string, multiline bool) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '"':
+			sb.WriteString(`\"`)
+		case r == '\n' && multiline:
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			sb.WriteString(fmt.Sprintf(`\u%04X`, r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
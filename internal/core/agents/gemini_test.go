@@ -0,0 +1,176 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeTOML(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		multiline bool
+		expected  string
+	}{
+		{
+			name:     "plain text",
+			input:    "Review code",
+			expected: "Review code",
+		},
+		{
+			name:     "quotes and backslashes",
+			input:    `Use "quotes" and C:\path`,
+			expected: `Use \"quotes\" and C:\\path`,
+		},
+		{
+			name:     "newlines in single-line string",
+			input:    "line one\nline two",
+			expected: `line one\nline two`,
+		},
+		{
+			name:      "newlines kept in multi-line string",
+			input:     "line one\nline two",
+			multiline: true,
+			expected:  "line one\nline two",
+		},
+		{
+			name:      "triple quotes cannot close multi-line string",
+			input:     `say """hi"""`,
+			multiline: true,
+			expected:  `say \"\"\"hi\"\"\"`,
+		},
+		{
+			name:     "tab and carriage return",
+			input:    "a\tb\rc",
+			expected: `a\tb\rc`,
+		},
+		{
+			name:     "other control characters",
+			input:    "bell\x07del\x7f",
+			expected: `bell\u0007del\u007F`,
+		},
+		{
+			name:     "unicode passes through",
+			input:    "naïve ✓",
+			expected: "naïve ✓",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, escapeTOML(tt.input, tt.multiline))
+		})
+	}
+}
+
+func TestGenerateGeminiCommandContent(t *testing.T) {
+	prompt := config.ProjectPrompt{
+		Name:        "code-review",
+		File:        "prompts/code-review.md",
+		Description: `Review "Go" code`,
+	}
+
+	t.Run("appends args placeholder", func(t *testing.T) {
+		content := generateGeminiCommandContent(prompt, "Review the code.\n\nUse `gofmt`.\n")
+
+		expected := "# Generated by DNASpec. Do not edit; run 'dnaspec update-agents' to refresh.\n" +
+			"description = \"Review \\\"Go\\\" code\"\n" +
+			"prompt = \"\"\"\nReview the code.\n\nUse `gofmt`.\n\n{{args}}\"\"\"\n"
+		assert.Equal(t, expected, content)
+	})
+
+	t.Run("keeps existing args placeholder", func(t *testing.T) {
+		content := generateGeminiCommandContent(prompt, "Review {{args}} carefully.")
+
+		assert.Contains(t, content, "prompt = \"\"\"\nReview {{args}} carefully.\"\"\"\n")
+	})
+}
+
+func TestGenerateGeminiCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+	sourceDir := filepath.Join("dnaspec", "test-source")
+
+	prompt := config.ProjectPrompt{
+		Name:        "review",
+		File:        "prompts/review.md",
+		Description: "Review code",
+	}
+
+	t.Run("generate command file", func(t *testing.T) {
+		err := GenerateGeminiCommand("test-source", prompt, sourceDir)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(".gemini", "commands", "dnaspec", "test-source-review.toml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `description = "Review code"`)
+		assert.Contains(t, string(content), "Review the code against guidelines.")
+	})
+
+	t.Run("error on missing prompt file", func(t *testing.T) {
+		missing := config.ProjectPrompt{Name: "missing", File: "prompts/missing.md", Description: "Missing"}
+
+		err := GenerateGeminiCommand("test-source", missing, sourceDir)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read prompt file")
+	})
+}
+
+func TestGenerateGeminiMD(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+					},
+				},
+			},
+		},
+	}
+
+	userContent := "# Gemini Notes\n\nPrefer small diffs.\n"
+	err = os.WriteFile("GEMINI.md", []byte(userContent), 0644)
+	require.NoError(t, err)
+
+	err = GenerateGeminiMD(cfg)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile("GEMINI.md")
+	require.NoError(t, err)
+
+	contentStr := string(content)
+	assert.Contains(t, contentStr, "Prefer small diffs.")
+	assert.Contains(t, contentStr, files.ManagedBlockStart)
+	assert.Contains(t, contentStr, "@/dnaspec/test-source/guidelines/test.md")
+}
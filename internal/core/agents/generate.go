@@ -3,7 +3,6 @@ package agents
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/aviator5/dnaspec/internal/core/files"
@@ -11,20 +10,62 @@ import (
 
 // GenerationSummary contains counts of generated files
 type GenerationSummary struct {
-	AgentsMD          bool
-	ClaudeMD          bool
-	ClaudeCommands    int
-	CopilotPrompts    int
-	AntigravityPrompts int
-	WindsurfWorkflows int
-	CursorCommands    int
-	Errors            []error
+	AgentsMD              bool
+	ClaudeMD              bool
+	CopilotInstructionsMD bool
+	ClaudeCommands        int
+	ClaudeSkills          int
+	CopilotPrompts        int
+	CopilotInstructions   int
+	AntigravityPrompts    int
+	WindsurfWorkflows     int
+	CursorCommands        int
+	GeminiMD              bool
+	GeminiCommands        int
+	KiroSteering          int
+	KiroPromptSteering    int
+	RuleFiles             map[string]int // Rule files generated per rule directory agent ID
+	WorkflowFiles         map[string]int // Workflow files generated per rule directory agent ID
+	Errors                []error
+}
+
+// agentSelection records which agents were selected for generation
+type agentSelection struct {
+	claudeCode  bool
+	copilot     bool
+	antigravity bool
+	windsurf    bool
+	cursor      bool
+	gemini      bool
+	kiro        bool
+	ruleDirs    []*RuleDirAgent
+}
+
+// newAgentSelection builds an agentSelection from a list of agent IDs
+func newAgentSelection(agents []string) agentSelection {
+	selection := agentSelection{
+		claudeCode:  contains(agents, "claude-code"),
+		copilot:     contains(agents, "github-copilot"),
+		antigravity: contains(agents, "antigravity"),
+		windsurf:    contains(agents, "windsurf"),
+		cursor:      contains(agents, "cursor"),
+		gemini:      contains(agents, "gemini-cli"),
+		kiro:        contains(agents, "kiro"),
+	}
+	for i := range RuleDirAgents {
+		if contains(agents, RuleDirAgents[i].AgentID) {
+			selection.ruleDirs = append(selection.ruleDirs, &RuleDirAgents[i])
+		}
+	}
+	return selection
 }
 
 // GenerateAgentFiles generates all agent integration files based on config and selected agents
 func GenerateAgentFiles(cfg *config.ProjectConfig, agents []string) (*GenerationSummary, error) {
 	summary := &GenerationSummary{
-		Errors: []error{},
+		RuleFiles:     map[string]int{},
+		WorkflowFiles: map[string]int{},
+		Errors:        []error{},
 	}
 
 	// Always generate AGENTS.md regardless of selected agents
@@ -34,19 +75,46 @@ func GenerateAgentFiles(cfg *config.ProjectConfig, agents []string) (*Generation
 		summary.AgentsMD = true
 	}
 
-	// Check if Claude Code is selected
-	hasClaudeCode := contains(agents, "claude-code")
-	// Check if GitHub Copilot is selected
-	hasCopilot := contains(agents, "github-copilot")
-	// Check if Antigravity is selected
-	hasAntigravity := contains(agents, "antigravity")
-	// Check if Windsurf is selected
-	hasWindsurf := contains(agents, "windsurf")
-	// Check if Cursor is selected
-	hasCursor := contains(agents, "cursor")
+	selection := newAgentSelection(agents)
+
+	// Generate agent-specific context files (CLAUDE.md, GEMINI.md, ...)
+	generateContextFiles(cfg, selection, summary)
+
+	// Generate guideline and prompt files for each source
+	for i := range cfg.Sources {
+		generateSourceFiles(cfg, &cfg.Sources[i], summary, selection)
+	}
+
+	// Return error if there were any failures
+	if len(summary.Errors) > 0 {
+		return summary, fmt.Errorf("generation completed with %d errors", len(summary.Errors))
+	}
 
+	return summary, nil
+}
+
+// generateSourceFiles renders the templates of a source and generates its guideline and prompt files
+func generateSourceFiles(cfg *config.ProjectConfig, source *config.ProjectSource, summary *GenerationSummary, selection agentSelection) {
+	rendered, sourceDir, err := renderSourceFiles(cfg, source, summary)
+	if err != nil {
+		summary.Errors = append(summary.Errors, fmt.Errorf("failed to render source %s: %w", source.Name, err))
+		return
+	}
+	defer func() { _ = os.RemoveAll(sourceDir) }()
+
+	for _, guideline := range rendered.Guidelines {
+		generateGuidelineFiles(cfg, rendered, guideline, sourceDir, summary, selection)
+	}
+
+	for _, prompt := range rendered.Prompts {
+		generatePromptFiles(rendered.Name, prompt, sourceDir, summary, selection)
+	}
+}
+
+// generateContextFiles generates the always-on context files of the selected agents
+func generateContextFiles(cfg *config.ProjectConfig, selection agentSelection, summary *GenerationSummary) {
 	// Generate CLAUDE.md if Claude Code is selected
-	if hasClaudeCode {
+	if selection.claudeCode {
 		if err := GenerateClaudeMD(cfg); err != nil {
 			summary.Errors = append(summary.Errors, fmt.Errorf("failed to generate CLAUDE.md: %w", err))
 		} else {
@@ -54,84 +122,124 @@ func GenerateAgentFiles(cfg *config.ProjectConfig, agents []string) (*Generation
 		}
 	}
 
-	// Generate prompt files for each source
-	for i := range cfg.Sources {
-		source := &cfg.Sources[i]
-		sourceDir := filepath.Join("dnaspec", source.Name)
+	// Generate .github/copilot-instructions.md if GitHub Copilot is selected
+	if selection.copilot {
+		if err := GenerateCopilotInstructionsMD(cfg); err != nil {
+			summary.Errors = append(summary.Errors, fmt.Errorf("failed to generate copilot-instructions.md: %w", err))
+		} else {
+			summary.CopilotInstructionsMD = true
+		}
+	}
 
-		for _, prompt := range source.Prompts {
-			generatePromptFiles(source.Name, prompt, sourceDir, summary,
-				hasClaudeCode, hasCopilot, hasAntigravity, hasWindsurf, hasCursor)
+	// Generate GEMINI.md if Gemini CLI is selected
+	if selection.gemini {
+		if err := GenerateGeminiMD(cfg); err != nil {
+			summary.Errors = append(summary.Errors, fmt.Errorf("failed to generate GEMINI.md: %w", err))
+		} else {
+			summary.GeminiMD = true
 		}
 	}
+}
 
-	// Return error if there were any failures
-	if len(summary.Errors) > 0 {
-		return summary, fmt.Errorf("generation completed with %d errors", len(summary.Errors))
+// generateGuidelineFiles generates guideline files for a single guideline across all selected agents
+func generateGuidelineFiles(cfg *config.ProjectConfig, source *config.ProjectSource, guideline config.ProjectGuideline,
+	sourceDir string, summary *GenerationSummary, selection agentSelection) {
+	// Generate Copilot instructions for scoped guidelines if GitHub Copilot is selected;
+	// always-on guidelines are already included in copilot-instructions.md
+	if selection.copilot {
+		if copilotScoped(guideline) {
+			err := GenerateCopilotInstruction(source.Name, guideline, sourceDir)
+			summary.record(err, &summary.CopilotInstructions, "Copilot instructions", source.Name, guideline.Name)
+		} else if err := RemoveCopilotInstruction(source.Name, guideline.Name); err != nil {
+			summary.Errors = append(summary.Errors, fmt.Errorf("failed to remove Copilot instructions for %s/%s: %w",
+				source.Name, guideline.Name, err))
+		}
 	}
 
-	return summary, nil
+	// Package guideline as a Claude skill if skills mode is enabled
+	if selection.claudeCode && cfg.ClaudeCode.Skills {
+		err := GenerateClaudeSkill(source, guideline, sourceDir)
+		summary.record(err, &summary.ClaudeSkills, "Claude skill", source.Name, guideline.Name)
+	}
+
+	// Generate Kiro steering file if Kiro is selected
+	if selection.kiro {
+		err := GenerateKiroGuidelineSteering(source.Name, guideline, sourceDir)
+		summary.record(err, &summary.KiroSteering, "Kiro steering", source.Name, guideline.Name)
+	}
+
+	// Generate rule files for agents that read a rules directory
+	for _, agent := range selection.ruleDirs {
+		count := summary.RuleFiles[agent.AgentID]
+		err := GenerateRuleFile(agent, source.Name, guideline, sourceDir)
+		summary.record(err, &count, agent.AgentID+" rule", source.Name, guideline.Name)
+		summary.RuleFiles[agent.AgentID] = count
+	}
 }
 
 // generatePromptFiles generates prompt files for a single prompt across all selected agents
 func generatePromptFiles(sourceName string, prompt config.ProjectPrompt, sourceDir string,
-	summary *GenerationSummary, hasClaudeCode, hasCopilot, hasAntigravity, hasWindsurf, hasCursor bool) {
+	summary *GenerationSummary, selection agentSelection) {
 	// Generate Claude command if Claude Code is selected
-	if hasClaudeCode {
-		if err := GenerateClaudeCommand(sourceName, prompt, sourceDir); err != nil {
-			summary.Errors = append(summary.Errors,
-				fmt.Errorf("failed to generate Claude command for %s/%s: %w",
-					sourceName, prompt.Name, err))
-		} else {
-			summary.ClaudeCommands++
-		}
+	if selection.claudeCode {
+		err := GenerateClaudeCommand(sourceName, prompt, sourceDir)
+		summary.record(err, &summary.ClaudeCommands, "Claude command", sourceName, prompt.Name)
 	}
 
 	// Generate Copilot prompt if GitHub Copilot is selected
-	if hasCopilot {
-		if err := GenerateCopilotPrompt(sourceName, prompt, sourceDir); err != nil {
-			summary.Errors = append(summary.Errors,
-				fmt.Errorf("failed to generate Copilot prompt for %s/%s: %w",
-					sourceName, prompt.Name, err))
-		} else {
-			summary.CopilotPrompts++
-		}
+	if selection.copilot {
+		err := GenerateCopilotPrompt(sourceName, prompt, sourceDir)
+		summary.record(err, &summary.CopilotPrompts, "Copilot prompt", sourceName, prompt.Name)
 	}
 
 	// Generate Antigravity prompt if Antigravity is selected
-	if hasAntigravity {
-		if err := GenerateAntigravityPrompt(sourceName, prompt, sourceDir); err != nil {
-			summary.Errors = append(summary.Errors,
-				fmt.Errorf("failed to generate Antigravity prompt for %s/%s: %w",
-					sourceName, prompt.Name, err))
-		} else {
-			summary.AntigravityPrompts++
-		}
+	if selection.antigravity {
+		err := GenerateAntigravityPrompt(sourceName, prompt, sourceDir)
+		summary.record(err, &summary.AntigravityPrompts, "Antigravity prompt", sourceName, prompt.Name)
 	}
 
 	// Generate Windsurf workflow if Windsurf is selected
-	if hasWindsurf {
-		if err := GenerateWindsurfPrompt(sourceName, prompt, sourceDir); err != nil {
-			summary.Errors = append(summary.Errors,
-				fmt.Errorf("failed to generate Windsurf workflow for %s/%s: %w",
-					sourceName, prompt.Name, err))
-		} else {
-			summary.WindsurfWorkflows++
-		}
+	if selection.windsurf {
+		err := GenerateWindsurfPrompt(sourceName, prompt, sourceDir)
+		summary.record(err, &summary.WindsurfWorkflows, "Windsurf workflow", sourceName, prompt.Name)
 	}
 
 	// Generate Cursor command if Cursor is selected
-	if hasCursor {
-		if err := GenerateCursorCommand(sourceName, prompt, sourceDir); err != nil {
-			summary.Errors = append(summary.Errors,
-				fmt.Errorf("failed to generate Cursor command for %s/%s: %w",
-					sourceName, prompt.Name, err))
-		} else {
-			summary.CursorCommands++
-		}
+	if selection.cursor {
+		err := GenerateCursorCommand(sourceName, prompt, sourceDir)
+		summary.record(err, &summary.CursorCommands, "Cursor command", sourceName, prompt.Name)
+	}
+
+	// Generate Gemini command if Gemini CLI is selected
+	if selection.gemini {
+		err := GenerateGeminiCommand(sourceName, prompt, sourceDir)
+		summary.record(err, &summary.GeminiCommands, "Gemini command", sourceName, prompt.Name)
+	}
+
+	// Expose prompt as a manual Kiro steering file if Kiro is selected
+	if selection.kiro {
+		err := GenerateKiroPromptSteering(sourceName, prompt, sourceDir)
+		summary.record(err, &summary.KiroPromptSteering, "Kiro prompt steering", sourceName, prompt.Name)
+	}
+
+	// Generate workflow files for agents that read a workflows directory
+	for _, agent := range selection.ruleDirs {
+		count := summary.WorkflowFiles[agent.AgentID]
+		err := GenerateWorkflowFile(agent, sourceName, prompt, sourceDir)
+		summary.record(err, &count, agent.AgentID+" workflow", sourceName, prompt.Name)
+		summary.WorkflowFiles[agent.AgentID] = count
 	}
 }
 
+// record counts a successfully generated file, or collects the error if generation failed
+func (s *GenerationSummary) record(err error, counter *int, kind, sourceName, name string) {
+	if err != nil {
+		s.Errors = append(s.Errors, fmt.Errorf("failed to generate %s for %s/%s: %w", kind, sourceName, name, err))
+		return
+	}
+	*counter++
+}
+
 // contains checks if a string slice contains a value
 func contains(slice []string, value string) bool {
 	for _, item := range slice {
@@ -144,11 +252,14 @@ func contains(slice []string, value string) bool {
 
 // CleanupSummary contains information about cleanup actions
 type CleanupSummary struct {
-	AgentsMDCleaned bool
-	ClaudeMDCleaned bool
+	AgentsMDCleaned              bool
+	ClaudeMDCleaned              bool
+	CopilotInstructionsMDCleaned bool
+	GeminiMDCleaned              bool
 }
 
-// CleanupAgentFiles removes DNASPEC blocks from AGENTS.md and CLAUDE.md if they exist
+// CleanupAgentFiles removes DNASPEC blocks from AGENTS.md, CLAUDE.md, GEMINI.md and
+// .github/copilot-instructions.md if they exist
 // Returns a summary of what was cleaned up
 func CleanupAgentFiles() (*CleanupSummary, error) {
 	summary := &CleanupSummary{}
@@ -167,6 +278,20 @@ func CleanupAgentFiles() (*CleanupSummary, error) {
 		return summary, fmt.Errorf("failed to cleanup CLAUDE.md: %w", err)
 	}
 
+	// Clean up GEMINI.md
+	if err := cleanupFile("GEMINI.md"); err == nil {
+		summary.GeminiMDCleaned = true
+	} else if !os.IsNotExist(err) {
+		return summary, fmt.Errorf("failed to cleanup GEMINI.md: %w", err)
+	}
+
+	// Clean up .github/copilot-instructions.md
+	if err := cleanupFile(copilotInstructionsPath); err == nil {
+		summary.CopilotInstructionsMDCleaned = true
+	} else if !os.IsNotExist(err) {
+		return summary, fmt.Errorf("failed to cleanup %s: %w", copilotInstructionsPath, err)
+	}
+
 	return summary, nil
 }
 
@@ -86,6 +86,8 @@ func TestGenerateAgentFiles(t *testing.T) {
 		assert.False(t, summary.ClaudeMD, "should not generate CLAUDE.md")
 		assert.Equal(t, 0, summary.ClaudeCommands, "should not generate Claude commands")
 		assert.Equal(t, 2, summary.CopilotPrompts, "should generate 2 Copilot prompts")
+		assert.True(t, summary.CopilotInstructionsMD, "should generate copilot-instructions.md")
+		assert.Equal(t, 0, summary.CopilotInstructions, "always-on guideline should only be in copilot-instructions.md")
 		assert.Empty(t, summary.Errors, "should have no errors")
 
 		// Verify files exist
@@ -93,6 +95,8 @@ func TestGenerateAgentFiles(t *testing.T) {
 		assert.NoFileExists(t, "CLAUDE.md")
 		assert.FileExists(t, ".github/prompts/dnaspec-test-source-review.prompt.md")
 		assert.FileExists(t, ".github/prompts/dnaspec-test-source-lint.prompt.md")
+		assert.FileExists(t, ".github/copilot-instructions.md")
+		assert.NoFileExists(t, ".github/instructions/dnaspec-test-source-test-guideline.instructions.md")
 	})
 
 	t.Run("generate for both agents", func(t *testing.T) {
@@ -106,6 +110,20 @@ func TestGenerateAgentFiles(t *testing.T) {
 		assert.Empty(t, summary.Errors)
 	})
 
+	t.Run("generate for Gemini CLI only", func(t *testing.T) {
+		summary, err := GenerateAgentFiles(cfg, []string{"gemini-cli"})
+		require.NoError(t, err)
+
+		assert.True(t, summary.GeminiMD, "should generate GEMINI.md")
+		assert.Equal(t, 2, summary.GeminiCommands, "should generate 2 Gemini commands")
+		assert.Equal(t, 0, summary.ClaudeCommands, "should not generate Claude commands")
+		assert.Empty(t, summary.Errors, "should have no errors")
+
+		assert.FileExists(t, "GEMINI.md")
+		assert.FileExists(t, ".gemini/commands/dnaspec/test-source-review.toml")
+		assert.FileExists(t, ".gemini/commands/dnaspec/test-source-lint.toml")
+	})
+
 	t.Run("generate with no agents still creates AGENTS.md", func(t *testing.T) {
 		summary, err := GenerateAgentFiles(cfg, []string{})
 		require.NoError(t, err)
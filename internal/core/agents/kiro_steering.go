@@ -0,0 +1,114 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+)
+
+// Kiro steering inclusion modes
+const (
+	kiroInclusionAlways    = "always"
+	kiroInclusionFileMatch = "fileMatch"
+	kiroInclusionManual    = "manual"
+)
+
+// GenerateKiroGuidelineSteering generates a Kiro steering file for a guideline
+func GenerateKiroGuidelineSteering(sourceName string, guideline config.ProjectGuideline, sourceDir string) error {
+	// Generate filename: dnaspec-<source-name>-<guideline-name>.md
+	filename := fmt.Sprintf("dnaspec-%s-%s.md", sourceName, guideline.Name)
+
+	// Read guideline content
+	guidelinePath := filepath.Join(sourceDir, guideline.File)
+	guidelineContent, err := os.ReadFile(guidelinePath)
+	if err != nil {
+		return fmt.Errorf("failed to read guideline file %s: %w", guidelinePath, err)
+	}
+
+	content := generateKiroSteeringContent(kiroInclusionMode(guideline), guideline.FilePatterns, string(guidelineContent),
+		guideline.Agents["kiro"])
+	return writeKiroSteeringFile(filename, content)
+}
+
+// GenerateKiroPromptSteering generates a manual Kiro steering file for a prompt
+// Manual steering files are pulled into a chat with #<file-name>
+func GenerateKiroPromptSteering(sourceName string, prompt config.ProjectPrompt, sourceDir string) error {
+	// Generate filename: dnaspec-<source-name>-<prompt-name>.prompt.md
+	// Guideline names can't contain a dot, so the ".prompt" suffix keeps prompts from colliding with any guideline
+	filename := fmt.Sprintf("dnaspec-%s-%s.prompt.md", sourceName, prompt.Name)
+
+	// Read prompt content
+	promptPath := filepath.Join(sourceDir, prompt.File)
+	promptContent, err := os.ReadFile(promptPath)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file %s: %w", promptPath, err)
+	}
+
+	body := expandPromptArguments(string(promptContent), prompt.Arguments, argumentsPlain)
+	content := generateKiroSteeringContent(kiroInclusionManual, nil, body, prompt.Agents["kiro"])
+	return writeKiroSteeringFile(filename, content)
+}
+
+// writeKiroSteeringFile writes a steering file into .kiro/steering/
+func writeKiroSteeringFile(filename, content string) error {
+	outputPath := filepath.Join(".kiro", "steering", filename)
+
+	// Create directory if needed
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Write atomically
+	return writeFileAtomic(outputPath, []byte(content))
+}
+
+// kiroInclusionMode derives the steering inclusion mode from guideline metadata
+func kiroInclusionMode(guideline config.ProjectGuideline) string {
+	switch {
+	case guideline.ManualOnly:
+		return kiroInclusionManual
+	case len(guideline.FilePatterns) > 0:
+		return kiroInclusionFileMatch
+	default:
+		return kiroInclusionAlways
+	}
+}
+
+// generateKiroSteeringContent creates the full content of a Kiro steering file
+func generateKiroSteeringContent(inclusion string, filePatterns []string, content string, fields map[string]any) string {
+	var sb strings.Builder
+
+	// Frontmatter, merged with the fields set for Kiro in the manifest
+	generated := []frontmatterField{{"inclusion", inclusion}}
+	if inclusion == kiroInclusionFileMatch {
+		generated = append(generated, frontmatterField{"fileMatchPattern", formatKiroFileMatchPattern(filePatterns)})
+	}
+	writeFrontmatter(&sb, generated, fields)
+
+	// Managed block with content
+	sb.WriteString(files.ManagedBlockStart)
+	sb.WriteString("\n")
+	sb.WriteString(strings.TrimSpace(content))
+	sb.WriteString("\n")
+	sb.WriteString(files.ManagedBlockEnd)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// formatKiroFileMatchPattern formats glob patterns as a quoted YAML string, or a flow list for several patterns
+func formatKiroFileMatchPattern(patterns []string) string {
+	quoted := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		quoted[i] = fmt.Sprintf("%q", pattern)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
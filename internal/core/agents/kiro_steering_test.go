@@ -0,0 +1,143 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKiroSteeringContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		guideline   config.ProjectGuideline
+		frontmatter string
+	}{
+		{
+			name:        "always included",
+			guideline:   config.ProjectGuideline{Name: "go-style"},
+			frontmatter: "---\ninclusion: always\n---\n",
+		},
+		{
+			name:        "single file pattern",
+			guideline:   config.ProjectGuideline{Name: "go-style", FilePatterns: []string{"**/*.go"}},
+			frontmatter: "---\ninclusion: fileMatch\nfileMatchPattern: \"**/*.go\"\n---\n",
+		},
+		{
+			name:        "multiple file patterns",
+			guideline:   config.ProjectGuideline{Name: "web", FilePatterns: []string{"**/*.ts", "**/*.tsx"}},
+			frontmatter: "---\ninclusion: fileMatch\nfileMatchPattern: [\"**/*.ts\", \"**/*.tsx\"]\n---\n",
+		},
+		{
+			name:        "manual only",
+			guideline:   config.ProjectGuideline{Name: "release", ManualOnly: true},
+			frontmatter: "---\ninclusion: manual\n---\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := generateKiroSteeringContent(kiroInclusionMode(tt.guideline), tt.guideline.FilePatterns, "# Guideline\n", nil)
+			assert.Equal(t, tt.frontmatter+files.ManagedBlockStart+"\n# Guideline\n"+files.ManagedBlockEnd+"\n", content)
+		})
+	}
+}
+
+func TestGenerateKiroSteeringFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+						FilePatterns:        []string{"**/*_test.go"},
+					},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "review", File: "prompts/review.md", Description: "Review code"},
+				},
+			},
+		},
+	}
+
+	summary, err := GenerateAgentFiles(cfg, []string{"kiro"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.KiroSteering)
+	assert.Equal(t, 1, summary.KiroPromptSteering)
+
+	content, err := os.ReadFile(filepath.Join(".kiro", "steering", "dnaspec-test-source-test-guideline.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "inclusion: fileMatch\nfileMatchPattern: \"**/*_test.go\"\n")
+	assert.Contains(t, string(content), "This is a test guideline.")
+
+	content, err = os.ReadFile(filepath.Join(".kiro", "steering", "dnaspec-test-source-review.prompt.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "inclusion: manual\n")
+	assert.Contains(t, string(content), "Review the code against guidelines.")
+}
+
+func TestGenerateKiroSteeringFiles_PromptNamedLikeGuideline(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "prompt-review",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+					},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "review", File: "prompts/review.md", Description: "Review code"},
+				},
+			},
+		},
+	}
+
+	_, err = GenerateAgentFiles(cfg, []string{"kiro"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(".kiro", "steering", "dnaspec-test-source-prompt-review.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "This is a test guideline.")
+
+	content, err = os.ReadFile(filepath.Join(".kiro", "steering", "dnaspec-test-source-review.prompt.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Review the code against guidelines.")
+}
@@ -3,6 +3,9 @@ package agents
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
 )
 
 // Agent represents an AI agent that can consume DNA guidelines
@@ -31,16 +34,41 @@ var Phase1Agents = []Agent{
 		DisplayName: "Claude Code",
 		Description: "Anthropic's AI assistant with slash commands",
 	},
+	{
+		ID:          "cline",
+		DisplayName: "Cline",
+		Description: "Autonomous coding agent for VS Code with rules and workflows",
+	},
 	{
 		ID:          "cursor",
 		DisplayName: "Cursor",
 		Description: "AI-first code editor",
 	},
+	{
+		ID:          "gemini-cli",
+		DisplayName: "Gemini CLI",
+		Description: "Google's open-source AI agent for the terminal",
+	},
 	{
 		ID:          "github-copilot",
 		DisplayName: "GitHub Copilot",
 		Description: "GitHub's AI pair programmer",
 	},
+	{
+		ID:          "kilo-code",
+		DisplayName: "Kilo Code",
+		Description: "Open-source VS Code coding agent with rules and workflows",
+	},
+	{
+		ID:          "kiro",
+		DisplayName: "Kiro",
+		Description: "Spec-driven AI IDE with steering files",
+	},
+	{
+		ID:          "roo-code",
+		DisplayName: "Roo Code",
+		Description: "VS Code coding agent with rules and custom commands",
+	},
 	{
 		ID:          "windsurf",
 		DisplayName: "Windsurf",
@@ -48,6 +76,9 @@ var Phase1Agents = []Agent{
 	},
 }
 
+// ContextFiles are the shared context files that carry the DNASpec managed block
+var ContextFiles = []string{"AGENTS.md", "CLAUDE.md", "GEMINI.md", ".github/copilot-instructions.md"}
+
 // AgentFilePatterns defines file patterns for all supported agents
 var AgentFilePatterns = []AgentFilePattern{
 	{
@@ -60,16 +91,66 @@ var AgentFilePatterns = []AgentFilePattern{
 		PatternFormat: ".claude/commands/dnaspec/%s-*.md",
 		DisplayFormat: ".claude/commands/dnaspec/%s-*.md",
 	},
+	{
+		AgentID:       "claude-code",
+		PatternFormat: ".claude/skills/dnaspec-%s-*",
+		DisplayFormat: ".claude/skills/dnaspec-%s-*/",
+	},
+	{
+		AgentID:       "cline",
+		PatternFormat: ".clinerules/dnaspec-%s-*.md",
+		DisplayFormat: ".clinerules/dnaspec-%s-*.md",
+	},
+	{
+		AgentID:       "cline",
+		PatternFormat: ".clinerules/workflows/dnaspec-%s-*.md",
+		DisplayFormat: ".clinerules/workflows/dnaspec-%s-*.md",
+	},
 	{
 		AgentID:       "cursor",
 		PatternFormat: ".cursor/commands/dnaspec-%s-*.md",
 		DisplayFormat: ".cursor/commands/dnaspec-%s-*.md",
 	},
+	{
+		AgentID:       "gemini-cli",
+		PatternFormat: ".gemini/commands/dnaspec/%s-*.toml",
+		DisplayFormat: ".gemini/commands/dnaspec/%s-*.toml",
+	},
 	{
 		AgentID:       "github-copilot",
 		PatternFormat: ".github/prompts/dnaspec-%s-*.prompt.md",
 		DisplayFormat: ".github/prompts/dnaspec-%s-*.prompt.md",
 	},
+	{
+		AgentID:       "github-copilot",
+		PatternFormat: ".github/instructions/dnaspec-%s-*.instructions.md",
+		DisplayFormat: ".github/instructions/dnaspec-%s-*.instructions.md",
+	},
+	{
+		AgentID:       "kilo-code",
+		PatternFormat: ".kilocode/rules/dnaspec-%s-*.md",
+		DisplayFormat: ".kilocode/rules/dnaspec-%s-*.md",
+	},
+	{
+		AgentID:       "kilo-code",
+		PatternFormat: ".kilocode/workflows/dnaspec-%s-*.md",
+		DisplayFormat: ".kilocode/workflows/dnaspec-%s-*.md",
+	},
+	{
+		AgentID:       "kiro",
+		PatternFormat: ".kiro/steering/dnaspec-%s-*.md",
+		DisplayFormat: ".kiro/steering/dnaspec-%s-*.md",
+	},
+	{
+		AgentID:       "roo-code",
+		PatternFormat: ".roo/rules/dnaspec-%s-*.md",
+		DisplayFormat: ".roo/rules/dnaspec-%s-*.md",
+	},
+	{
+		AgentID:       "roo-code",
+		PatternFormat: ".roo/commands/dnaspec-%s-*.md",
+		DisplayFormat: ".roo/commands/dnaspec-%s-*.md",
+	},
 	{
 		AgentID:       "windsurf",
 		PatternFormat: ".windsurf/workflows/dnaspec-%s-*.md",
@@ -82,6 +163,38 @@ func (afp AgentFilePattern) GetFilePatternForSource(sourceName string) string {
 	return filepath.FromSlash(fmt.Sprintf(afp.PatternFormat, sourceName))
 }
 
+// GetFilesForSource returns the existing files matching the pattern for a source
+// Files that also match the pattern of another configured source whose name extends this one
+// ("foo-bar" for "foo") belong to that source and are left out
+func (afp AgentFilePattern) GetFilesForSource(cfg *config.ProjectConfig, sourceName string) ([]string, error) {
+	matches, err := filepath.Glob(afp.GetFilePatternForSource(sourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	var others []string
+	for _, source := range cfg.Sources {
+		if strings.HasPrefix(source.Name, sourceName+"-") {
+			others = append(others, afp.GetFilePatternForSource(source.Name))
+		}
+	}
+
+	var files []string
+	for _, match := range matches {
+		owned := true
+		for _, other := range others {
+			if ok, _ := filepath.Match(other, match); ok {
+				owned = false
+				break
+			}
+		}
+		if owned {
+			files = append(files, match)
+		}
+	}
+	return files, nil
+}
+
 // GetDisplayPatternForSource returns the display string for a specific source
 func (afp AgentFilePattern) GetDisplayPatternForSource(sourceName string) string {
 	return fmt.Sprintf(afp.DisplayFormat, sourceName)
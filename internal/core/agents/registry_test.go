@@ -9,13 +9,18 @@ import (
 func TestGetAvailableAgents(t *testing.T) {
 	agents := GetAvailableAgents()
 
-	assert.Len(t, agents, 5, "Should return 5 supported agents")
+	assert.Len(t, agents, 10, "Should return 10 supported agents")
 	// Verify agents are in alphabetical order by ID
 	assert.Equal(t, "antigravity", agents[0].ID)
 	assert.Equal(t, "claude-code", agents[1].ID)
-	assert.Equal(t, "cursor", agents[2].ID)
-	assert.Equal(t, "github-copilot", agents[3].ID)
-	assert.Equal(t, "windsurf", agents[4].ID)
+	assert.Equal(t, "cline", agents[2].ID)
+	assert.Equal(t, "cursor", agents[3].ID)
+	assert.Equal(t, "gemini-cli", agents[4].ID)
+	assert.Equal(t, "github-copilot", agents[5].ID)
+	assert.Equal(t, "kilo-code", agents[6].ID)
+	assert.Equal(t, "kiro", agents[7].ID)
+	assert.Equal(t, "roo-code", agents[8].ID)
+	assert.Equal(t, "windsurf", agents[9].ID)
 }
 
 func TestIsValidAgent(t *testing.T) {
@@ -34,16 +39,41 @@ func TestIsValidAgent(t *testing.T) {
 			agentID:  "claude-code",
 			expected: true,
 		},
+		{
+			name:     "valid cline",
+			agentID:  "cline",
+			expected: true,
+		},
 		{
 			name:     "valid cursor",
 			agentID:  "cursor",
 			expected: true,
 		},
+		{
+			name:     "valid gemini-cli",
+			agentID:  "gemini-cli",
+			expected: true,
+		},
 		{
 			name:     "valid github-copilot",
 			agentID:  "github-copilot",
 			expected: true,
 		},
+		{
+			name:     "valid kilo-code",
+			agentID:  "kilo-code",
+			expected: true,
+		},
+		{
+			name:     "valid kiro",
+			agentID:  "kiro",
+			expected: true,
+		},
+		{
+			name:     "valid roo-code",
+			agentID:  "roo-code",
+			expected: true,
+		},
 		{
 			name:     "valid windsurf",
 			agentID:  "windsurf",
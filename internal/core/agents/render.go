@@ -0,0 +1,71 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/aviator5/dnaspec/internal/core/render"
+)
+
+// renderSourceFiles renders the guideline and prompt templates of a source into a temporary directory
+// Generators read the rendered copies, and the source's asset files, from the returned directory, which the
+// caller must remove. Files that fail to render are reported in the summary and left out of the returned source
+func renderSourceFiles(
+	cfg *config.ProjectConfig,
+	source *config.ProjectSource,
+	summary *GenerationSummary,
+) (rendered *config.ProjectSource, renderedDir string, err error) {
+	renderedDir, err = os.MkdirTemp("", "dnaspec-render-*")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	renderer := render.ForSource(cfg, source, "")
+
+	// Assets are copied as is, so generators that bundle a guideline can take its assets along
+	if err := files.CopyAssets(filepath.Join("dnaspec", source.Name), renderedDir, source.AssetFiles); err != nil {
+		summary.Errors = append(summary.Errors, fmt.Errorf("failed to copy assets of %s: %w", source.Name, err))
+	}
+
+	result := *source
+	result.Guidelines = nil
+	result.Prompts = nil
+
+	for _, guideline := range source.Guidelines {
+		content, err := renderer.RenderFile(guideline.File)
+		if err == nil {
+			err = writeRenderedFile(renderedDir, guideline.File, content)
+		}
+		if err != nil {
+			summary.Errors = append(summary.Errors, fmt.Errorf("failed to render guideline %s/%s: %w", source.Name, guideline.Name, err))
+			continue
+		}
+		result.Guidelines = append(result.Guidelines, guideline)
+	}
+
+	for _, prompt := range source.Prompts {
+		content, err := renderer.RenderPrompt(prompt)
+		if err == nil {
+			err = writeRenderedFile(renderedDir, prompt.File, content)
+		}
+		if err != nil {
+			summary.Errors = append(summary.Errors, fmt.Errorf("failed to render prompt %s/%s: %w", source.Name, prompt.Name, err))
+			continue
+		}
+		result.Prompts = append(result.Prompts, prompt)
+	}
+
+	return &result, renderedDir, nil
+}
+
+// writeRenderedFile writes rendered content to renderedDir/file
+func writeRenderedFile(renderedDir, file, content string) error {
+	outputPath := filepath.Join(renderedDir, file)
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(outputPath), err)
+	}
+	return os.WriteFile(outputPath, []byte(content), 0o644)
+}
@@ -0,0 +1,223 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAgentFiles_RendersTemplates(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+	promptContent := "Check imports of {{.module_path}} and run `{{.test_command}}`."
+	err = os.WriteFile(filepath.Join("dnaspec", "test-source", "prompts", "review.md"), []byte(promptContent), 0644)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Vars:    map[string]string{"module_path": "github.com/acme/app"},
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Variables: []config.Variable{
+					{Name: "module_path"},
+					{Name: "test_command", Default: "go test ./..."},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "review", File: "prompts/review.md", Description: "Review code", Template: true},
+				},
+			},
+		},
+	}
+
+	t.Run("substitutes project vars and manifest defaults", func(t *testing.T) {
+		summary, err := GenerateAgentFiles(cfg, []string{"claude-code"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.ClaudeCommands)
+
+		content, err := os.ReadFile(".claude/commands/dnaspec/test-source-review.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Check imports of github.com/acme/app and run `go test ./...`.")
+
+		// The source file itself stays a template
+		source, err := os.ReadFile(filepath.Join("dnaspec", "test-source", "prompts", "review.md"))
+		require.NoError(t, err)
+		assert.Equal(t, promptContent, string(source))
+	})
+
+	t.Run("uses prompts that aren't templates verbatim", func(t *testing.T) {
+		verbatim := *cfg
+		verbatim.Sources = []config.ProjectSource{cfg.Sources[0]}
+		verbatim.Sources[0].Prompts = []config.ProjectPrompt{{Name: "lint", File: "prompts/lint.md", Description: "Lint code"}}
+		lintContent := "Check the workflow passes `${{ secrets.TOKEN }}` to {{.module_path}}."
+		err := os.WriteFile(filepath.Join("dnaspec", "test-source", "prompts", "lint.md"), []byte(lintContent), 0644)
+		require.NoError(t, err)
+
+		_, err = GenerateAgentFiles(&verbatim, []string{"claude-code"})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(".claude/commands/dnaspec/test-source-lint.md")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), lintContent)
+	})
+
+	t.Run("undefined variable is reported", func(t *testing.T) {
+		undefined := *cfg
+		undefined.Vars = nil
+		undefined.Sources = []config.ProjectSource{cfg.Sources[0]}
+		undefined.Sources[0].Variables = []config.Variable{{Name: "module_path"}}
+
+		summary, err := GenerateAgentFiles(&undefined, []string{"claude-code"})
+		require.Error(t, err)
+		require.Len(t, summary.Errors, 1)
+		assert.Contains(t, summary.Errors[0].Error(), "failed to render prompt test-source/review")
+	})
+}
+
+func TestGenerateAgentFiles_IncludesGuidelines(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+	promptContent := "Review the code against this guideline:\n\n{{include \"test-guideline\"}}\n"
+	err = os.WriteFile(filepath.Join("dnaspec", "test-source", "prompts", "review.md"), []byte(promptContent), 0644)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+					},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "review", File: "prompts/review.md", Description: "Review code", Template: true},
+				},
+			},
+		},
+	}
+
+	_, err = GenerateAgentFiles(cfg, []string{"claude-code"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(".claude/commands/dnaspec/test-source-review.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Review the code against this guideline:\n\n# Test Guideline\n\nThis is a test guideline.\n")
+}
+
+func TestGenerateAgentFiles_StripsFrontmatter(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+	guidelineContent := "---\nname: test-guideline\ndescription: Test guideline\napplicable_scenarios:\n  - testing code\n---\n" +
+		"# Test Guideline\n\nThis is a test guideline.\n"
+	err = os.WriteFile(filepath.Join("dnaspec", "test-source", "guidelines", "test.md"), []byte(guidelineContent), 0644)
+	require.NoError(t, err)
+	promptContent := "---\ndescription: Review code\n---\nReview the code against:\n\n{{include \"test-guideline\"}}\n"
+	err = os.WriteFile(filepath.Join("dnaspec", "test-source", "prompts", "review.md"), []byte(promptContent), 0644)
+	require.NoError(t, err)
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+					},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "review", File: "prompts/review.md", Description: "Review code", Template: true},
+				},
+			},
+		},
+	}
+
+	_, err = GenerateAgentFiles(cfg, []string{"claude-code", "kiro"})
+	require.NoError(t, err)
+
+	command, err := os.ReadFile(".claude/commands/dnaspec/test-source-review.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(command), "Review the code against:\n\n# Test Guideline\n")
+	assert.NotContains(t, string(command), "applicable_scenarios")
+	assert.NotContains(t, string(command), "description: Review code\n---\nReview")
+
+	steering, err := os.ReadFile(".kiro/steering/dnaspec-test-source-test-guideline.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(steering), "# Test Guideline")
+	assert.NotContains(t, string(steering), "applicable_scenarios")
+}
+
+func TestRenderSourceFiles_CopiesAssets(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+	assetPath := filepath.Join("dnaspec", "test-source", "guidelines", "test", "example.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(assetPath), 0755))
+	require.NoError(t, os.WriteFile(assetPath, []byte("package example\n"), 0644))
+
+	cfg := &config.ProjectConfig{Version: 1}
+	source := &config.ProjectSource{
+		Name: "test-source",
+		Guidelines: []config.ProjectGuideline{
+			{Name: "test-guideline", File: "guidelines/test.md", Assets: []string{"guidelines/test/*.go"}},
+		},
+		AssetFiles: []string{"guidelines/test/example.go"},
+	}
+
+	summary := &GenerationSummary{}
+	_, renderedDir, err := renderSourceFiles(cfg, source, summary)
+	require.NoError(t, err)
+	defer os.RemoveAll(renderedDir)
+	assert.Empty(t, summary.Errors)
+
+	content, err := os.ReadFile(filepath.Join(renderedDir, "guidelines", "test", "example.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package example\n", string(content))
+}
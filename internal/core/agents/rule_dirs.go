@@ -0,0 +1,139 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+)
+
+// RuleDirAgent describes an agent that reads rules and workflows from plain markdown directories
+// Guidelines become rule files and prompts become workflow (or command) files
+type RuleDirAgent struct {
+	AgentID      string
+	RulesDir     string // Directory for always-on rule files, relative to project root
+	WorkflowsDir string // Directory for on-demand workflow/command files, relative to project root
+	// WorkflowFrontmatter adds a description frontmatter to workflow files
+	WorkflowFrontmatter bool
+}
+
+// RuleDirAgents are the agents that share the rules/workflows directory layout
+var RuleDirAgents = []RuleDirAgent{
+	{
+		AgentID:      "cline",
+		RulesDir:     ".clinerules",
+		WorkflowsDir: ".clinerules/workflows",
+	},
+	{
+		AgentID:      "kilo-code",
+		RulesDir:     ".kilocode/rules",
+		WorkflowsDir: ".kilocode/workflows",
+	},
+	{
+		AgentID:             "roo-code",
+		RulesDir:            ".roo/rules",
+		WorkflowsDir:        ".roo/commands",
+		WorkflowFrontmatter: true,
+	},
+}
+
+// GetRuleDirAgent returns the rule directory layout for an agent, or nil if the agent doesn't use one
+func GetRuleDirAgent(id string) *RuleDirAgent {
+	for i := range RuleDirAgents {
+		if RuleDirAgents[i].AgentID == id {
+			return &RuleDirAgents[i]
+		}
+	}
+	return nil
+}
+
+// GenerateRuleFile generates a rule file for a guideline in the agent's rules directory
+func GenerateRuleFile(agent *RuleDirAgent, sourceName string, guideline config.ProjectGuideline, sourceDir string) error {
+	// Generate filename: dnaspec-<source-name>-<guideline-name>.md
+	filename := fmt.Sprintf("dnaspec-%s-%s.md", sourceName, guideline.Name)
+	outputPath := filepath.Join(filepath.FromSlash(agent.RulesDir), filename)
+
+	// Create directory if needed
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Read guideline content
+	guidelinePath := filepath.Join(sourceDir, guideline.File)
+	guidelineContent, err := os.ReadFile(guidelinePath)
+	if err != nil {
+		return fmt.Errorf("failed to read guideline file %s: %w", guidelinePath, err)
+	}
+
+	// Rule files carry no frontmatter, only the managed block
+	content := generateRuleFileContent(string(guidelineContent))
+
+	// Write atomically
+	return writeFileAtomic(outputPath, []byte(content))
+}
+
+// generateRuleFileContent creates the full content of a rule file
+func generateRuleFileContent(guidelineContent string) string {
+	var sb strings.Builder
+
+	sb.WriteString(files.ManagedBlockStart)
+	sb.WriteString("\n")
+	sb.WriteString(strings.TrimSpace(guidelineContent))
+	sb.WriteString("\n")
+	sb.WriteString(files.ManagedBlockEnd)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// GenerateWorkflowFile generates a workflow file for a prompt in the agent's workflows directory
+func GenerateWorkflowFile(agent *RuleDirAgent, sourceName string, prompt config.ProjectPrompt, sourceDir string) error {
+	// Generate filename: dnaspec-<source-name>-<prompt-name>.md
+	filename := fmt.Sprintf("dnaspec-%s-%s.md", sourceName, prompt.Name)
+	outputPath := filepath.Join(filepath.FromSlash(agent.WorkflowsDir), filename)
+
+	// Create directory if needed
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Read prompt content
+	promptPath := filepath.Join(sourceDir, prompt.File)
+	promptContent, err := os.ReadFile(promptPath)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file %s: %w", promptPath, err)
+	}
+
+	// Generate frontmatter and content
+	content := generateWorkflowFileContent(agent, prompt, string(promptContent))
+
+	// Write atomically
+	return writeFileAtomic(outputPath, []byte(content))
+}
+
+// generateWorkflowFileContent creates the full content of a workflow file
+func generateWorkflowFileContent(agent *RuleDirAgent, prompt config.ProjectPrompt, promptContent string) string {
+	var sb strings.Builder
+
+	// Frontmatter, merged with the fields set for the agent in the manifest
+	if agent.WorkflowFrontmatter {
+		writeFrontmatter(&sb, []frontmatterField{
+			{"description", fmt.Sprintf("%q", prompt.Description)},
+		}, prompt.Agents[agent.AgentID])
+	}
+
+	// Managed block with prompt content
+	sb.WriteString(files.ManagedBlockStart)
+	sb.WriteString("\n")
+	sb.WriteString(expandPromptArguments(promptContent, prompt.Arguments, argumentsPlain))
+	sb.WriteString("\n")
+	sb.WriteString(files.ManagedBlockEnd)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
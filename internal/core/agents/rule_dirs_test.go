@@ -0,0 +1,122 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRuleDirAgent(t *testing.T) {
+	for _, id := range []string{"cline", "kilo-code", "roo-code"} {
+		agent := GetRuleDirAgent(id)
+		require.NotNil(t, agent, "agent %s should use a rules directory", id)
+		assert.Equal(t, id, agent.AgentID)
+		assert.True(t, IsValidAgent(id), "agent %s should be registered", id)
+	}
+
+	assert.Nil(t, GetRuleDirAgent("claude-code"))
+}
+
+func TestGenerateWorkflowFileContent(t *testing.T) {
+	prompt := config.ProjectPrompt{
+		Name:        "review",
+		File:        "prompts/review.md",
+		Description: "Review code",
+	}
+
+	t.Run("plain workflow", func(t *testing.T) {
+		content := generateWorkflowFileContent(GetRuleDirAgent("cline"), prompt, "Review the code.\n")
+		assert.True(t, strings.HasPrefix(content, files.ManagedBlockStart))
+		assert.NotContains(t, content, "description:")
+	})
+
+	t.Run("workflow with frontmatter", func(t *testing.T) {
+		content := generateWorkflowFileContent(GetRuleDirAgent("roo-code"), prompt, "Review the code.\n")
+		assert.True(t, strings.HasPrefix(content, "---\ndescription: \"Review code\"\n---\n"))
+		assert.Contains(t, content, "Review the code.")
+	})
+}
+
+func TestGenerateRuleDirFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	setupTestSource(t, "test-source")
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "test-source",
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing code"},
+					},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "review", File: "prompts/review.md", Description: "Review code"},
+				},
+			},
+		},
+	}
+
+	summary, err := GenerateAgentFiles(cfg, []string{"cline", "kilo-code", "roo-code"})
+	require.NoError(t, err)
+
+	expected := map[string][]string{
+		"cline": {
+			".clinerules/dnaspec-test-source-test-guideline.md",
+			".clinerules/workflows/dnaspec-test-source-review.md",
+		},
+		"kilo-code": {
+			".kilocode/rules/dnaspec-test-source-test-guideline.md",
+			".kilocode/workflows/dnaspec-test-source-review.md",
+		},
+		"roo-code": {
+			".roo/rules/dnaspec-test-source-test-guideline.md",
+			".roo/commands/dnaspec-test-source-review.md",
+		},
+	}
+
+	for agentID, paths := range expected {
+		assert.Equal(t, 1, summary.RuleFiles[agentID], "%s should have 1 rule file", agentID)
+		assert.Equal(t, 1, summary.WorkflowFiles[agentID], "%s should have 1 workflow file", agentID)
+		for _, path := range paths {
+			assert.FileExists(t, path)
+		}
+	}
+
+	// Every generated file must be covered by the cleanup patterns used by 'dnaspec remove'
+	for _, paths := range expected {
+		for _, path := range paths {
+			matched := false
+			for _, pattern := range AgentFilePatterns {
+				if ok, _ := filepath.Match(pattern.GetFilePatternForSource("test-source"), filepath.FromSlash(path)); ok {
+					matched = true
+					break
+				}
+			}
+			assert.True(t, matched, "%s should match an agent file pattern", path)
+		}
+	}
+
+	ruleContent, err := os.ReadFile(".clinerules/dnaspec-test-source-test-guideline.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(ruleContent), "This is a test guideline.")
+}
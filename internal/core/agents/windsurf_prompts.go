@@ -42,16 +42,16 @@ func GenerateWindsurfPrompt(sourceName string, prompt config.ProjectPrompt, sour
 func generateWindsurfPromptContent(prompt config.ProjectPrompt, promptContent string) string {
 	var sb strings.Builder
 
-	// Frontmatter
-	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("description: %s\n", prompt.Description))
-	sb.WriteString("auto_execution_mode: 3\n")
-	sb.WriteString("---\n")
+	// Frontmatter, merged with the fields set for Windsurf in the manifest
+	writeFrontmatter(&sb, []frontmatterField{
+		{"description", prompt.Description},
+		{"auto_execution_mode", "3"},
+	}, prompt.Agents["windsurf"])
 
 	// Managed block with prompt content
 	sb.WriteString(files.ManagedBlockStart)
 	sb.WriteString("\n")
-	sb.WriteString(strings.TrimSpace(promptContent))
+	sb.WriteString(expandPromptArguments(promptContent, prompt.Arguments, argumentsPlain))
 	sb.WriteString("\n")
 	sb.WriteString(files.ManagedBlockEnd)
 	sb.WriteString("\n")
@@ -0,0 +1,316 @@
+// Package authoring helps DNA maintainers write manifests: it builds manifest entries from the frontmatter
+// of guideline and prompt files.
+package authoring
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/frontmatter"
+)
+
+// entryKind describes a manifest list built from the markdown files of a directory
+type entryKind struct {
+	list   string   // Manifest list, e.g. guidelines
+	dir    string   // Directory scanned for markdown files
+	keys   []string // Frontmatter keys copied into entries
+	decode func(*yaml.Node) error
+}
+
+var entryKinds = []entryKind{
+	{
+		list: "guidelines",
+		dir:  "guidelines",
+		keys: config.EntryKeys(config.ManifestGuideline{}),
+		decode: func(node *yaml.Node) error {
+			var g config.ManifestGuideline
+			return node.Decode(&g)
+		},
+	},
+	{
+		list: "prompts",
+		dir:  "prompts",
+		keys: config.EntryKeys(config.ManifestPrompt{}),
+		decode: func(node *yaml.Node) error {
+			var p config.ManifestPrompt
+			return node.Decode(&p)
+		},
+	},
+}
+
+// BuildResult lists the files whose manifest entries a build changed
+type BuildResult struct {
+	Added    []string
+	Updated  []string
+	Removed  []string // Entries whose file no longer exists
+	Unlinked []string // References to removed entries, dropped from requires, prompts and bundles
+	Skipped  []string // Files whose entry is in a partial manifest, which build doesn't edit
+	Warnings []string
+}
+
+// Changed reports whether the build changed the manifest
+func (r BuildResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0 || len(r.Unlinked) > 0
+}
+
+// BuildManifest updates a manifest from the frontmatter of the markdown files in guidelines/ and prompts/
+// Files with frontmatter get an entry, with their frontmatter keys set on it; other keys, the order of
+// entries and comments are kept. Files without frontmatter are left alone, and entries whose file no longer
+// exists are removed, along with the references to them. Returns the updated manifest YAML.
+func BuildManifest(manifestPath string) ([]byte, BuildResult, error) {
+	var result BuildResult
+	baseDir := filepath.Dir(manifestPath)
+
+	doc, err := config.LoadManifestDocument(manifestPath)
+	if err != nil {
+		return nil, result, fmt.Errorf("failed to load manifest: %w", err)
+	}
+	partials, err := partialManifestFiles(manifestPath)
+	if err != nil {
+		return nil, result, err
+	}
+
+	removed := make(map[string]map[string]bool)
+	for _, kind := range entryKinds {
+		files, err := markdownFiles(baseDir, kind.dir)
+		if err != nil {
+			return nil, result, err
+		}
+		for _, file := range files {
+			if err := buildEntry(doc, baseDir, file, kind, partials, &result); err != nil {
+				return nil, result, err
+			}
+		}
+		removed[kind.list] = removeMissing(doc, baseDir, kind.list, &result)
+	}
+	removeReferences(doc, removed["guidelines"], removed["prompts"], &result)
+
+	data, err := doc.Bytes()
+	if err != nil {
+		return nil, result, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return data, result, nil
+}
+
+// buildEntry adds or updates the manifest entry of a markdown file with frontmatter
+func buildEntry(doc *config.ManifestDocument, baseDir, file string, kind entryKind, partials map[string]string, result *BuildResult) error {
+	content, err := os.ReadFile(filepath.Join(baseDir, filepath.FromSlash(file)))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	fields, err := frontmatter.Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if fields == nil {
+		return nil
+	}
+
+	// Entries start with name and file, like hand-written ones
+	entry := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	name := config.MappingValue(fields, "name")
+	if name == nil {
+		name = config.ScalarNode(strings.TrimSuffix(filepath.Base(file), ".md"))
+	}
+	config.SetMappingValue(entry, "name", name)
+	config.SetMappingValue(entry, "file", config.ScalarNode(file))
+	for i := 0; i+1 < len(fields.Content); i += 2 {
+		key := fields.Content[i].Value
+		if key == "file" || !slices.Contains(kind.keys, key) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s:%d: ignoring frontmatter key '%s'", file, fields.Content[i].Line, key))
+			continue
+		}
+		config.SetMappingValue(entry, key, fields.Content[i+1])
+	}
+	if err := kind.decode(entry); err != nil {
+		return fmt.Errorf("%s: invalid frontmatter: %w", file, err)
+	}
+
+	if partial, ok := partials[file]; ok {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("%s (defined in %s)", file, partial))
+		return nil
+	}
+
+	existing := doc.FindEntry(kind.list, "file", file)
+	if existing == nil {
+		existing = doc.FindEntry(kind.list, "name", name.Value)
+	}
+	if existing == nil {
+		doc.AppendEntry(kind.list, entry)
+		result.Added = append(result.Added, file)
+		return nil
+	}
+
+	updated := false
+	for i := 0; i+1 < len(entry.Content); i += 2 {
+		key, value := entry.Content[i].Value, entry.Content[i+1]
+		// Derived names only apply to new entries, keep the name of an existing one
+		if key == "name" && config.MappingValue(fields, "name") == nil {
+			continue
+		}
+		if sameValue(config.MappingValue(existing, key), value) {
+			continue
+		}
+		config.SetMappingValue(existing, key, value)
+		updated = true
+	}
+	if updated {
+		result.Updated = append(result.Updated, file)
+	}
+	return nil
+}
+
+// sameValue reports whether two YAML nodes hold the same data, regardless of style and comments
+func sameValue(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	var va, vb any
+	if a.Decode(&va) != nil || b.Decode(&vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// removeMissing removes the entries of a manifest list whose file doesn't exist
+// Returns the names of the removed entries
+func removeMissing(doc *config.ManifestDocument, baseDir, list string, result *BuildResult) map[string]bool {
+	removed := make(map[string]bool)
+	for _, entry := range slices.Clone(doc.Entries(list)) {
+		file := config.MappingValue(entry, "file")
+		if file == nil || file.Value == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(file.Value))); os.IsNotExist(err) {
+			doc.RemoveEntry(list, entry)
+			result.Removed = append(result.Removed, file.Value)
+			if name := config.MappingValue(entry, "name"); name != nil {
+				removed[name.Value] = true
+			}
+		}
+	}
+	return removed
+}
+
+// removeReferences drops removed guidelines and prompts from the requires and prompts of guidelines and from bundles
+// A deprecation replacement can't be dropped, so a replacement that was removed is only reported
+func removeReferences(doc *config.ManifestDocument, guidelines, prompts map[string]bool, result *BuildResult) {
+	if len(guidelines) == 0 && len(prompts) == 0 {
+		return
+	}
+
+	for _, entry := range doc.Entries("guidelines") {
+		owner := fmt.Sprintf("guideline '%s'", entryName(entry))
+		// Lists left empty are dropped; an empty bundle is left for validation to report instead
+		if unlink(entry, "requires", guidelines, owner, result) {
+			config.RemoveMappingValue(entry, "requires")
+		}
+		if unlink(entry, "prompts", prompts, owner, result) {
+			config.RemoveMappingValue(entry, "prompts")
+		}
+
+		replacement := config.MappingValue(config.MappingValue(entry, "deprecated"), "replacement")
+		if replacement != nil && guidelines[replacement.Value] {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%s is deprecated in favor of removed guideline '%s'", owner, replacement.Value))
+		}
+	}
+	for _, entry := range doc.Entries("bundles") {
+		unlink(entry, "guidelines", guidelines, fmt.Sprintf("bundle '%s'", entryName(entry)), result)
+	}
+}
+
+// unlink drops the removed names from a list of an entry
+// Returns true when that left the list empty
+func unlink(entry *yaml.Node, key string, removed map[string]bool, owner string, result *BuildResult) bool {
+	list := config.MappingValue(entry, key)
+	if list == nil || list.Kind != yaml.SequenceNode || len(list.Content) == 0 {
+		return false
+	}
+
+	kept := list.Content[:0]
+	for _, item := range list.Content {
+		if removed[item.Value] {
+			result.Unlinked = append(result.Unlinked, fmt.Sprintf("'%s' from the %s of %s", item.Value, key, owner))
+			continue
+		}
+		kept = append(kept, item)
+	}
+	list.Content = kept
+	return len(kept) == 0
+}
+
+// entryName returns the name of a manifest entry, or an empty string
+func entryName(entry *yaml.Node) string {
+	if name := config.MappingValue(entry, "name"); name != nil {
+		return name.Value
+	}
+	return ""
+}
+
+// markdownFiles returns the markdown files below dir as slash-separated paths relative to baseDir
+func markdownFiles(baseDir, dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join(baseDir, dir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == filepath.Join(baseDir, dir) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		rel, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// partialManifestFiles maps the files of entries defined in partial manifests to the partial manifest
+func partialManifestFiles(manifestPath string) (map[string]string, error) {
+	manifest, err := loadMergedManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	partials := make(map[string]string)
+	for i, g := range manifest.Guidelines {
+		if file, _ := manifest.Locate(fmt.Sprintf("guidelines[%d]", i)); file != "" {
+			partials[g.File] = file
+		}
+	}
+	for i, p := range manifest.Prompts {
+		if file, _ := manifest.Locate(fmt.Sprintf("prompts[%d]", i)); file != "" {
+			partials[p.File] = file
+		}
+	}
+	return partials, nil
+}
+
+// loadMergedManifest loads a manifest with its partial manifests, or returns an empty manifest if it's missing
+func loadMergedManifest(manifestPath string) (*config.Manifest, error) {
+	if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
+		return &config.Manifest{}, nil
+	}
+	manifest, err := config.LoadManifest(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+	return manifest, nil
+}
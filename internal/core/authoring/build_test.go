@@ -0,0 +1,284 @@
+package authoring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles writes files below dir, creating directories as needed
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestBuildManifest_NewManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"guidelines/go-style.md": "---\ndescription: Go code style\napplicable_scenarios:\n  - Writing Go code\n---\n# Go Style\n",
+		"guidelines/notes.md":    "# Notes without frontmatter\n",
+		"prompts/review.md":      "---\nname: code-review\ndescription: Review code\n---\nReview the code.\n",
+	})
+
+	data, result, err := BuildManifest(filepath.Join(dir, "dnaspec-manifest.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"guidelines/go-style.md", "prompts/review.md"}, result.Added)
+	assert.Empty(t, result.Updated)
+	assert.Empty(t, result.Removed)
+	assert.True(t, result.Changed())
+	assert.Equal(t, `version: 1
+guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style
+    applicable_scenarios:
+      - Writing Go code
+prompts:
+  - name: code-review
+    file: prompts/review.md
+    description: Review code
+`, string(data))
+}
+
+func TestBuildManifest_KeepsOrderAndComments(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml": `# Go DNA
+version: 1
+guidelines:
+  # Testing comes first on purpose
+  - name: go-testing
+    file: guidelines/go-testing.md
+    description: Old description # from the wiki
+    applicable_scenarios:
+      - Writing tests
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style
+    applicable_scenarios:
+      - Writing Go code
+  - name: removed
+    file: guidelines/removed.md
+    description: Gone
+    applicable_scenarios:
+      - Never
+prompts: []
+`,
+		"guidelines/go-style.md":   "---\ndescription: Go code style\n---\n# Go Style\n",
+		"guidelines/go-testing.md": "---\ndescription: \"Go testing\"\n---\n# Go Testing\n",
+		"guidelines/grpc.md":       "---\ndescription: gRPC services\napplicable_scenarios: [Writing gRPC services]\n---\n# gRPC\n",
+	})
+
+	data, result, err := BuildManifest(manifestPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"guidelines/grpc.md"}, result.Added)
+	assert.Equal(t, []string{"guidelines/go-testing.md"}, result.Updated)
+	assert.Equal(t, []string{"guidelines/removed.md"}, result.Removed)
+	assert.Equal(t, `# Go DNA
+version: 1
+guidelines:
+  # Testing comes first on purpose
+  - name: go-testing
+    file: guidelines/go-testing.md
+    description: "Go testing" # from the wiki
+    applicable_scenarios:
+      - Writing tests
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style
+    applicable_scenarios:
+      - Writing Go code
+  - name: grpc
+    file: guidelines/grpc.md
+    description: gRPC services
+    applicable_scenarios: [Writing gRPC services]
+prompts: []
+`, string(data))
+}
+
+func TestBuildManifest_RemovesReferences(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml": `version: 1
+guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style
+    applicable_scenarios:
+      - Writing Go code
+    requires:
+      - go-legacy
+    prompts:
+      - review
+      - lint
+  - name: go-legacy
+    file: guidelines/go-legacy.md
+    description: Legacy Go
+    applicable_scenarios:
+      - Maintaining legacy services
+  - name: go-old
+    file: guidelines/go-old.md
+    description: Old Go
+    applicable_scenarios:
+      - Maintaining old services
+    deprecated:
+      message: Superseded
+      replacement: go-legacy
+prompts:
+  - name: review
+    file: prompts/review.md
+    description: Review code
+  - name: lint
+    file: prompts/lint.md
+    description: Lint code
+bundles:
+  - name: backend-go
+    description: Go services
+    guidelines:
+      - go-style
+      - go-legacy
+`,
+		"guidelines/go-style.md": "# Go Style\n",
+		"guidelines/go-old.md":   "# Old Go\n",
+		"prompts/review.md":      "Review the code.\n",
+	})
+
+	data, result, err := BuildManifest(manifestPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"guidelines/go-legacy.md", "prompts/lint.md"}, result.Removed)
+	assert.Equal(t, []string{
+		"'go-legacy' from the requires of guideline 'go-style'",
+		"'lint' from the prompts of guideline 'go-style'",
+		"'go-legacy' from the guidelines of bundle 'backend-go'",
+	}, result.Unlinked)
+	assert.Equal(t, []string{"guideline 'go-old' is deprecated in favor of removed guideline 'go-legacy'"}, result.Warnings)
+	assert.Equal(t, `version: 1
+guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style
+    applicable_scenarios:
+      - Writing Go code
+    prompts:
+      - review
+  - name: go-old
+    file: guidelines/go-old.md
+    description: Old Go
+    applicable_scenarios:
+      - Maintaining old services
+    deprecated:
+      message: Superseded
+      replacement: go-legacy
+prompts:
+  - name: review
+    file: prompts/review.md
+    description: Review code
+bundles:
+  - name: backend-go
+    description: Go services
+    guidelines:
+      - go-style
+`, string(data))
+}
+
+func TestBuildManifest_UpToDate(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml": `version: 1
+guidelines:
+  - name: style
+    file: guidelines/go-style.md
+    description: 'Go code style'
+    applicable_scenarios:
+      - Writing Go code
+`,
+		"guidelines/go-style.md": "---\ndescription: Go code style\n---\n# Go Style\n",
+	})
+
+	_, result, err := BuildManifest(manifestPath)
+	require.NoError(t, err)
+
+	// Values that only differ in quoting don't count, and the hand-written name is kept
+	assert.False(t, result.Changed())
+}
+
+func TestBuildManifest_PartialManifests(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml": "version: 1\ninclude:\n  - manifests/*.yaml\n",
+		"manifests/go.yaml": `guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style
+    applicable_scenarios:
+      - Writing Go code
+`,
+		"guidelines/go-style.md": "---\ndescription: Go style\n---\n# Go Style\n",
+	})
+
+	_, result, err := BuildManifest(manifestPath)
+	require.NoError(t, err)
+
+	assert.False(t, result.Changed())
+	assert.Equal(t, []string{"guidelines/go-style.md (defined in manifests/go.yaml)"}, result.Skipped)
+}
+
+func TestBuildManifest_UnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"guidelines/go-style.md": "---\ndescription: Go code style\nauthor: someone\nfile: other.md\n---\n# Go Style\n",
+	})
+
+	data, result, err := BuildManifest(filepath.Join(dir, "dnaspec-manifest.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"guidelines/go-style.md:3: ignoring frontmatter key 'author'",
+		"guidelines/go-style.md:4: ignoring frontmatter key 'file'",
+	}, result.Warnings)
+	assert.Contains(t, string(data), "file: guidelines/go-style.md")
+	assert.NotContains(t, string(data), "author")
+}
+
+func TestBuildManifest_InvalidFrontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "not a mapping",
+			content: "---\n- a\n---\n# Go Style\n",
+			wantErr: "guidelines/go-style.md: invalid frontmatter",
+		},
+		{
+			name:    "wrong type",
+			content: "---\napplicable_scenarios: Writing Go code\n---\n# Go Style\n",
+			wantErr: "guidelines/go-style.md: invalid frontmatter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{"guidelines/go-style.md": tt.content})
+
+			_, _, err := BuildManifest(filepath.Join(dir, "dnaspec-manifest.yaml"))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package authoring
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/aviator5/dnaspec/internal/core/frontmatter"
+	"github.com/aviator5/dnaspec/internal/core/markdown"
+	"github.com/aviator5/dnaspec/internal/core/validate"
+)
+
+// maxDescriptionLength caps descriptions taken from imported content
+const maxDescriptionLength = 120
+
+// importSource is a kind of hand-written agent file that import harvests
+type importSource struct {
+	pattern string // Glob relative to the imported project
+	list    string // Manifest list the file's entries go to
+	split   bool   // Split the file into one entry per section
+}
+
+// importSources are the agent files import harvests, in import order
+var importSources = []importSource{
+	{pattern: "CLAUDE.md", list: "guidelines", split: true},
+	{pattern: ".cursorrules", list: "guidelines", split: true},
+	{pattern: ".github/copilot-instructions.md", list: "guidelines", split: true},
+	{pattern: ".cursor/rules/*.mdc", list: "guidelines"},
+	{pattern: ".claude/commands/*.md", list: "prompts"},
+}
+
+// ImportedEntry is a guideline or prompt harvested from an agent file
+type ImportedEntry struct {
+	List   string // guidelines or prompts
+	Name   string
+	File   string // New file, relative to the manifest
+	Source string // Agent file and section it came from
+}
+
+// ImportResult lists what an import harvested and what it skipped
+type ImportResult struct {
+	Imported []ImportedEntry
+	Skipped  []string
+}
+
+// section is a part of an agent file that becomes a guideline or prompt
+type section struct {
+	name        string
+	title       string // Heading of the section, if any
+	description string
+	content     string
+}
+
+// Import harvests the hand-written agent files of a project into guidelines, prompts and manifest entries
+// Files that cover several topics (CLAUDE.md, .cursorrules, copilot-instructions.md) are split by heading.
+// DNASpec managed blocks are skipped, as are names and files that already exist.
+// With dryRun, nothing is written.
+func Import(manifestPath, projectDir string, dryRun bool) (ImportResult, error) {
+	var result ImportResult
+	baseDir := filepath.Dir(manifestPath)
+
+	doc, err := config.LoadManifestDocument(manifestPath)
+	if err != nil {
+		return result, fmt.Errorf("failed to load manifest: %w", err)
+	}
+	manifest, err := loadMergedManifest(manifestPath)
+	if err != nil {
+		return result, err
+	}
+	taken := map[string][]string{
+		"guidelines": entryNames(manifest, "guidelines"),
+		"prompts":    entryNames(manifest, "prompts"),
+	}
+
+	contents := make(map[string]string)
+	for _, src := range importSources {
+		paths, err := filepath.Glob(filepath.Join(projectDir, filepath.FromSlash(src.pattern)))
+		if err != nil {
+			return result, fmt.Errorf("invalid pattern %s: %w", src.pattern, err)
+		}
+		for _, path := range paths {
+			rel, _ := filepath.Rel(projectDir, path)
+			rel = filepath.ToSlash(rel)
+
+			sections, err := readSections(path, src.split)
+			if err != nil {
+				return result, err
+			}
+			if len(sections) == 0 {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s: nothing to import outside DNASpec managed blocks", rel))
+				continue
+			}
+
+			for _, s := range sections {
+				entry, skipped := importEntry(baseDir, rel, src.list, s, taken[src.list], contents)
+				if skipped != "" {
+					result.Skipped = append(result.Skipped, skipped)
+					continue
+				}
+				taken[src.list] = append(taken[src.list], entry.Name)
+				contents[entry.File] = s.content
+				doc.AppendEntry(src.list, newImportNode(entry, s))
+				result.Imported = append(result.Imported, entry)
+			}
+		}
+	}
+
+	if dryRun || len(result.Imported) == 0 {
+		return result, nil
+	}
+	return result, writeImport(manifestPath, doc, contents)
+}
+
+// importEntry checks that a section can be imported and returns its entry, or why it was skipped
+func importEntry(baseDir, source, list string, s section, taken []string, contents map[string]string) (ImportedEntry, string) {
+	kind := strings.TrimSuffix(list, "s")
+	from := source
+	if s.title != "" {
+		from += " (" + s.title + ")"
+	}
+	entry := ImportedEntry{List: list, Name: s.name, File: list + "/" + s.name + ".md", Source: from}
+
+	if !validate.IsSpinalCase(s.name) {
+		return entry, fmt.Sprintf("%s: can't derive a spinal-case %s name from '%s'", from, kind, s.name)
+	}
+	if slices.Contains(taken, s.name) {
+		return entry, fmt.Sprintf("%s: %s '%s' already exists", from, kind, s.name)
+	}
+	if _, pending := contents[entry.File]; pending {
+		return entry, fmt.Sprintf("%s: file already exists: %s", from, entry.File)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(entry.File))); err == nil {
+		return entry, fmt.Sprintf("%s: file already exists: %s", from, entry.File)
+	}
+	return entry, ""
+}
+
+// newImportNode returns the manifest entry of an imported section
+func newImportNode(entry ImportedEntry, s section) *yaml.Node {
+	description := s.description
+	if description == "" {
+		description = fmt.Sprintf("TODO: describe the %s %s", s.name, strings.TrimSuffix(entry.List, "s"))
+	}
+	node := newEntry(entry.Name, entry.File, description)
+	if entry.List == "guidelines" {
+		scenarios := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		scenarios.Content = append(scenarios.Content, config.ScalarNode("TODO: when to use this guideline"))
+		config.SetMappingValue(node, "applicable_scenarios", scenarios)
+	}
+	return node
+}
+
+// writeImport writes the imported files and the manifest
+func writeImport(manifestPath string, doc *config.ManifestDocument, contents map[string]string) error {
+	data, err := doc.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	baseDir := filepath.Dir(manifestPath)
+	for file, content := range contents {
+		path := filepath.Join(baseDir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// readSections reads an agent file and returns the sections to import
+func readSections(path string, split bool) ([]section, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	content := string(data)
+	for removed := true; removed; {
+		content, removed = files.RemoveManagedBlock(content)
+	}
+
+	// Cursor rules and Claude commands describe themselves in frontmatter
+	var description string
+	if fields, err := frontmatter.Parse(content); err == nil && fields != nil {
+		if value := config.MappingValue(fields, "description"); value != nil && value.Kind == yaml.ScalarNode {
+			description = value.Value
+		}
+	}
+	_, content, _ = frontmatter.Split(content)
+
+	fallback := config.SanitizeName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	var sections []section
+	if split {
+		sections = splitSections(content, fallback)
+	} else if strings.TrimSpace(content) != "" {
+		sections = []section{{name: fallback, content: content}}
+	}
+
+	for i := range sections {
+		if sections[i].description == "" {
+			sections[i].description = description
+		}
+		if sections[i].description == "" {
+			sections[i].description = firstSentence(sections[i].content)
+		}
+		sections[i].content = strings.TrimSpace(sections[i].content) + "\n"
+	}
+	return sections, nil
+}
+
+// splitSections splits markdown content at its topmost heading level that occurs more than once
+// Each section becomes a document of its own, with its heading promoted to the top level. Content outside
+// the sections, such as an introduction, goes to a section named fallback.
+func splitSections(content, fallback string) []section {
+	headings := markdown.Headings(content)
+	level := splitLevel(headings)
+	if level == 0 {
+		if strings.TrimSpace(content) == "" {
+			return nil
+		}
+		s := section{name: fallback, content: content}
+		if len(headings) > 0 && headings[0].Level == 1 {
+			s.name, s.title = config.SanitizeName(headings[0].Text), headings[0].Text
+		}
+		return []section{s}
+	}
+
+	var sections []section
+	var rest strings.Builder
+	bounds := []markdown.Heading{}
+	for _, h := range headings {
+		if h.Level <= level {
+			bounds = append(bounds, h)
+		}
+	}
+	rest.WriteString(content[:bounds[0].Offset])
+	for i, h := range bounds {
+		end := len(content)
+		if i+1 < len(bounds) {
+			end = bounds[i+1].Offset
+		}
+		part := content[h.Offset:end]
+		if h.Level < level {
+			rest.WriteString(part)
+			continue
+		}
+		sections = append(sections, section{
+			name:    config.SanitizeName(h.Text),
+			title:   h.Text,
+			content: markdown.ShiftHeadings(part, 1-level),
+		})
+	}
+
+	if hasText(rest.String()) {
+		sections = append([]section{{name: fallback, content: rest.String()}}, sections...)
+	}
+	return sections
+}
+
+// splitLevel returns the topmost heading level that occurs more than once, or 0
+func splitLevel(headings []markdown.Heading) int {
+	counts := make(map[int]int)
+	for _, h := range headings {
+		counts[h.Level]++
+	}
+	for level := 1; level <= 6; level++ {
+		if counts[level] > 1 {
+			return level
+		}
+	}
+	return 0
+}
+
+// hasText reports whether markdown content has anything besides headings and blank lines
+func hasText(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return true
+		}
+	}
+	return false
+}
+
+// firstSentence returns the first sentence of the first paragraph of markdown content, or ""
+func firstSentence(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*>"))
+		if i := strings.Index(line, ". "); i >= 0 {
+			line = line[:i+1]
+		}
+		if runes := []rune(line); len(runes) > maxDescriptionLength {
+			line = strings.TrimSpace(string(runes[:maxDescriptionLength-3])) + "..."
+		}
+		return line
+	}
+	return ""
+}
@@ -0,0 +1,156 @@
+package authoring
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/validate"
+)
+
+const claudeMD = `# Project Instructions
+
+This service handles payments.
+
+## Code Style
+
+Use gofmt. Keep functions short.
+
+### Naming
+
+Use {{short}} names.
+
+## Testing
+
+- Write table-driven tests.
+
+` + "```sh\n## not a heading\ngo test ./...\n```\n" + `
+<!-- DNASPEC:START -->
+## DNASpec guidelines
+Generated content.
+<!-- DNASPEC:END -->
+`
+
+func TestImport_AgentFiles(t *testing.T) {
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		"CLAUDE.md":                       claudeMD,
+		".cursor/rules/sql.mdc":           "---\ndescription: SQL conventions\nglobs: \"*.sql\"\n---\n# SQL\n\nUse snake_case.\n",
+		".github/copilot-instructions.md": "# Copilot\n\nAlways write tests first.\n",
+		".claude/commands/review.md":      "---\ndescription: Review the diff\n---\nReview the changes in $ARGUMENTS.\n",
+		".claude/commands/dnaspec/x.md":   "<!-- DNASPEC:START -->\nGenerated\n<!-- DNASPEC:END -->\n",
+	})
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+
+	result, err := Import(manifestPath, project, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ImportedEntry{
+		{List: "guidelines", Name: "claude", File: "guidelines/claude.md", Source: "CLAUDE.md"},
+		{List: "guidelines", Name: "code-style", File: "guidelines/code-style.md", Source: "CLAUDE.md (Code Style)"},
+		{List: "guidelines", Name: "testing", File: "guidelines/testing.md", Source: "CLAUDE.md (Testing)"},
+		{List: "guidelines", Name: "copilot", File: "guidelines/copilot.md", Source: ".github/copilot-instructions.md (Copilot)"},
+		{List: "guidelines", Name: "sql", File: "guidelines/sql.md", Source: ".cursor/rules/sql.mdc"},
+		{List: "prompts", Name: "review", File: "prompts/review.md", Source: ".claude/commands/review.md"},
+	}, result.Imported)
+	assert.Empty(t, result.Skipped)
+
+	content, err := os.ReadFile(filepath.Join(dir, "guidelines", "code-style.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Code Style\n\nUse gofmt. Keep functions short.\n\n## Naming\n\nUse {{short}} names.\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(dir, "guidelines", "testing.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Testing\n\n- Write table-driven tests.\n\n```sh\n## not a heading\ngo test ./...\n```\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(dir, "guidelines", "sql.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# SQL\n\nUse snake_case.\n", string(content))
+
+	manifest, err := config.LoadManifest(manifestPath)
+	require.NoError(t, err)
+	descriptions := make(map[string]string)
+	for _, g := range manifest.Guidelines {
+		descriptions[g.Name] = g.Description
+	}
+	for _, p := range manifest.Prompts {
+		descriptions[p.Name] = p.Description
+	}
+	assert.Equal(t, map[string]string{
+		"claude":     "This service handles payments.",
+		"code-style": "Use gofmt.",
+		"testing":    "Write table-driven tests.",
+		"copilot":    "Always write tests first.",
+		"sql":        "SQL conventions",
+		"review":     "Review the diff",
+	}, descriptions)
+	assert.Empty(t, validate.ValidateManifest(manifest, dir))
+}
+
+func TestImport_SkipsExistingAndDuplicates(t *testing.T) {
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		"CLAUDE.md":                        "## Testing\n\nWrite tests.\n\n## Style\n\nUse gofmt.\n",
+		".cursorrules":                     "## Testing\n\nWrite tests.\n\n## Security\n\nNo secrets.\n",
+		".claude/commands/only-managed.md": "<!-- DNASPEC:START -->\nGenerated\n<!-- DNASPEC:END -->\n",
+	})
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml":  commentedManifest,
+		"guidelines/go-style.md": "# Go Style\n",
+		"guidelines/style.md":    "# Style\n",
+	})
+
+	result, err := Import(manifestPath, project, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"testing", "security"}, importedNames(result))
+	assert.Equal(t, []string{
+		"CLAUDE.md (Style): file already exists: guidelines/style.md",
+		".cursorrules (Testing): guideline 'testing' already exists",
+		".claude/commands/only-managed.md: nothing to import outside DNASpec managed blocks",
+	}, result.Skipped)
+
+	// Existing comments are kept
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Style first")
+}
+
+func TestImport_DryRun(t *testing.T) {
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{"CLAUDE.md": "# Rules\n\nBe nice.\n"})
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+
+	result, err := Import(manifestPath, project, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"rules"}, importedNames(result))
+	_, err = os.Stat(manifestPath)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "guidelines"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFirstSentence(t *testing.T) {
+	assert.Equal(t, "Use gofmt.", firstSentence("# Style\n\n- Use gofmt. Always.\n"))
+	assert.Equal(t, "", firstSentence("# Only a heading\n"))
+	assert.Len(t, []rune(firstSentence(strings.Repeat("word ", 50))), maxDescriptionLength)
+}
+
+// importedNames returns the names of the imported entries
+func importedNames(result ImportResult) []string {
+	var names []string
+	for _, entry := range result.Imported {
+		names = append(names, entry.Name)
+	}
+	return names
+}
@@ -0,0 +1,218 @@
+package authoring
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/validate"
+)
+
+// GuidelineOptions configures a new guideline
+type GuidelineOptions struct {
+	Description string
+	Scenarios   []string // Applicable scenarios
+}
+
+// PromptOptions configures a new prompt
+type PromptOptions struct {
+	Description string
+	For         string // Guideline that references the prompt, if any
+}
+
+// NewGuideline writes guidelines/<name>.md from a template and adds its entry to the manifest
+// Returns the path of the new file, relative to the manifest
+func NewGuideline(manifestPath, name string, opts GuidelineOptions) (string, error) {
+	description := opts.Description
+	if description == "" {
+		description = fmt.Sprintf("TODO: describe the %s guideline", name)
+	}
+	scenarios := opts.Scenarios
+	if len(scenarios) == 0 {
+		scenarios = []string{"TODO: when to use this guideline"}
+	}
+
+	file := "guidelines/" + name + ".md"
+	entry := newEntry(name, file, description)
+	scenarioNodes := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, scenario := range scenarios {
+		scenarioNodes.Content = append(scenarioNodes.Content, config.ScalarNode(scenario))
+	}
+	config.SetMappingValue(entry, "applicable_scenarios", scenarioNodes)
+
+	return file, scaffold(manifestPath, "guidelines", entry, guidelineTemplate(name), nil)
+}
+
+// NewPrompt writes prompts/<name>.md from a template and adds its entry to the manifest
+// With opts.For, the prompt is also added to the prompts of that guideline.
+// Returns the path of the new file, relative to the manifest
+func NewPrompt(manifestPath, name string, opts PromptOptions) (string, error) {
+	description := opts.Description
+	if description == "" {
+		description = fmt.Sprintf("TODO: describe the %s prompt", name)
+	}
+
+	file := "prompts/" + name + ".md"
+	entry := newEntry(name, file, description)
+
+	var wire func(*config.ManifestDocument, *config.Manifest) error
+	if opts.For != "" {
+		wire = func(doc *config.ManifestDocument, manifest *config.Manifest) error {
+			return addPromptReference(doc, manifest, opts.For, name)
+		}
+	}
+	return file, scaffold(manifestPath, "prompts", entry, promptTemplate(opts.For), wire)
+}
+
+// scaffold adds an entry to a manifest list and writes its file
+// edit can make further changes to the manifest document before it is saved
+func scaffold(
+	manifestPath, list string,
+	entry *yaml.Node,
+	content string,
+	edit func(*config.ManifestDocument, *config.Manifest) error,
+) error {
+	baseDir := filepath.Dir(manifestPath)
+	name := config.MappingValue(entry, "name").Value
+	file := config.MappingValue(entry, "file").Value
+	kind := strings.TrimSuffix(list, "s")
+
+	if !validate.IsSpinalCase(name) {
+		return fmt.Errorf("invalid %s name '%s' (expected spinal-case: lowercase letters and hyphens only)", kind, name)
+	}
+
+	doc, err := config.LoadManifestDocument(manifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %w", err)
+	}
+	manifest, err := loadMergedManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(entryNames(manifest, list), name) {
+		return fmt.Errorf("%s '%s' already exists in the manifest", kind, name)
+	}
+	path := filepath.Join(baseDir, filepath.FromSlash(file))
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("file already exists: %s", file)
+	}
+
+	doc.AppendEntry(list, entry)
+	if edit != nil {
+		if err := edit(doc, manifest); err != nil {
+			return err
+		}
+	}
+	data, err := doc.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// addPromptReference adds a prompt to the prompts of a guideline
+func addPromptReference(doc *config.ManifestDocument, manifest *config.Manifest, guideline, prompt string) error {
+	entry := doc.FindEntry("guidelines", "name", guideline)
+	if entry == nil {
+		for i, g := range manifest.Guidelines {
+			if g.Name == guideline {
+				file, _ := manifest.Locate(fmt.Sprintf("guidelines[%d]", i))
+				return fmt.Errorf("guideline '%s' is defined in %s, add the prompt to it there", guideline, file)
+			}
+		}
+		return fmt.Errorf("guideline '%s' not found in the manifest", guideline)
+	}
+
+	prompts := config.MappingValue(entry, "prompts")
+	if prompts == nil || prompts.Kind != yaml.SequenceNode {
+		prompts = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		config.SetMappingValue(entry, "prompts", prompts)
+	}
+	prompts.Content = append(prompts.Content, config.ScalarNode(prompt))
+	return nil
+}
+
+// newEntry returns a manifest entry with its name, file and description
+func newEntry(name, file, description string) *yaml.Node {
+	entry := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	config.SetMappingValue(entry, "name", config.ScalarNode(name))
+	config.SetMappingValue(entry, "file", config.ScalarNode(file))
+	config.SetMappingValue(entry, "description", config.ScalarNode(description))
+	return entry
+}
+
+// entryNames returns the names of a manifest list, including guideline aliases
+func entryNames(manifest *config.Manifest, list string) []string {
+	var names []string
+	if list == "prompts" {
+		for _, p := range manifest.Prompts {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+	for _, g := range manifest.Guidelines {
+		names = append(names, g.Name)
+		names = append(names, g.Aliases...)
+	}
+	return names
+}
+
+// guidelineTemplate returns the content of a new guideline file
+func guidelineTemplate(name string) string {
+	return fmt.Sprintf(`# %s
+
+## Overview
+TODO: Describe what this guideline covers.
+
+## When to Use
+- TODO: Scenario
+
+## Standards
+TODO: The rules, patterns and conventions to follow.
+
+### Example
+TODO: Code or configuration examples.
+
+## References
+- TODO: Links to additional resources
+`, title(name))
+}
+
+// promptTemplate returns the content of a new prompt file
+func promptTemplate(guideline string) string {
+	content := `TODO: Describe what the AI agent should do.
+
+Check for:
+- TODO: Specific thing to check
+`
+	if guideline != "" {
+		content += fmt.Sprintf("\nFollow the %s guideline.\n", guideline)
+	}
+	return content + "\nProvide specific feedback with line numbers.\n"
+}
+
+// title turns a spinal-case name into a title, e.g. go-style -> Go Style
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,177 @@
+package authoring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/validate"
+)
+
+const commentedManifest = `# Go DNA
+version: 1
+guidelines:
+  # Style first
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style # short
+    applicable_scenarios:
+      - Writing Go code
+prompts: []
+`
+
+func TestNewGuideline(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml":  commentedManifest,
+		"guidelines/go-style.md": "# Go Style\n",
+	})
+
+	file, err := NewGuideline(manifestPath, "rest-api", GuidelineOptions{
+		Description: "REST API design",
+		Scenarios:   []string{"Designing REST APIs"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "guidelines/rest-api.md", file)
+
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, commentedManifest[:len(commentedManifest)-len("prompts: []\n")]+`  - name: rest-api
+    file: guidelines/rest-api.md
+    description: REST API design
+    applicable_scenarios:
+      - Designing REST APIs
+prompts: []
+`, string(data))
+
+	content, err := os.ReadFile(filepath.Join(dir, "guidelines", "rest-api.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Rest Api\n")
+}
+
+func TestNewGuideline_PlaceholdersAreValid(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+
+	_, err := NewGuideline(manifestPath, "go-style", GuidelineOptions{})
+	require.NoError(t, err)
+
+	manifest, err := config.LoadManifest(manifestPath)
+	require.NoError(t, err)
+	require.Len(t, manifest.Guidelines, 1)
+	assert.Equal(t, "TODO: describe the go-style guideline", manifest.Guidelines[0].Description)
+	assert.Empty(t, validate.ValidateManifest(manifest, dir))
+}
+
+func TestNewGuideline_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		guideline string
+		files     map[string]string
+		wantErr   string
+	}{
+		{
+			name:      "invalid name",
+			guideline: "Go_Style",
+			wantErr:   "invalid guideline name 'Go_Style' (expected spinal-case",
+		},
+		{
+			name:    "existing guideline",
+			files:   map[string]string{"dnaspec-manifest.yaml": commentedManifest},
+			wantErr: "guideline 'go-style' already exists in the manifest",
+		},
+		{
+			name: "existing alias",
+			files: map[string]string{
+				"dnaspec-manifest.yaml": "version: 1\nguidelines:\n  - name: style\n    aliases: [go-style]\n",
+			},
+			wantErr: "guideline 'go-style' already exists in the manifest",
+		},
+		{
+			name:    "existing file",
+			files:   map[string]string{"guidelines/go-style.md": "# Go Style\n"},
+			wantErr: "file already exists: guidelines/go-style.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			name := tt.guideline
+			if name == "" {
+				name = "go-style"
+			}
+
+			_, err := NewGuideline(filepath.Join(dir, "dnaspec-manifest.yaml"), name, GuidelineOptions{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewPrompt_ForGuideline(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml":  commentedManifest,
+		"guidelines/go-style.md": "# Go Style\n",
+	})
+
+	file, err := NewPrompt(manifestPath, "go-review", PromptOptions{Description: "Review Go code", For: "go-style"})
+	require.NoError(t, err)
+	assert.Equal(t, "prompts/go-review.md", file)
+
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, `# Go DNA
+version: 1
+guidelines:
+  # Style first
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style # short
+    applicable_scenarios:
+      - Writing Go code
+    prompts:
+      - go-review
+prompts:
+  - name: go-review
+    file: prompts/go-review.md
+    description: Review Go code
+`, string(data))
+
+	content, err := os.ReadFile(filepath.Join(dir, "prompts", "go-review.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Follow the go-style guideline.")
+
+	manifest, err := config.LoadManifest(manifestPath)
+	require.NoError(t, err)
+	assert.Empty(t, validate.ValidateManifest(manifest, dir))
+}
+
+func TestNewPrompt_Errors(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "dnaspec-manifest.yaml")
+	writeFiles(t, dir, map[string]string{
+		"dnaspec-manifest.yaml": "version: 1\ninclude:\n  - manifests/*.yaml\nprompts:\n  - name: review\n    file: prompts/review.md\n",
+		"manifests/go.yaml":     "guidelines:\n  - name: go-style\n    file: guidelines/go-style.md\n",
+	})
+
+	_, err := NewPrompt(manifestPath, "review", PromptOptions{})
+	assert.ErrorContains(t, err, "prompt 'review' already exists in the manifest")
+
+	_, err = NewPrompt(manifestPath, "go-review", PromptOptions{For: "missing"})
+	assert.ErrorContains(t, err, "guideline 'missing' not found in the manifest")
+
+	_, err = NewPrompt(manifestPath, "go-review", PromptOptions{For: "go-style"})
+	assert.ErrorContains(t, err, "guideline 'go-style' is defined in manifests/go.yaml")
+
+	// Failed commands leave no files behind
+	_, err = os.Stat(filepath.Join(dir, "prompts", "go-review.md"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FindBundle returns the bundle with the given name, or nil if the manifest doesn't define it
+func (m *Manifest) FindBundle(name string) *Bundle {
+	for i := range m.Bundles {
+		if m.Bundles[i].Name == name {
+			return &m.Bundles[i]
+		}
+	}
+	return nil
+}
+
+// BundleNames returns the names of the bundles defined by the manifest
+func (m *Manifest) BundleNames() []string {
+	names := make([]string, 0, len(m.Bundles))
+	for _, bundle := range m.Bundles {
+		names = append(names, bundle.Name)
+	}
+	return names
+}
+
+// hasGuideline reports whether the manifest defines a guideline with the given name
+func (m *Manifest) hasGuideline(name string) bool {
+	return slices.ContainsFunc(m.Guidelines, func(g ManifestGuideline) bool { return g.Name == name })
+}
+
+// ResolveBundles looks up bundles by name and returns the names of their guidelines,
+// without duplicates and in bundle order, along with the bundles to remember in the project config
+func ResolveBundles(manifest *Manifest, names []string) ([]string, []ProjectBundle, error) {
+	var guidelines []string
+	var bundles []ProjectBundle
+	var missing []string
+
+	for _, name := range names {
+		bundle := manifest.FindBundle(name)
+		if bundle == nil {
+			missing = append(missing, name)
+			continue
+		}
+		for _, guideline := range bundle.Guidelines {
+			if !slices.Contains(guidelines, guideline) {
+				guidelines = append(guidelines, guideline)
+			}
+		}
+		bundles = append(bundles, ProjectBundle{Name: bundle.Name, Guidelines: slices.Clone(bundle.Guidelines)})
+	}
+
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("bundles not found: %v (available: %v)", missing, manifest.BundleNames())
+	}
+	return guidelines, bundles, nil
+}
+
+// BundleAddition is a guideline added to a remembered bundle since the project last recorded it
+type BundleAddition struct {
+	Bundle    string
+	Guideline string
+}
+
+// NewBundleGuidelines returns the guidelines added to the source's bundles since they were recorded
+// Guidelines the source already has, or that the manifest doesn't define, are skipped
+func NewBundleGuidelines(source *ProjectSource, manifest *Manifest) []BundleAddition {
+	var additions []BundleAddition
+	seen := make(map[string]bool)
+	for _, g := range source.Guidelines {
+		seen[g.Name] = true
+	}
+
+	for _, recorded := range source.Bundles {
+		bundle := manifest.FindBundle(recorded.Name)
+		if bundle == nil {
+			continue
+		}
+		for _, guideline := range bundle.Guidelines {
+			if seen[guideline] || slices.Contains(recorded.Guidelines, guideline) || !manifest.hasGuideline(guideline) {
+				continue
+			}
+			seen[guideline] = true
+			additions = append(additions, BundleAddition{Bundle: bundle.Name, Guideline: guideline})
+		}
+	}
+
+	return additions
+}
+
+// RefreshBundles records the current guidelines of each remembered bundle
+// Bundles the manifest no longer defines are dropped and returned as removed
+func RefreshBundles(bundles []ProjectBundle, manifest *Manifest) (refreshed []ProjectBundle, removed []string) {
+	for _, recorded := range bundles {
+		bundle := manifest.FindBundle(recorded.Name)
+		if bundle == nil {
+			removed = append(removed, recorded.Name)
+			continue
+		}
+		refreshed = append(refreshed, ProjectBundle{Name: bundle.Name, Guidelines: slices.Clone(bundle.Guidelines)})
+	}
+	return refreshed, removed
+}
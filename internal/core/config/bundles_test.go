@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bundleTestManifest() *Manifest {
+	return &Manifest{
+		Guidelines: []ManifestGuideline{
+			{Name: "go-style"}, {Name: "go-testing"}, {Name: "rest-api"}, {Name: "sql"},
+		},
+		Bundles: []Bundle{
+			{Name: "backend-go", Description: "Go services", Guidelines: []string{"go-style", "go-testing", "rest-api"}},
+			{Name: "data", Description: "Databases", Guidelines: []string{"sql", "go-style"}},
+		},
+	}
+}
+
+func TestResolveBundles(t *testing.T) {
+	manifest := bundleTestManifest()
+
+	t.Run("merges guidelines without duplicates", func(t *testing.T) {
+		guidelines, bundles, err := ResolveBundles(manifest, []string{"backend-go", "data"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go-style", "go-testing", "rest-api", "sql"}, guidelines)
+		assert.Equal(t, []ProjectBundle{
+			{Name: "backend-go", Guidelines: []string{"go-style", "go-testing", "rest-api"}},
+			{Name: "data", Guidelines: []string{"sql", "go-style"}},
+		}, bundles)
+	})
+
+	t.Run("no bundles", func(t *testing.T) {
+		guidelines, bundles, err := ResolveBundles(manifest, nil)
+		require.NoError(t, err)
+		assert.Empty(t, guidelines)
+		assert.Empty(t, bundles)
+	})
+
+	t.Run("unknown bundle", func(t *testing.T) {
+		_, _, err := ResolveBundles(manifest, []string{"frontend"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "bundles not found: [frontend]")
+		assert.Contains(t, err.Error(), "available: [backend-go data]")
+	})
+}
+
+func TestNewBundleGuidelines(t *testing.T) {
+	manifest := bundleTestManifest()
+	manifest.Guidelines = append(manifest.Guidelines, ManifestGuideline{Name: "grpc"})
+	manifest.Bundles[0].Guidelines = append(manifest.Bundles[0].Guidelines, "grpc", "missing")
+
+	source := &ProjectSource{
+		Guidelines: []ProjectGuideline{{Name: "go-style"}, {Name: "go-testing"}},
+		Bundles: []ProjectBundle{
+			{Name: "backend-go", Guidelines: []string{"go-style", "go-testing", "rest-api"}},
+			{Name: "removed-bundle", Guidelines: []string{"sql"}},
+		},
+	}
+
+	// rest-api was deselected before and stays deselected, missing isn't in the manifest
+	additions := NewBundleGuidelines(source, manifest)
+	assert.Equal(t, []BundleAddition{{Bundle: "backend-go", Guideline: "grpc"}}, additions)
+}
+
+func TestRefreshBundles(t *testing.T) {
+	manifest := bundleTestManifest()
+	bundles := []ProjectBundle{
+		{Name: "backend-go", Guidelines: []string{"go-style"}},
+		{Name: "removed-bundle", Guidelines: []string{"sql"}},
+	}
+
+	refreshed, removed := RefreshBundles(bundles, manifest)
+	assert.Equal(t, []ProjectBundle{
+		{Name: "backend-go", Guidelines: []string{"go-style", "go-testing", "rest-api"}},
+	}, refreshed)
+	assert.Equal(t, []string{"removed-bundle"}, removed)
+}
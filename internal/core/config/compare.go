@@ -1,6 +1,7 @@
 package config
 
 import (
+	"reflect"
 	"slices"
 )
 
@@ -9,6 +10,7 @@ type GuidelineComparison struct {
 	Updated   []string // Guidelines that exist in both but have changes
 	New       []string // Guidelines in manifest but not in config
 	Removed   []string // Guidelines in config but not in manifest
+	Renamed   []Rename // Guidelines in config under a name the manifest lists as an alias
 	Unchanged []string // Guidelines with no changes
 }
 
@@ -39,14 +41,17 @@ func CompareGuidelines(
 			} else {
 				result.Unchanged = append(result.Unchanged, name)
 			}
+		} else if newName, renamed := findRenamed(manifestGuidelines, name); renamed {
+			result.Renamed = append(result.Renamed, Rename{From: name, To: newName})
 		} else {
 			result.Removed = append(result.Removed, name)
 		}
 	}
 
-	// Find new guidelines
+	// Find new guidelines, other than the new names of renamed ones
 	for name := range manifestMap {
-		if _, exists := currentMap[name]; !exists {
+		_, exists := currentMap[name]
+		if !exists && !slices.ContainsFunc(result.Renamed, func(r Rename) bool { return r.To == name }) {
 			result.New = append(result.New, name)
 		}
 	}
@@ -66,6 +71,30 @@ func hasChanges(current ProjectGuideline, manifest ManifestGuideline) bool {
 	if !slices.Equal(current.Prompts, manifest.Prompts) {
 		return true
 	}
+	if !slices.Equal(current.Requires, manifest.Requires) {
+		return true
+	}
+	if !slices.Equal(current.Assets, manifest.Assets) {
+		return true
+	}
+	if !reflect.DeepEqual(current.Deprecated, manifest.Deprecated) {
+		return true
+	}
+	if !slices.Equal(current.Tags, manifest.Tags) || !slices.Equal(current.Owners, manifest.Owners) {
+		return true
+	}
+	if current.Version != manifest.Version || current.LastReviewed != manifest.LastReviewed {
+		return true
+	}
+	if !slices.Equal(current.FilePatterns, manifest.FilePatterns) {
+		return true
+	}
+	if current.ManualOnly != manifest.ManualOnly || current.Template != manifest.Template {
+		return true
+	}
+	if !reflect.DeepEqual(current.Agents, manifest.Agents) {
+		return true
+	}
 	return false
 }
 
@@ -119,6 +119,40 @@ func TestCompareGuidelines_Removed(t *testing.T) {
 	}
 }
 
+func TestCompareGuidelines_Renamed(t *testing.T) {
+	current := []ProjectGuideline{
+		{Name: "go-style", File: "guidelines/go-style.md", Description: "Go style"},
+	}
+	manifest := []ManifestGuideline{
+		{Name: "go-conventions", File: "guidelines/go-conventions.md", Description: "Go style", Aliases: []string{"go-style"}},
+		{Name: "go-testing", File: "guidelines/go-testing.md", Description: "Go testing"},
+	}
+
+	result := CompareGuidelines(current, manifest)
+
+	if !slices.Equal(result.Renamed, []Rename{{From: "go-style", To: "go-conventions"}}) {
+		t.Errorf("Expected go-style renamed to go-conventions, got %v", result.Renamed)
+	}
+	if len(result.Removed) != 0 {
+		t.Errorf("Expected 0 removed, got %v", result.Removed)
+	}
+	if !slices.Equal(result.New, []string{"go-testing"}) {
+		t.Errorf("Expected only go-testing in new list, got %v", result.New)
+	}
+}
+
+func TestDeprecationNotice(t *testing.T) {
+	notice := DeprecationNotice("go-style", &Deprecation{Message: "merged into go-conventions", Replacement: "go-conventions"})
+	expected := "'go-style' is deprecated: merged into go-conventions (use 'go-conventions' instead)"
+	if notice != expected {
+		t.Errorf("Expected %q, got %q", expected, notice)
+	}
+
+	if notice := DeprecationNotice("go-style", &Deprecation{}); notice != "'go-style' is deprecated" {
+		t.Errorf("Expected notice without message, got %q", notice)
+	}
+}
+
 func TestCompareGuidelines_Mixed(t *testing.T) {
 	current := []ProjectGuideline{
 		{
@@ -215,6 +249,56 @@ func TestHasChanges_PromptsChanged(t *testing.T) {
 	}
 }
 
+func TestHasChanges_FilePatternsChanged(t *testing.T) {
+	current := ProjectGuideline{
+		Name:         "test",
+		Description:  "Same",
+		FilePatterns: []string{"**/*.go"},
+	}
+	manifest := ManifestGuideline{
+		Name:         "test",
+		Description:  "Same",
+		FilePatterns: []string{"**/*.go", "go.mod"},
+	}
+
+	if !hasChanges(current, manifest) {
+		t.Error("Expected hasChanges to return true for file patterns change")
+	}
+}
+
+func TestHasChanges_ManualOnlyChanged(t *testing.T) {
+	current := ProjectGuideline{
+		Name:        "test",
+		Description: "Same",
+	}
+	manifest := ManifestGuideline{
+		Name:        "test",
+		Description: "Same",
+		ManualOnly:  true,
+	}
+
+	if !hasChanges(current, manifest) {
+		t.Error("Expected hasChanges to return true for manual_only change")
+	}
+}
+
+func TestHasChanges_AgentsChanged(t *testing.T) {
+	current := ProjectGuideline{
+		Name:        "test",
+		Description: "Same",
+		Agents:      AgentFrontmatter{"github-copilot": {"applyTo": "**/*.go"}},
+	}
+	manifest := ManifestGuideline{
+		Name:        "test",
+		Description: "Same",
+		Agents:      AgentFrontmatter{"github-copilot": {"applyTo": "**/*.ts"}},
+	}
+
+	if !hasChanges(current, manifest) {
+		t.Error("Expected hasChanges to return true for agents change")
+	}
+}
+
 func TestHasChanges_NoChanges(t *testing.T) {
 	current := ProjectGuideline{
 		Name:                "test",
@@ -301,3 +385,26 @@ func TestUpdateSourceInConfig_NotFound(t *testing.T) {
 		t.Errorf("Expected no error for nonexistent source, got %v", err)
 	}
 }
+
+func TestHasChanges_RequiresChanged(t *testing.T) {
+	current := ProjectGuideline{Name: "test", Description: "Same", Requires: []string{"error-handling"}}
+	manifest := ManifestGuideline{Name: "test", Description: "Same", Requires: []string{"error-handling", "logging"}}
+
+	if !hasChanges(current, manifest) {
+		t.Error("Expected hasChanges to return true for requires change")
+	}
+}
+
+func TestHasChanges_MetadataChanged(t *testing.T) {
+	current := ProjectGuideline{Name: "test", Description: "Same", Owners: []string{"@platform"}, LastReviewed: "2026-01-10"}
+	manifest := ManifestGuideline{Name: "test", Description: "Same", Owners: []string{"@platform"}, LastReviewed: "2026-01-10"}
+
+	if hasChanges(current, manifest) {
+		t.Error("Expected hasChanges to return false for the same metadata")
+	}
+
+	manifest.LastReviewed = "2026-06-01"
+	if !hasChanges(current, manifest) {
+		t.Error("Expected hasChanges to return true for last_reviewed change")
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import "fmt"
+
+// Deprecation marks a guideline that should no longer be used
+type Deprecation struct {
+	Message     string `yaml:"message"`
+	Replacement string `yaml:"replacement,omitempty"` // Name of the guideline to use instead
+}
+
+// Rename is a guideline a project has under a name the manifest lists as an alias of another guideline
+type Rename struct {
+	From string
+	To   string
+}
+
+// DeprecationNotice describes why a guideline is deprecated and what to use instead
+func DeprecationNotice(name string, deprecation *Deprecation) string {
+	notice := fmt.Sprintf("'%s' is deprecated", name)
+	if deprecation.Message != "" {
+		notice += ": " + deprecation.Message
+	}
+	if deprecation.Replacement != "" {
+		notice += fmt.Sprintf(" (use '%s' instead)", deprecation.Replacement)
+	}
+	return notice
+}
+
+// findRenamed returns the manifest guideline that lists name as an alias
+func findRenamed(manifestGuidelines []ManifestGuideline, name string) (string, bool) {
+	for _, g := range manifestGuidelines {
+		for _, alias := range g.Aliases {
+			if alias == name {
+				return g.Name, true
+			}
+		}
+	}
+	return "", false
+}
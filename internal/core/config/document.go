@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// emptyManifestYAML is the document new manifests start from
+const emptyManifestYAML = "version: 1\nguidelines: []\nprompts: []\n"
+
+// ManifestDocument is a manifest file as a YAML node tree, so that edits keep its comments and ordering
+type ManifestDocument struct {
+	root *yaml.Node // Top-level mapping
+}
+
+// LoadManifestDocument reads a manifest file for editing
+// A missing file yields an empty manifest
+func LoadManifestDocument(path string) (*ManifestDocument, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		data = []byte(emptyManifestYAML)
+	} else if err != nil {
+		return nil, err
+	}
+	return ParseManifestDocument(data)
+}
+
+// ParseManifestDocument parses manifest YAML for editing
+func ParseManifestDocument(data []byte) (*ManifestDocument, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		if err := yaml.Unmarshal([]byte(emptyManifestYAML), &doc); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("manifest must be a YAML mapping")
+	}
+	return &ManifestDocument{root: doc.Content[0]}, nil
+}
+
+// Bytes encodes the document as YAML
+func (d *ManifestDocument) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{d.root}}); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Entries returns the entries of a top-level list such as "guidelines" or "prompts"
+func (d *ManifestDocument) Entries(list string) []*yaml.Node {
+	seq := MappingValue(d.root, list)
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return seq.Content
+}
+
+// FindEntry returns the entry of a top-level list whose key has the given value, or nil
+func (d *ManifestDocument) FindEntry(list, key, value string) *yaml.Node {
+	for _, entry := range d.Entries(list) {
+		if field := MappingValue(entry, key); field != nil && field.Value == value {
+			return entry
+		}
+	}
+	return nil
+}
+
+// AppendEntry adds an entry at the end of a top-level list, creating the list if needed
+func (d *ManifestDocument) AppendEntry(list string, entry *yaml.Node) {
+	seq := MappingValue(d.root, list)
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		SetMappingValue(d.root, list, seq)
+	}
+	// An empty list written as [] would otherwise stay in flow style
+	seq.Style = 0
+	seq.Content = append(seq.Content, entry)
+}
+
+// RemoveEntry removes an entry from a top-level list
+func (d *ManifestDocument) RemoveEntry(list string, entry *yaml.Node) {
+	seq := MappingValue(d.root, list)
+	if seq == nil {
+		return
+	}
+	for i, e := range seq.Content {
+		if e == entry {
+			seq.Content = append(seq.Content[:i], seq.Content[i+1:]...)
+			return
+		}
+	}
+}
+
+// MappingValue returns the value of a key in a YAML mapping node, or nil
+func MappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// SetMappingValue sets the value of a key in a YAML mapping node, appending the key if it's missing
+// Comments on the existing value are kept
+func SetMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			old := mapping.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// RemoveMappingValue removes a key and its value from a YAML mapping node
+func RemoveMappingValue(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// ScalarNode returns a YAML string node
+func ScalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestManifestDocument_KeepsComments(t *testing.T) {
+	doc, err := ParseManifestDocument([]byte(`# DNA for Go projects
+version: 1
+guidelines:
+  # Style first
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go style # keep it short
+prompts: []
+`))
+	require.NoError(t, err)
+
+	entry := doc.FindEntry("guidelines", "name", "go-style")
+	require.NotNil(t, entry)
+	SetMappingValue(entry, "description", ScalarNode("Go code style"))
+	doc.AppendEntry("prompts", &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		ScalarNode("name"), ScalarNode("review"),
+		ScalarNode("file"), ScalarNode("prompts/review.md"),
+	}})
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, `# DNA for Go projects
+version: 1
+guidelines:
+  # Style first
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go code style # keep it short
+prompts:
+  - name: review
+    file: prompts/review.md
+`, string(data))
+}
+
+func TestManifestDocument_RemoveEntry(t *testing.T) {
+	doc, err := ParseManifestDocument([]byte(`version: 1
+guidelines:
+  - name: a
+    file: guidelines/a.md
+  - name: b
+    file: guidelines/b.md
+`))
+	require.NoError(t, err)
+
+	doc.RemoveEntry("guidelines", doc.FindEntry("guidelines", "file", "guidelines/a.md"))
+
+	require.Len(t, doc.Entries("guidelines"), 1)
+	assert.Equal(t, "b", MappingValue(doc.Entries("guidelines")[0], "name").Value)
+	assert.Nil(t, doc.FindEntry("guidelines", "name", "a"))
+}
+
+func TestLoadManifestDocument_MissingFile(t *testing.T) {
+	doc, err := LoadManifestDocument(filepath.Join(t.TempDir(), "dnaspec-manifest.yaml"))
+	require.NoError(t, err)
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, emptyManifestYAML, string(data))
+}
+
+func TestParseManifestDocument_NotAMapping(t *testing.T) {
+	_, err := ParseManifestDocument([]byte("- a\n- b\n"))
+	assert.ErrorContains(t, err, "manifest must be a YAML mapping")
+}
+
+func TestLoadManifestDocument_InvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dnaspec-manifest.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("version: [1\n"), 0644))
+
+	_, err := LoadManifestDocument(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aviator5/dnaspec/internal/core/paths"
+)
+
+// Origin is where a manifest entry was defined
+type Origin struct {
+	File  string // Partial manifest relative to the main manifest's directory, empty for the main manifest
+	Index int    // Index of the entry in that file's list
+}
+
+// manifestOrigins records the origin of each entry of a manifest merged from partial manifests
+type manifestOrigins struct {
+	guidelines []Origin
+	prompts    []Origin
+	variables  []Origin
+	bundles    []Origin
+}
+
+// fieldIndexRegex matches the list and index a validation field starts with, e.g. guidelines[12]
+var fieldIndexRegex = regexp.MustCompile(`^(guidelines|prompts|variables|bundles)\[(\d+)\]`)
+
+// Locate maps a field of the merged manifest, such as guidelines[12].file, to the file that defined it
+// and the field within that file, such as guidelines[2].file
+// Returns an empty file for fields of the main manifest
+func (m *Manifest) Locate(field string) (file, localField string) {
+	match := fieldIndexRegex.FindStringSubmatch(field)
+	if m.origins == nil || match == nil {
+		return "", field
+	}
+
+	var origins []Origin
+	switch match[1] {
+	case "guidelines":
+		origins = m.origins.guidelines
+	case "prompts":
+		origins = m.origins.prompts
+	case "variables":
+		origins = m.origins.variables
+	case "bundles":
+		origins = m.origins.bundles
+	}
+
+	index, err := strconv.Atoi(match[2])
+	if err != nil || index >= len(origins) || origins[index].File == "" {
+		return "", field
+	}
+	origin := origins[index]
+	return origin.File, fmt.Sprintf("%s[%d]%s", match[1], origin.Index, field[len(match[0]):])
+}
+
+// mergeIncludes appends the entries of the partial manifests matched by the include patterns
+func (m *Manifest) mergeIncludes(baseDir, manifestFile string) error {
+	files, err := expandIncludes(baseDir, manifestFile, m.Include)
+	if err != nil {
+		return err
+	}
+
+	m.origins = &manifestOrigins{
+		guidelines: mainOrigins(len(m.Guidelines)),
+		prompts:    mainOrigins(len(m.Prompts)),
+		variables:  mainOrigins(len(m.Variables)),
+		bundles:    mainOrigins(len(m.Bundles)),
+	}
+
+	for _, file := range files {
+		partial, err := parseManifest(filepath.Join(baseDir, filepath.FromSlash(file)))
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if len(partial.Include) > 0 {
+			return fmt.Errorf("%s: include is only supported in the main manifest", file)
+		}
+		if partial.Lint != nil {
+			return fmt.Errorf("%s: lint is only supported in the main manifest", file)
+		}
+
+		if m.partials == nil {
+			m.partials = make(map[string]*yaml.Node)
+		}
+		m.partials[file] = partial.document
+
+		m.Guidelines = append(m.Guidelines, partial.Guidelines...)
+		m.origins.guidelines = append(m.origins.guidelines, fileOrigins(file, len(partial.Guidelines))...)
+		m.Prompts = append(m.Prompts, partial.Prompts...)
+		m.origins.prompts = append(m.origins.prompts, fileOrigins(file, len(partial.Prompts))...)
+		m.Variables = append(m.Variables, partial.Variables...)
+		m.origins.variables = append(m.origins.variables, fileOrigins(file, len(partial.Variables))...)
+		m.Bundles = append(m.Bundles, partial.Bundles...)
+		m.origins.bundles = append(m.origins.bundles, fileOrigins(file, len(partial.Bundles))...)
+	}
+
+	return nil
+}
+
+// expandIncludes returns the files below baseDir matching the include patterns, sorted and without the main manifest
+func expandIncludes(baseDir, manifestFile string, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if pattern == "" || filepath.IsAbs(pattern) || strings.HasPrefix(pattern, "/") ||
+			slices.Contains(strings.Split(pattern, "/"), "..") {
+			return nil, fmt.Errorf("invalid include pattern '%s' (expected a relative path within the repository)", pattern)
+		}
+	}
+
+	matched := make(map[string]bool)
+	var files []string
+	err := filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == manifestFile {
+			return nil
+		}
+		for _, pattern := range patterns {
+			if paths.MatchGlob(pattern, rel) {
+				matched[pattern] = true
+				files = append(files, rel)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find included manifests: %w", err)
+	}
+
+	for _, pattern := range patterns {
+		if !matched[pattern] {
+			return nil, fmt.Errorf("include pattern '%s' matches no files", pattern)
+		}
+	}
+	return files, nil
+}
+
+func mainOrigins(count int) []Origin {
+	return fileOrigins("", count)
+}
+
+func fileOrigins(file string, count int) []Origin {
+	origins := make([]Origin, count)
+	for i := range origins {
+		origins[i] = Origin{File: file, Index: i}
+	}
+	return origins
+}
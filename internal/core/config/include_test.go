@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifests(t *testing.T, dir string, manifests map[string]string) {
+	t.Helper()
+	for file, content := range manifests {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestLoadManifest_Include(t *testing.T) {
+	dir := t.TempDir()
+	writeManifests(t, dir, map[string]string{
+		"dnaspec-manifest.yaml": `version: 1
+include:
+  - manifests/*.yaml
+guidelines:
+  - name: security
+    file: guidelines/security.md
+prompts: []
+`,
+		"manifests/go.yaml": `guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+  - name: go-testing
+    file: guidelines/go-testing.md
+prompts:
+  - name: go-review
+    file: prompts/go-review.md
+`,
+		"manifests/data.yaml": `guidelines:
+  - name: sql
+    file: guidelines/sql.md
+bundles:
+  - name: data
+    description: Databases
+    guidelines: [sql]
+`,
+		"manifests/README.md": "not a manifest",
+	})
+
+	manifest, err := LoadManifest(filepath.Join(dir, "dnaspec-manifest.yaml"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, g := range manifest.Guidelines {
+		names = append(names, g.Name)
+	}
+	// Partial manifests are merged in file name order
+	assert.Equal(t, []string{"security", "sql", "go-style", "go-testing"}, names)
+	require.Len(t, manifest.Prompts, 1)
+	assert.Equal(t, "go-review", manifest.Prompts[0].Name)
+	require.Len(t, manifest.Bundles, 1)
+
+	tests := []struct {
+		field     string
+		wantFile  string
+		wantField string
+	}{
+		{"guidelines[0].file", "", "guidelines[0].file"},
+		{"guidelines[1].name", "manifests/data.yaml", "guidelines[0].name"},
+		{"guidelines[3].requires[1]", "manifests/go.yaml", "guidelines[1].requires[1]"},
+		{"prompts[0].file", "manifests/go.yaml", "prompts[0].file"},
+		{"bundles[0].guidelines[0]", "manifests/data.yaml", "bundles[0].guidelines[0]"},
+		{"version", "", "version"},
+	}
+	for _, tt := range tests {
+		file, field := manifest.Locate(tt.field)
+		assert.Equal(t, tt.wantFile, file, tt.field)
+		assert.Equal(t, tt.wantField, field, tt.field)
+	}
+}
+
+func TestLoadManifest_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifests map[string]string
+		wantError string
+	}{
+		{
+			name: "pattern without matches",
+			manifests: map[string]string{
+				"dnaspec-manifest.yaml": "version: 1\ninclude: [manifests/*.yaml]\n",
+			},
+			wantError: "include pattern 'manifests/*.yaml' matches no files",
+		},
+		{
+			name: "pattern leaving the repository",
+			manifests: map[string]string{
+				"dnaspec-manifest.yaml": "version: 1\ninclude: [../shared/*.yaml]\n",
+			},
+			wantError: "invalid include pattern '../shared/*.yaml'",
+		},
+		{
+			name: "nested include",
+			manifests: map[string]string{
+				"dnaspec-manifest.yaml": "version: 1\ninclude: [manifests/*.yaml]\n",
+				"manifests/go.yaml":     "include: [more/*.yaml]\n",
+			},
+			wantError: "manifests/go.yaml: include is only supported in the main manifest",
+		},
+		{
+			name: "lint in partial",
+			manifests: map[string]string{
+				"dnaspec-manifest.yaml": "version: 1\ninclude: [manifests/*.yaml]\n",
+				"manifests/go.yaml":     "lint:\n  max_file_size_kb: 10\n",
+			},
+			wantError: "manifests/go.yaml: lint is only supported in the main manifest",
+		},
+		{
+			name: "invalid partial",
+			manifests: map[string]string{
+				"dnaspec-manifest.yaml": "version: 1\ninclude: [manifests/*.yaml]\n",
+				"manifests/go.yaml":     "guidelines: [\n",
+			},
+			wantError: "manifests/go.yaml: yaml:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeManifests(t, dir, tt.manifests)
+
+			_, err := LoadManifest(filepath.Join(dir, "dnaspec-manifest.yaml"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
+
+func TestManifest_LocateWithoutIncludes(t *testing.T) {
+	manifest := &Manifest{Guidelines: []ManifestGuideline{{Name: "go-style"}}}
+	file, field := manifest.Locate("guidelines[0].name")
+	assert.Empty(t, file)
+	assert.Equal(t, "guidelines[0].name", field)
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Content lint rules checked by manifest validate
+const (
+	LintEmptyFile            = "empty-file"
+	LintMissingHeading       = "missing-heading"
+	LintUnclosedCodeFence    = "unclosed-code-fence"
+	LintOversizedFile        = "oversized-file"
+	LintFrontmatterCollision = "frontmatter-collision"
+	LintManagedBlockMarker   = "managed-block-marker"
+)
+
+// Severities of validation problems and lint rules
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityOff     = "off" // Rule disabled
+)
+
+// DefaultMaxFileSizeKB is the size above which guideline and prompt files are reported as oversized
+const DefaultMaxFileSizeKB = 50
+
+// DefaultLintSeverities are the severities of lint rules not set in the manifest
+var DefaultLintSeverities = map[string]string{
+	LintEmptyFile:            SeverityError,
+	LintMissingHeading:       SeverityWarning,
+	LintUnclosedCodeFence:    SeverityError,
+	LintOversizedFile:        SeverityWarning,
+	LintFrontmatterCollision: SeverityWarning,
+	LintManagedBlockMarker:   SeverityError,
+}
+
+// LintConfig configures the content checks of manifest validate
+type LintConfig struct {
+	MaxFileSizeKB int               `yaml:"max_file_size_kb,omitempty"`
+	Rules         map[string]string `yaml:"rules,omitempty"` // Severity by rule: error, warning or off
+}
+
+// Severity returns the configured severity of a lint rule, falling back to its default
+func (c *LintConfig) Severity(rule string) string {
+	if c != nil {
+		if severity, ok := c.Rules[rule]; ok {
+			return severity
+		}
+	}
+	return DefaultLintSeverities[rule]
+}
+
+// MaxFileSize returns the size in bytes above which files are reported as oversized
+func (c *LintConfig) MaxFileSize() int {
+	if c != nil && c.MaxFileSizeKB > 0 {
+		return c.MaxFileSizeKB * 1024
+	}
+	return DefaultMaxFileSizeKB * 1024
+}
+
+// EntryKeys returns the YAML keys of a manifest entry type, e.g. ManifestGuideline{}
+func EntryKeys(entry any) []string {
+	var keys []string
+	t := reflect.TypeOf(entry)
+	for i := range t.NumField() {
+		if key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); key != "" && key != "-" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintConfig_Defaults(t *testing.T) {
+	var lint *LintConfig
+
+	assert.Equal(t, SeverityError, lint.Severity(LintEmptyFile))
+	assert.Equal(t, SeverityWarning, lint.Severity(LintMissingHeading))
+	assert.Equal(t, DefaultMaxFileSizeKB*1024, lint.MaxFileSize())
+}
+
+func TestLintConfig_Overrides(t *testing.T) {
+	lint := &LintConfig{
+		MaxFileSizeKB: 10,
+		Rules:         map[string]string{LintMissingHeading: SeverityOff},
+	}
+
+	assert.Equal(t, SeverityOff, lint.Severity(LintMissingHeading))
+	assert.Equal(t, SeverityError, lint.Severity(LintUnclosedCodeFence))
+	assert.Equal(t, 10*1024, lint.MaxFileSize())
+}
+
+func TestEntryKeys(t *testing.T) {
+	keys := EntryKeys(ManifestPrompt{})
+
+	assert.Equal(t, []string{"name", "file", "description"}, keys[:3])
+	assert.NotContains(t, keys, "")
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 )
@@ -9,41 +10,131 @@ import (
 // Manifest represents the dnaspec-manifest.yaml structure
 type Manifest struct {
 	Version    int                 `yaml:"version"`
+	Include    []string            `yaml:"include,omitempty"` // Glob patterns of partial manifests merged into this one
 	Guidelines []ManifestGuideline `yaml:"guidelines"`
 	Prompts    []ManifestPrompt    `yaml:"prompts"`
+	Variables  []Variable          `yaml:"variables,omitempty"`
+	Bundles    []Bundle            `yaml:"bundles,omitempty"`
+	Lint       *LintConfig         `yaml:"lint,omitempty"` // Content checks of manifest validate
+
+	origins  *manifestOrigins      // Set when entries were merged from partial manifests
+	document *yaml.Node            // Parsed main manifest, for field positions
+	partials map[string]*yaml.Node // Parsed partial manifests by file
+}
+
+// Bundle is a named set of guidelines that projects can add together, e.g. backend-go
+type Bundle struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Guidelines  []string `yaml:"guidelines"`
+}
+
+// Variable declares a template variable that guidelines and prompts can use as {{.name}}
+type Variable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Default     string `yaml:"default,omitempty"` // Used when the project doesn't set the variable in vars
 }
 
 // ManifestGuideline represents a single guideline entry
 type ManifestGuideline struct {
-	Name                string   `yaml:"name"`
-	File                string   `yaml:"file"`
-	Description         string   `yaml:"description"`
-	ApplicableScenarios []string `yaml:"applicable_scenarios"`
-	Prompts             []string `yaml:"prompts,omitempty"`
+	Name                string           `yaml:"name"`
+	File                string           `yaml:"file"`
+	Description         string           `yaml:"description"`
+	ApplicableScenarios []string         `yaml:"applicable_scenarios"`
+	Prompts             []string         `yaml:"prompts,omitempty"`
+	Requires            []string         `yaml:"requires,omitempty"`      // Guidelines this guideline depends on
+	Required            bool             `yaml:"required,omitempty"`      // Installed in every project using the source
+	Assets              []string         `yaml:"assets,omitempty"`        // Glob patterns of supporting files copied with the guideline
+	Aliases             []string         `yaml:"aliases,omitempty"`       // Former names, so update can migrate renamed guidelines
+	Deprecated          *Deprecation     `yaml:"deprecated,omitempty"`    // Set when the guideline should no longer be used
+	Tags                []string         `yaml:"tags,omitempty"`          // Topics used to group and filter guidelines
+	Owners              []string         `yaml:"owners,omitempty"`        // People or teams maintaining the guideline
+	Version             string           `yaml:"version,omitempty"`       // Guideline version, e.g. 1.2
+	LastReviewed        string           `yaml:"last_reviewed,omitempty"` // Date of the last review as YYYY-MM-DD
+	FilePatterns        []string         `yaml:"file_patterns,omitempty"` // Glob patterns of files the guideline applies to
+	ManualOnly          bool             `yaml:"manual_only,omitempty"`   // Only include when explicitly referenced
+	Template            bool             `yaml:"template,omitempty"`      // Rendered as a template with variables and includes
+	Agents              AgentFrontmatter `yaml:"agents,omitempty"`
+
+	// Includes lists the guidelines that the guideline's template, or the templates of its prompts, include
+	// It isn't part of the manifest; sources fill it in when they are fetched
+	Includes []string `yaml:"-"`
 }
 
 // ManifestPrompt represents a single prompt entry
 type ManifestPrompt struct {
+	Name        string           `yaml:"name"`
+	File        string           `yaml:"file"`
+	Description string           `yaml:"description"`
+	Arguments   []PromptArgument `yaml:"arguments,omitempty"`
+	Template    bool             `yaml:"template,omitempty"` // Rendered as a template with variables and includes
+	Agents      AgentFrontmatter `yaml:"agents,omitempty"`
+}
+
+// AgentFrontmatter holds frontmatter fields merged into the files generated for specific agents
+// Keyed by agent ID, then by frontmatter key
+type AgentFrontmatter map[string]map[string]any
+
+// PromptArgument declares an argument a prompt accepts, referenced in the prompt as {{arg "name"}}
+type PromptArgument struct {
 	Name        string `yaml:"name"`
-	File        string `yaml:"file"`
-	Description string `yaml:"description"`
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
 }
 
 // LoadManifest loads and parses a manifest file from the given path
+// Partial manifests matched by its include patterns, relative to the manifest's directory, are merged in
 func LoadManifest(path string) (*Manifest, error) {
+	manifest, err := parseManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(manifest.Include) > 0 {
+		if err := manifest.mergeIncludes(filepath.Dir(path), filepath.Base(path)); err != nil {
+			return nil, err
+		}
+	}
+
+	return manifest, nil
+}
+
+// parseManifest parses a single manifest file without merging includes
+func parseManifest(path string) (*Manifest, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var manifest Manifest
-	if err := yaml.Unmarshal(data, &manifest); err != nil {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
 		return nil, err
 	}
+	var manifest Manifest
+	if len(doc.Content) > 0 {
+		if err := doc.Decode(&manifest); err != nil {
+			return nil, err
+		}
+	}
+	manifest.document = &doc
 
 	return &manifest, nil
 }
 
+// Position returns the line and column of a field in the manifest file Locate returned it for
+// Returns 0, 0 when the position is unknown, e.g. for manifests that weren't loaded from a file
+func (m *Manifest) Position(file, field string) (line, column int) {
+	document := m.document
+	if file != "" {
+		document = m.partials[file]
+	}
+	if document == nil {
+		return 0, 0
+	}
+	return FieldPosition(document, field)
+}
+
 // SaveManifest writes a manifest to the given path
 func SaveManifest(path string, manifest *Manifest) error {
 	data, err := yaml.Marshal(manifest)
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fieldSegmentRegex matches the keys and indexes of a field path such as sources[0].guidelines[2].file
+var fieldSegmentRegex = regexp.MustCompile(`[^.\[\]]+|\[\d+\]`)
+
+// LoadYAMLNode reads a YAML file as a node tree, for looking up field positions
+func LoadYAMLNode(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// FieldPosition returns the line and column of a field such as guidelines[0].file in a YAML node tree
+// Fields missing from the document resolve to the closest enclosing node that exists, e.g. the guideline
+// entry for a missing description. Returns 0, 0 when nothing matches.
+func FieldPosition(root *yaml.Node, field string) (line, column int) {
+	node := root
+	if node != nil && node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return 0, 0
+		}
+		node = node.Content[0]
+	}
+
+	for _, segment := range fieldSegmentRegex.FindAllString(field, -1) {
+		next, key := fieldChild(node, segment)
+		if next == nil {
+			break
+		}
+		// Point at the key of mapping entries, which is where the field starts
+		line, column = key.Line, key.Column
+		node = next
+	}
+	return line, column
+}
+
+// fieldChild returns the node a field segment selects in node, and the node marking its position
+func fieldChild(node *yaml.Node, segment string) (child, position *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if segment[0] == '[' {
+		index, err := strconv.Atoi(segment[1 : len(segment)-1])
+		if err != nil || node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+			return nil, nil
+		}
+		return node.Content[index], node.Content[index]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == segment {
+			return node.Content[i+1], node.Content[i]
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFieldPosition(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`version: 1
+guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+  - name: rest-api
+    scenarios:
+      - Designing APIs
+`), &doc))
+
+	tests := []struct {
+		field  string
+		line   int
+		column int
+	}{
+		{"version", 1, 1},
+		{"guidelines", 2, 1},
+		{"guidelines[0]", 3, 5},
+		{"guidelines[0].file", 4, 5},
+		{"guidelines[1].scenarios[0]", 7, 9},
+		// Missing fields resolve to the closest enclosing node
+		{"guidelines[1].description", 5, 5},
+		{"guidelines[5].name", 2, 1},
+		{"prompts", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			line, column := FieldPosition(&doc, tt.field)
+			assert.Equal(t, tt.line, line)
+			assert.Equal(t, tt.column, column)
+		})
+	}
+}
+
+func TestFieldPosition_EmptyDocument(t *testing.T) {
+	line, column := FieldPosition(&yaml.Node{Kind: yaml.DocumentNode}, "version")
+	assert.Zero(t, line)
+	assert.Zero(t, column)
+
+	line, column = FieldPosition(nil, "version")
+	assert.Zero(t, line)
+	assert.Zero(t, column)
+}
+
+func TestManifestPosition(t *testing.T) {
+	dir := t.TempDir()
+	writeManifests(t, dir, map[string]string{
+		"dnaspec-manifest.yaml": `version: 1
+include:
+  - manifests/*.yaml
+guidelines:
+  - name: security
+    file: guidelines/security.md
+prompts: []
+`,
+		"manifests/go.yaml": `guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+`,
+	})
+
+	manifest, err := LoadManifest(filepath.Join(dir, "dnaspec-manifest.yaml"))
+	require.NoError(t, err)
+
+	line, column := manifest.Position("", "guidelines[0].file")
+	assert.Equal(t, 6, line)
+	assert.Equal(t, 5, column)
+
+	file, field := manifest.Locate("guidelines[1].file")
+	require.Equal(t, "manifests/go.yaml", file)
+	line, column = manifest.Position(file, field)
+	assert.Equal(t, 3, line)
+	assert.Equal(t, 5, column)
+
+	line, column = manifest.Position("manifests/unknown.yaml", "guidelines[0]")
+	assert.Zero(t, line)
+	assert.Zero(t, column)
+}
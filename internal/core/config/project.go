@@ -17,37 +17,154 @@ const (
 
 // ProjectConfig represents the dnaspec.yaml structure
 type ProjectConfig struct {
-	Version int             `yaml:"version"`
-	Agents  []string        `yaml:"agents,omitempty"`
-	Sources []ProjectSource `yaml:"sources,omitempty"`
+	Version    int               `yaml:"version"`
+	Agents     []string          `yaml:"agents,omitempty"`
+	AgentsMD   AgentsMDOptions   `yaml:"agents_md,omitempty"`
+	ClaudeCode ClaudeCodeOptions `yaml:"claude_code,omitempty"`
+	Budgets    BudgetOptions     `yaml:"budgets,omitempty"`
+	Links      LinkOptions       `yaml:"links,omitempty"`
+	Vars       map[string]string `yaml:"vars,omitempty"` // Template variables for guidelines and prompts
+	Sources    []ProjectSource   `yaml:"sources,omitempty"`
+}
+
+// Default token budgets used by 'dnaspec validate'
+const (
+	DefaultGuidelineTokenBudget = 4000
+	DefaultPromptTokenBudget    = 2000
+	DefaultAgentFileTokenBudget = 8000
+)
+
+// BudgetOptions holds the approximate token thresholds above which 'dnaspec validate' warns
+// Zero values use the defaults
+type BudgetOptions struct {
+	GuidelineTokens int `yaml:"guideline_tokens,omitempty"`
+	PromptTokens    int `yaml:"prompt_tokens,omitempty"`
+	AgentFileTokens int `yaml:"agent_file_tokens,omitempty"`
+}
+
+// GuidelineLimit returns the token budget of a single guideline
+func (b BudgetOptions) GuidelineLimit() int {
+	return valueOrDefault(b.GuidelineTokens, DefaultGuidelineTokenBudget)
+}
+
+// PromptLimit returns the token budget of a single prompt
+func (b BudgetOptions) PromptLimit() int {
+	return valueOrDefault(b.PromptTokens, DefaultPromptTokenBudget)
+}
+
+// AgentFileLimit returns the token budget of a single generated agent file
+func (b BudgetOptions) AgentFileLimit() int {
+	return valueOrDefault(b.AgentFileTokens, DefaultAgentFileTokenBudget)
+}
+
+func valueOrDefault(value, defaultValue int) int {
+	if value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// AgentsMD rendering modes
+const (
+	// AgentsMDModePointer lists guidelines as @/dnaspec/... file references (default)
+	AgentsMDModePointer = "pointer"
+	// AgentsMDModeInline embeds the guideline markdown in the managed block
+	AgentsMDModeInline = "inline"
+)
+
+// DefaultInlineBudget is the default maximum size in bytes of guideline content inlined into AGENTS.md
+const DefaultInlineBudget = 32 * 1024
+
+// AgentsMDOptions holds rendering settings for AGENTS.md and the context files sharing its content
+type AgentsMDOptions struct {
+	// Mode is the default rendering mode for all guidelines: "pointer" (default) or "inline"
+	Mode string `yaml:"mode,omitempty"`
+	// InlineBudget caps the bytes of inlined guideline content; guidelines that don't fit fall back to pointer mode
+	InlineBudget int `yaml:"inline_budget,omitempty"`
+	// Guidelines overrides the mode per guideline, keyed by "<source-name>/<guideline-name>"
+	Guidelines map[string]string `yaml:"guidelines,omitempty"`
+}
+
+// GuidelineMode returns the rendering mode of a guideline, applying per-guideline overrides
+func (o AgentsMDOptions) GuidelineMode(sourceName, guidelineName string) string {
+	if mode, ok := o.Guidelines[sourceName+"/"+guidelineName]; ok && mode != "" {
+		return mode
+	}
+	if o.Mode != "" {
+		return o.Mode
+	}
+	return AgentsMDModePointer
+}
+
+// Budget returns the inline size budget in bytes
+func (o AgentsMDOptions) Budget() int {
+	return valueOrDefault(o.InlineBudget, DefaultInlineBudget)
+}
+
+// ClaudeCodeOptions holds generation settings specific to the claude-code agent
+type ClaudeCodeOptions struct {
+	// Skills packages each guideline as a .claude/skills/<name>/SKILL.md directory
+	Skills bool `yaml:"skills,omitempty"`
+}
+
+// LinkOptions holds how add and update treat links in guidelines and prompts to files that aren't installed
+type LinkOptions struct {
+	// RewriteUpstream rewrites such links in git sources to the file in the upstream repository at the pinned commit
+	RewriteUpstream bool `yaml:"rewrite_upstream,omitempty"`
 }
 
 // ProjectSource represents a DNA source in the project configuration
 type ProjectSource struct {
-	Name       string             `yaml:"name"`
-	Type       string             `yaml:"type"` // "git-repo" or "local-path"
-	URL        string             `yaml:"url,omitempty"`
-	Path       string             `yaml:"path,omitempty"`
-	Ref        string             `yaml:"ref,omitempty"`
-	Commit     string             `yaml:"commit,omitempty"`
-	Guidelines []ProjectGuideline `yaml:"guidelines,omitempty"`
-	Prompts    []ProjectPrompt    `yaml:"prompts,omitempty"`
+	Name               string             `yaml:"name"`
+	Type               string             `yaml:"type"` // "git-repo" or "local-path"
+	URL                string             `yaml:"url,omitempty"`
+	Path               string             `yaml:"path,omitempty"`
+	Ref                string             `yaml:"ref,omitempty"`
+	Commit             string             `yaml:"commit,omitempty"`
+	Guidelines         []ProjectGuideline `yaml:"guidelines,omitempty"`
+	Prompts            []ProjectPrompt    `yaml:"prompts,omitempty"`
+	Variables          []Variable         `yaml:"variables,omitempty"`           // Template variables declared by the source manifest
+	Bundles            []ProjectBundle    `yaml:"bundles,omitempty"`             // Bundles the guidelines were selected from
+	RequiredGuidelines []string           `yaml:"required_guidelines,omitempty"` // Guidelines the source manifest requires in every project
+	AssetFiles         []string           `yaml:"asset_files,omitempty"`         // Asset files copied for the guidelines, pruned by update
+}
+
+// ProjectBundle remembers a bundle selected from the source manifest
+// Guidelines holds the bundle's guidelines as of the last add or update, so that
+// update can tell which guidelines were added to the bundle since
+type ProjectBundle struct {
+	Name       string   `yaml:"name"`
+	Guidelines []string `yaml:"guidelines,omitempty"`
 }
 
 // ProjectGuideline represents a guideline in the project configuration
 type ProjectGuideline struct {
-	Name                string   `yaml:"name"`
-	File                string   `yaml:"file"`
-	Description         string   `yaml:"description"`
-	ApplicableScenarios []string `yaml:"applicable_scenarios,omitempty"`
-	Prompts             []string `yaml:"prompts,omitempty"`
+	Name                string           `yaml:"name"`
+	File                string           `yaml:"file"`
+	Description         string           `yaml:"description"`
+	ApplicableScenarios []string         `yaml:"applicable_scenarios,omitempty"`
+	Prompts             []string         `yaml:"prompts,omitempty"`
+	Requires            []string         `yaml:"requires,omitempty"`
+	Assets              []string         `yaml:"assets,omitempty"`
+	Deprecated          *Deprecation     `yaml:"deprecated,omitempty"`
+	Tags                []string         `yaml:"tags,omitempty"`
+	Owners              []string         `yaml:"owners,omitempty"`
+	Version             string           `yaml:"version,omitempty"`
+	LastReviewed        string           `yaml:"last_reviewed,omitempty"`
+	FilePatterns        []string         `yaml:"file_patterns,omitempty"`
+	ManualOnly          bool             `yaml:"manual_only,omitempty"`
+	Template            bool             `yaml:"template,omitempty"`
+	Agents              AgentFrontmatter `yaml:"agents,omitempty"`
 }
 
 // ProjectPrompt represents a prompt in the project configuration
 type ProjectPrompt struct {
-	Name        string `yaml:"name"`
-	File        string `yaml:"file"`
-	Description string `yaml:"description"`
+	Name        string           `yaml:"name"`
+	File        string           `yaml:"file"`
+	Description string           `yaml:"description"`
+	Arguments   []PromptArgument `yaml:"arguments,omitempty"`
+	Template    bool             `yaml:"template,omitempty"`
+	Agents      AgentFrontmatter `yaml:"agents,omitempty"`
 }
 
 // LoadProjectConfig loads and parses a project config file from the given path
@@ -0,0 +1,72 @@
+package config
+
+import "slices"
+
+// Dependency is a guideline selected because another selected guideline requires or includes it
+type Dependency struct {
+	Name       string
+	RequiredBy string // Empty when the source marks the guideline as required in every project
+	Included   bool   // Set when RequiredBy, or one of its prompts, includes the guideline
+}
+
+// RequiredGuidelineNames returns the names of the guidelines marked as required in every project
+func RequiredGuidelineNames(guidelines []ManifestGuideline) []string {
+	var names []string
+	for _, g := range guidelines {
+		if g.Required {
+			names = append(names, g.Name)
+		}
+	}
+	return names
+}
+
+// AddRequiredGuidelines extends a selection of guideline names with the guidelines marked as required
+// and the guidelines the selection requires or includes, transitively
+// Returns the selected names followed by the added dependencies, and why each dependency was added
+// Required names missing from available are skipped; 'dnaspec manifest validate' reports them
+func AddRequiredGuidelines(available []ManifestGuideline, selected []string) ([]string, []Dependency) {
+	byName := make(map[string]*ManifestGuideline, len(available))
+	for i := range available {
+		byName[available[i].Name] = &available[i]
+	}
+
+	result := slices.Clone(selected)
+	included := make(map[string]bool, len(selected))
+	for _, name := range selected {
+		included[name] = true
+	}
+
+	var dependencies []Dependency
+	for _, name := range RequiredGuidelineNames(available) {
+		if !included[name] {
+			included[name] = true
+			result = append(result, name)
+			dependencies = append(dependencies, Dependency{Name: name})
+		}
+	}
+
+	for i := 0; i < len(result); i++ {
+		guideline, ok := byName[result[i]]
+		if !ok {
+			continue
+		}
+		for _, required := range guideline.Requires {
+			if included[required] || byName[required] == nil {
+				continue
+			}
+			included[required] = true
+			result = append(result, required)
+			dependencies = append(dependencies, Dependency{Name: required, RequiredBy: guideline.Name})
+		}
+		for _, target := range guideline.Includes {
+			if included[target] || byName[target] == nil {
+				continue
+			}
+			included[target] = true
+			result = append(result, target)
+			dependencies = append(dependencies, Dependency{Name: target, RequiredBy: guideline.Name, Included: true})
+		}
+	}
+
+	return result, dependencies
+}
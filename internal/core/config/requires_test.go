@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRequiredGuidelines(t *testing.T) {
+	available := []ManifestGuideline{
+		{Name: "rest-api", Requires: []string{"error-handling", "logging"}},
+		{Name: "error-handling", Requires: []string{"logging", "go-style"}},
+		{Name: "logging"},
+		{Name: "go-style", Requires: []string{"rest-api", "unknown"}},
+		{Name: "sql"},
+	}
+
+	t.Run("adds dependencies transitively", func(t *testing.T) {
+		names, dependencies := AddRequiredGuidelines(available, []string{"sql", "rest-api"})
+		assert.Equal(t, []string{"sql", "rest-api", "error-handling", "logging", "go-style"}, names)
+		assert.Equal(t, []Dependency{
+			{Name: "error-handling", RequiredBy: "rest-api"},
+			{Name: "logging", RequiredBy: "rest-api"},
+			{Name: "go-style", RequiredBy: "error-handling"},
+		}, dependencies)
+	})
+
+	t.Run("already selected dependencies are not reported", func(t *testing.T) {
+		names, dependencies := AddRequiredGuidelines(available, []string{"error-handling", "logging", "go-style"})
+		assert.Equal(t, []string{"error-handling", "logging", "go-style", "rest-api"}, names)
+		assert.Equal(t, []Dependency{{Name: "rest-api", RequiredBy: "go-style"}}, dependencies)
+	})
+
+	t.Run("adds guidelines the source requires", func(t *testing.T) {
+		withRequired := append([]ManifestGuideline{{Name: "security", Required: true, Requires: []string{"logging"}}}, available...)
+		names, dependencies := AddRequiredGuidelines(withRequired, []string{"sql"})
+		assert.Equal(t, []string{"sql", "security", "logging"}, names)
+		assert.Equal(t, []Dependency{
+			{Name: "security"},
+			{Name: "logging", RequiredBy: "security"},
+		}, dependencies)
+		assert.Equal(t, []string{"security"}, RequiredGuidelineNames(withRequired))
+	})
+
+	t.Run("adds included guidelines", func(t *testing.T) {
+		withIncludes := append([]ManifestGuideline{{Name: "review", Includes: []string{"sql", "unknown"}}}, available...)
+		names, dependencies := AddRequiredGuidelines(withIncludes, []string{"review"})
+		assert.Equal(t, []string{"review", "sql"}, names)
+		assert.Equal(t, []Dependency{{Name: "sql", RequiredBy: "review", Included: true}}, dependencies)
+	})
+
+	t.Run("no dependencies", func(t *testing.T) {
+		names, dependencies := AddRequiredGuidelines(available, []string{"sql"})
+		assert.Equal(t, []string{"sql"}, names)
+		assert.Empty(t, dependencies)
+	})
+}
@@ -0,0 +1,47 @@
+package config
+
+import "slices"
+
+// Untagged names the group of guidelines without tags
+const Untagged = "untagged"
+
+// TagGroup is a set of guidelines grouped under their first tag
+type TagGroup struct {
+	Tag        string
+	Guidelines []ManifestGuideline
+}
+
+// GroupByTag groups guidelines by their first tag, in order of first appearance, with untagged guidelines last
+// Guidelines keep their order within a group
+func GroupByTag(guidelines []ManifestGuideline) []TagGroup {
+	var groups []TagGroup
+	var untagged []ManifestGuideline
+	for _, g := range guidelines {
+		if len(g.Tags) == 0 {
+			untagged = append(untagged, g)
+			continue
+		}
+		idx := slices.IndexFunc(groups, func(group TagGroup) bool { return group.Tag == g.Tags[0] })
+		if idx < 0 {
+			groups = append(groups, TagGroup{Tag: g.Tags[0]})
+			idx = len(groups) - 1
+		}
+		groups[idx].Guidelines = append(groups[idx].Guidelines, g)
+	}
+
+	if len(untagged) > 0 {
+		groups = append(groups, TagGroup{Tag: Untagged, Guidelines: untagged})
+	}
+	return groups
+}
+
+// FilterByTag returns the guidelines that have the tag
+func FilterByTag(guidelines []ManifestGuideline, tag string) []ManifestGuideline {
+	var result []ManifestGuideline
+	for _, g := range guidelines {
+		if slices.Contains(g.Tags, tag) {
+			result = append(result, g)
+		}
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByTag(t *testing.T) {
+	guidelines := []ManifestGuideline{
+		{Name: "go-style", Tags: []string{"go", "style"}},
+		{Name: "readme"},
+		{Name: "sql", Tags: []string{"data"}},
+		{Name: "go-testing", Tags: []string{"go"}},
+	}
+
+	groups := GroupByTag(guidelines)
+	var tags []string
+	var names [][]string
+	for _, group := range groups {
+		tags = append(tags, group.Tag)
+		var groupNames []string
+		for _, g := range group.Guidelines {
+			groupNames = append(groupNames, g.Name)
+		}
+		names = append(names, groupNames)
+	}
+	assert.Equal(t, []string{"go", "data", Untagged}, tags)
+	assert.Equal(t, [][]string{{"go-style", "go-testing"}, {"sql"}, {"readme"}}, names)
+
+	assert.Empty(t, GroupByTag(nil))
+}
+
+func TestFilterByTag(t *testing.T) {
+	guidelines := []ManifestGuideline{
+		{Name: "go-style", Tags: []string{"go", "style"}},
+		{Name: "sql", Tags: []string{"data"}},
+		{Name: "markdown-style", Tags: []string{"docs", "style"}},
+	}
+
+	filtered := FilterByTag(guidelines, "style")
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "go-style", filtered[0].Name)
+	assert.Equal(t, "markdown-style", filtered[1].Name)
+	assert.Empty(t, FilterByTag(guidelines, "security"))
+}
@@ -10,6 +10,13 @@ const ExampleManifestYAML = `# DNASpec Manifest
 
 version: 1
 
+# Template variables that guidelines and prompts marked with template: true can reference as {{.name}}
+# Projects set the values under vars in dnaspec.yaml
+# variables:
+#   - name: test_command
+#     description: Command that runs the test suite
+#     default: go test ./...
+
 guidelines:
   # Example guideline entry
   - name: go-style
@@ -30,6 +37,12 @@ guidelines:
   #   applicable_scenarios:
   #     - "Designing REST APIs"
   #     - "Implementing API endpoints"
+  #   requires:            # guidelines added whenever this one is selected
+  #     - go-style
+  #   required: true       # installed in every project using this DNA
+  #   assets:              # supporting files copied with the guideline
+  #     - guidelines/rest-api/examples/**
+  #   template: true       # rendered with variables and {{include "..."}}
 
 prompts:
   # Example prompt entry
@@ -45,6 +58,24 @@ prompts:
   # - name: debugging
   #   file: prompts/debugging.md
   #   description: Prompt for systematic debugging
+  #   arguments:           # referenced in the prompt as {{arg "symptom"}}
+  #     - name: symptom
+  #       description: What goes wrong
+  #       required: true
+
+# Bundles group guidelines that projects add together (dnaspec add --bundle backend-go)
+# bundles:
+#   - name: backend-go
+#     description: Guidelines for Go backend services
+#     guidelines:
+#       - go-style
+
+# Content checks of dnaspec manifest validate, each reported as error, warning or off
+# lint:
+#   max_file_size_kb: 50
+#   rules:
+#     missing-heading: error
+#     oversized-file: off
 `
 
 // CreateExampleManifest creates an example manifest file at the given path
@@ -63,6 +94,28 @@ version: 1
 #   - "claude-code"
 #   - "github-copilot"
 
+# AGENTS.md rendering (also used for CLAUDE.md, GEMINI.md and copilot-instructions.md)
+# agents_md:
+#   mode: inline            # embed guideline content instead of @/dnaspec/... references
+#   inline_budget: 32768    # max bytes to inline; guidelines beyond it stay as references
+#   guidelines:
+#     company-dna/go-style: pointer   # per-guideline override
+
+# Approximate token thresholds for 'dnaspec validate' warnings (see 'dnaspec stats')
+# budgets:
+#   guideline_tokens: 4000
+#   prompt_tokens: 2000
+#   agent_file_tokens: 8000
+
+# Values for template variables declared by sources, used as {{.module_path}} in guidelines and prompts
+# vars:
+#   module_path: github.com/company/service
+#   test_command: make test
+
+# Claude Code specific settings
+# claude_code:
+#   skills: true   # package guidelines as .claude/skills/ directories
+
 # DNA sources - guidelines from repositories or local directories
 # sources:
 #   - name: company-dna
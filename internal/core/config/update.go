@@ -35,7 +35,7 @@ func ExtractReferencedPrompts(selectedGuidelines []ManifestGuideline, allPrompts
 	var result []ProjectPrompt
 	for _, p := range allPrompts {
 		if referenced[p.Name] {
-			result = append(result, ProjectPrompt(p))
+			result = append(result, ManifestPromptToProject(p))
 		}
 	}
 
@@ -46,11 +46,73 @@ func ExtractReferencedPrompts(selectedGuidelines []ManifestGuideline, allPrompts
 func ManifestGuidelinesToProject(guidelines []ManifestGuideline) []ProjectGuideline {
 	result := make([]ProjectGuideline, len(guidelines))
 	for i, g := range guidelines {
-		result[i] = ProjectGuideline(g)
+		result[i] = ManifestGuidelineToProject(g)
 	}
 	return result
 }
 
+// ManifestGuidelineToProject copies the fields a project uses from a manifest guideline
+// Manifest-only fields, such as aliases and required, aren't stored in dnaspec.yaml
+func ManifestGuidelineToProject(g ManifestGuideline) ProjectGuideline {
+	return ProjectGuideline{
+		Name:                g.Name,
+		File:                g.File,
+		Description:         g.Description,
+		ApplicableScenarios: g.ApplicableScenarios,
+		Prompts:             g.Prompts,
+		Requires:            g.Requires,
+		Assets:              g.Assets,
+		Deprecated:          g.Deprecated,
+		Tags:                g.Tags,
+		Owners:              g.Owners,
+		Version:             g.Version,
+		LastReviewed:        g.LastReviewed,
+		FilePatterns:        g.FilePatterns,
+		ManualOnly:          g.ManualOnly,
+		Template:            g.Template,
+		Agents:              g.Agents,
+	}
+}
+
+// ProjectGuidelinesToManifest converts project guidelines back to manifest guidelines
+// Fields that only manifests have are left empty
+func ProjectGuidelinesToManifest(guidelines []ProjectGuideline) []ManifestGuideline {
+	result := make([]ManifestGuideline, len(guidelines))
+	for i, g := range guidelines {
+		result[i] = ManifestGuideline{
+			Name:                g.Name,
+			File:                g.File,
+			Description:         g.Description,
+			ApplicableScenarios: g.ApplicableScenarios,
+			Prompts:             g.Prompts,
+			Requires:            g.Requires,
+			Assets:              g.Assets,
+			Deprecated:          g.Deprecated,
+			Tags:                g.Tags,
+			Owners:              g.Owners,
+			Version:             g.Version,
+			LastReviewed:        g.LastReviewed,
+			FilePatterns:        g.FilePatterns,
+			ManualOnly:          g.ManualOnly,
+			Template:            g.Template,
+			Agents:              g.Agents,
+		}
+	}
+	return result
+}
+
+// ManifestPromptToProject copies the fields a project uses from a manifest prompt
+func ManifestPromptToProject(p ManifestPrompt) ProjectPrompt {
+	return ProjectPrompt{
+		Name:        p.Name,
+		File:        p.File,
+		Description: p.Description,
+		Arguments:   p.Arguments,
+		Template:    p.Template,
+		Agents:      p.Agents,
+	}
+}
+
 // UpdateAgents updates the agents configuration in-memory
 // Caller is responsible for saving the config
 func UpdateAgents(cfg *ProjectConfig, agents []string) {
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestAddSource(t *testing.T) {
@@ -181,6 +184,37 @@ func TestManifestGuidelinesToProject(t *testing.T) {
 		}
 	})
 
+	t.Run("copies only the fields projects use", func(t *testing.T) {
+		result := ManifestGuidelinesToProject([]ManifestGuideline{
+			{
+				Name:         "test-guideline",
+				File:         "guidelines/test.md",
+				Required:     true,
+				Aliases:      []string{"old-name"},
+				FilePatterns: []string{"**/*.go"},
+				ManualOnly:   true,
+				Template:     true,
+			},
+		})
+
+		g := result[0]
+		if len(g.FilePatterns) != 1 || g.FilePatterns[0] != "**/*.go" {
+			t.Errorf("FilePatterns = %v, want [**/*.go]", g.FilePatterns)
+		}
+		if !g.ManualOnly || !g.Template {
+			t.Errorf("ManualOnly = %v, Template = %v, want true", g.ManualOnly, g.Template)
+		}
+
+		// Required guidelines are tracked on the source, and aliases only matter to update
+		data, err := yaml.Marshal(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "required") || strings.Contains(string(data), "aliases") {
+			t.Errorf("project guideline stores manifest-only fields:\n%s", data)
+		}
+	})
+
 	t.Run("empty list", func(t *testing.T) {
 		result := ManifestGuidelinesToProject([]ManifestGuideline{})
 
@@ -0,0 +1,130 @@
+package files
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/paths"
+)
+
+// ExpandAssets resolves the asset patterns of guidelines against the files below sourceDir
+// Returns the matched files as slash-separated paths relative to sourceDir, sorted and without duplicates
+// Symlinks are skipped, since they may point outside the DNA repository
+func ExpandAssets(sourceDir string, guidelines []config.ManifestGuideline) ([]string, error) {
+	var patterns []string
+	for _, g := range guidelines {
+		patterns = append(patterns, g.Assets...)
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	var assets []string
+	err := walkAssetCandidates(sourceDir, func(rel string) error {
+		if slices.ContainsFunc(patterns, func(pattern string) bool { return paths.MatchGlob(pattern, rel) }) {
+			assets = append(assets, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assets in %s: %w", sourceDir, err)
+	}
+
+	return assets, nil
+}
+
+// MatchedAssetPatterns reports which of the asset patterns match at least one file below sourceDir
+// The tree is walked once for all patterns, stopping as soon as every pattern has matched
+func MatchedAssetPatterns(sourceDir string, patterns []string) (map[string]bool, error) {
+	matched := make(map[string]bool)
+	patterns = slices.Compact(slices.Sorted(slices.Values(patterns)))
+	if len(patterns) == 0 {
+		return matched, nil
+	}
+
+	err := walkAssetCandidates(sourceDir, func(rel string) error {
+		for _, pattern := range patterns {
+			if !matched[pattern] && paths.MatchGlob(pattern, rel) {
+				matched[pattern] = true
+			}
+		}
+		if len(matched) == len(patterns) {
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assets in %s: %w", sourceDir, err)
+	}
+
+	return matched, nil
+}
+
+// walkAssetCandidates calls visit with the slash-separated path, relative to sourceDir, of every file that may be an asset
+// The .git directory and symlinks are skipped
+func walkAssetCandidates(sourceDir string, visit func(rel string) error) error {
+	return filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		return visit(filepath.ToSlash(rel))
+	})
+}
+
+// CopyAssets copies asset files, given relative to sourceDir as returned by ExpandAssets, to destination
+func CopyAssets(sourceDir, destDir string, assets []string) error {
+	for _, asset := range assets {
+		src := filepath.Join(sourceDir, filepath.FromSlash(asset))
+		dst := filepath.Join(destDir, filepath.FromSlash(asset))
+		if err := copyFile(src, dst); err != nil {
+			return fmt.Errorf("failed to copy asset %s: %w", asset, err)
+		}
+	}
+	return nil
+}
+
+// PruneAssets removes previously copied asset files that are no longer current, along with directories left empty
+// Run it before copying guideline and prompt files, which may match a stale asset
+func PruneAssets(destDir string, previous, current []string) error {
+	for _, asset := range previous {
+		if slices.Contains(current, asset) {
+			continue
+		}
+		path := filepath.Join(destDir, filepath.FromSlash(asset))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove asset %s: %w", asset, err)
+		}
+		removeEmptyDirs(filepath.Dir(path), destDir)
+	}
+	return nil
+}
+
+// removeEmptyDirs removes dir and its parents up to, but not including, root while they are empty
+func removeEmptyDirs(dir, root string) {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		// Remove fails on non-empty directories, which ends the walk
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
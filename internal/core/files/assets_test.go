@@ -0,0 +1,131 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func writeFiles(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(file), 0644))
+	}
+}
+
+func TestExpandAssets(t *testing.T) {
+	sourceDir := t.TempDir()
+	writeFiles(t, sourceDir,
+		"guidelines/go-style.md",
+		"guidelines/go-style/examples/main.go",
+		"guidelines/go-style/examples/nested/handler.go",
+		"guidelines/go-style/diagram.png",
+		"guidelines/rest-api/openapi.yaml",
+		".git/config",
+	)
+
+	guidelines := []config.ManifestGuideline{
+		{Name: "go-style", Assets: []string{"guidelines/go-style/**/*.go", "guidelines/go-style/*.png"}},
+		{Name: "rest-api", Assets: []string{"guidelines/rest-api/*", "guidelines/go-style/examples/main.go"}},
+		{Name: "no-assets"},
+	}
+
+	assets, err := ExpandAssets(sourceDir, guidelines)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"guidelines/go-style/diagram.png",
+		"guidelines/go-style/examples/main.go",
+		"guidelines/go-style/examples/nested/handler.go",
+		"guidelines/rest-api/openapi.yaml",
+	}, assets)
+
+	assets, err = ExpandAssets(sourceDir, guidelines[2:])
+	require.NoError(t, err)
+	assert.Empty(t, assets)
+}
+
+func TestExpandAssets_SkipsSymlinks(t *testing.T) {
+	sourceDir := t.TempDir()
+	outside := t.TempDir()
+	writeFiles(t, sourceDir, "guidelines/go-style/main.go")
+	writeFiles(t, outside, "secret.txt")
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(sourceDir, "guidelines", "go-style", "secret.txt")))
+	require.NoError(t, os.Symlink(outside, filepath.Join(sourceDir, "guidelines", "go-style", "linked")))
+
+	assets, err := ExpandAssets(sourceDir, []config.ManifestGuideline{{Name: "go-style", Assets: []string{"guidelines/go-style/**"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"guidelines/go-style/main.go"}, assets)
+}
+
+func TestMatchedAssetPatterns(t *testing.T) {
+	sourceDir := t.TempDir()
+	writeFiles(t, sourceDir,
+		"guidelines/go-style/examples/main.go",
+		"guidelines/go-style/diagram.png",
+		".git/config",
+	)
+
+	matched, err := MatchedAssetPatterns(sourceDir, []string{
+		"guidelines/go-style/**/*.go",
+		"guidelines/go-style/*.png",
+		"guidelines/go-style/*.png",
+		"guidelines/go-style/*.svg",
+		".git/*",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"guidelines/go-style/**/*.go": true,
+		"guidelines/go-style/*.png":   true,
+	}, matched)
+
+	matched, err = MatchedAssetPatterns(sourceDir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, matched)
+}
+
+func TestCopyAssets(t *testing.T) {
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	writeFiles(t, sourceDir, "guidelines/go-style/examples/main.go")
+
+	err := CopyAssets(sourceDir, destDir, []string{"guidelines/go-style/examples/main.go"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(destDir, "guidelines", "go-style", "examples", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "guidelines/go-style/examples/main.go", string(content))
+
+	err = CopyAssets(sourceDir, destDir, []string{"guidelines/missing.go"})
+	assert.ErrorContains(t, err, "failed to copy asset guidelines/missing.go")
+}
+
+func TestPruneAssets(t *testing.T) {
+	destDir := t.TempDir()
+	writeFiles(t, destDir,
+		"guidelines/go-style.md",
+		"guidelines/go-style/examples/nested/handler.go",
+		"guidelines/go-style/examples/main.go",
+		"guidelines/go-style/diagram.png",
+	)
+
+	previous := []string{
+		"guidelines/go-style/examples/nested/handler.go",
+		"guidelines/go-style/examples/main.go",
+		"guidelines/go-style/diagram.png",
+		"guidelines/go-style/already-removed.png",
+	}
+	current := []string{"guidelines/go-style/diagram.png"}
+
+	require.NoError(t, PruneAssets(destDir, previous, current))
+
+	assert.NoDirExists(t, filepath.Join(destDir, "guidelines", "go-style", "examples"))
+	assert.FileExists(t, filepath.Join(destDir, "guidelines", "go-style", "diagram.png"))
+	assert.FileExists(t, filepath.Join(destDir, "guidelines", "go-style.md"))
+}
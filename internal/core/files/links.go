@@ -0,0 +1,81 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/links"
+)
+
+// MissingLink is a relative link in a copied file to a source file that wasn't copied
+type MissingLink struct {
+	File     string // Copied file containing the link, relative to the source root
+	Line     int    // 1-based line number of the link
+	Target   string // Link target as written
+	Upstream string // URL the link was rewritten to, empty when the link was kept
+}
+
+// CheckLinks finds relative links in copied markdown documents that point at source files outside installed
+// Links leaving the source or pointing at files the source doesn't have are skipped,
+// 'dnaspec manifest validate' reports them
+// When upstream returns a URL for a source path, the copied document is rewritten to link there instead
+func CheckLinks(
+	sourceDir, destDir string,
+	documents, installed []string,
+	upstream func(path string) (string, bool),
+) ([]MissingLink, error) {
+	var missing []MissingLink
+
+	for _, document := range documents {
+		dst := filepath.Join(destDir, filepath.FromSlash(document))
+		content, err := os.ReadFile(dst)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", document, err)
+		}
+
+		var found []MissingLink
+		rewritten := links.Rewrite(string(content), links.Parse(string(content)), func(link links.Link) (string, bool) {
+			target, ok := links.Resolve(document, link.Path)
+			if !ok || isInstalled(target, installed) {
+				return "", false
+			}
+			if _, err := os.Stat(filepath.Join(sourceDir, filepath.FromSlash(target))); err != nil {
+				return "", false
+			}
+
+			missingLink := MissingLink{File: document, Line: link.Line, Target: link.Target}
+			if upstream != nil {
+				if url, ok := upstream(target); ok {
+					if _, fragment, hasFragment := strings.Cut(link.Target, "#"); hasFragment {
+						url += "#" + fragment
+					}
+					missingLink.Upstream = url
+				}
+			}
+			found = append(found, missingLink)
+			return missingLink.Upstream, missingLink.Upstream != ""
+		})
+
+		if rewritten != string(content) {
+			if err := os.WriteFile(dst, []byte(rewritten), 0o644); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", document, err)
+			}
+		}
+		missing = append(missing, found...)
+	}
+
+	return missing, nil
+}
+
+// isInstalled reports whether a source path is an installed file or a directory containing one
+func isInstalled(target string, installed []string) bool {
+	if target == "." {
+		return true
+	}
+	return slices.ContainsFunc(installed, func(file string) bool {
+		return file == target || strings.HasPrefix(file, target+"/")
+	})
+}
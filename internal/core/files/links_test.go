@@ -0,0 +1,64 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLinks(t *testing.T) {
+	guideline := "# Go\n" +
+		"Use [review](../prompts/review.md) and [lint](../prompts/lint.md#rules).\n" +
+		"See [examples](examples/) and [gone](../missing.md).\n" +
+		"Also [rust](rust.md).\n"
+
+	setup := func(t *testing.T) (string, string) {
+		t.Helper()
+		sourceDir := t.TempDir()
+		destDir := t.TempDir()
+		writeFiles(t, sourceDir, "prompts/review.md", "prompts/lint.md", "guidelines/examples/a.go", "guidelines/rust.md")
+		for _, dir := range []string{sourceDir, destDir} {
+			writeFiles(t, dir, "guidelines/go.md")
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "guidelines", "go.md"), []byte(guideline), 0644))
+		}
+		return sourceDir, destDir
+	}
+	installed := []string{"guidelines/go.md", "prompts/review.md", "guidelines/examples/a.go"}
+
+	t.Run("reports links to files that aren't installed", func(t *testing.T) {
+		sourceDir, destDir := setup(t)
+
+		missing, err := CheckLinks(sourceDir, destDir, []string{"guidelines/go.md"}, installed, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []MissingLink{
+			{File: "guidelines/go.md", Line: 2, Target: "../prompts/lint.md#rules"},
+			{File: "guidelines/go.md", Line: 4, Target: "rust.md"},
+		}, missing)
+
+		content, err := os.ReadFile(filepath.Join(destDir, "guidelines", "go.md"))
+		require.NoError(t, err)
+		assert.Equal(t, guideline, string(content))
+	})
+
+	t.Run("rewrites links to upstream", func(t *testing.T) {
+		sourceDir, destDir := setup(t)
+		upstream := func(path string) (string, bool) {
+			return "https://example.com/" + path, path != "guidelines/rust.md"
+		}
+
+		missing, err := CheckLinks(sourceDir, destDir, []string{"guidelines/go.md"}, installed, upstream)
+		require.NoError(t, err)
+		require.Len(t, missing, 2)
+		assert.Equal(t, "https://example.com/prompts/lint.md#rules", missing[0].Upstream)
+		assert.Empty(t, missing[1].Upstream)
+
+		content, err := os.ReadFile(filepath.Join(destDir, "guidelines", "go.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "[lint](https://example.com/prompts/lint.md#rules)")
+		assert.Contains(t, string(content), "[review](../prompts/review.md)")
+		assert.Contains(t, string(content), "[rust](rust.md)")
+	})
+}
@@ -0,0 +1,64 @@
+// Package frontmatter reads the YAML frontmatter of markdown files.
+package frontmatter
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// delimiter opens and closes a frontmatter block
+const delimiter = "---"
+
+// Split separates the YAML frontmatter delimited by --- lines at the start of markdown content from the body
+// Returns false when content has no frontmatter, in which case body is the whole content
+func Split(content string) (frontmatter, body string, ok bool) {
+	rest, found := strings.CutPrefix(content, delimiter+"\n")
+	if !found {
+		rest, found = strings.CutPrefix(content, delimiter+"\r\n")
+	}
+	if !found {
+		return "", content, false
+	}
+
+	offset := 0
+	for _, line := range strings.SplitAfter(rest, "\n") {
+		if strings.TrimRight(line, "\r\n") == delimiter {
+			return rest[:offset], rest[offset+len(line):], true
+		}
+		offset += len(line)
+	}
+	return "", content, false
+}
+
+// Parse returns the frontmatter of markdown content as a YAML mapping node
+// Returns nil without an error when content has no frontmatter; empty frontmatter yields an empty mapping
+func Parse(content string) (*yaml.Node, error) {
+	frontmatter, _, ok := Split(content)
+	if !ok {
+		return nil, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &doc); err != nil {
+		return nil, fmt.Errorf("invalid frontmatter: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid frontmatter: expected key-value pairs")
+	}
+	// Frontmatter starts on the line after the opening delimiter
+	shiftLines(doc.Content[0], 1)
+	return doc.Content[0], nil
+}
+
+// shiftLines offsets the line numbers of a node tree
+func shiftLines(node *yaml.Node, offset int) {
+	node.Line += offset
+	for _, child := range node.Content {
+		shiftLines(child, offset)
+	}
+}
@@ -0,0 +1,58 @@
+package frontmatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name            string
+		content         string
+		wantFrontmatter string
+		wantBody        string
+		wantOK          bool
+	}{
+		{"frontmatter", "---\nname: go-style\n---\n# Go\n", "name: go-style\n", "# Go\n", true},
+		{"windows line endings", "---\r\nname: go-style\r\n---\r\n# Go\r\n", "name: go-style\r\n", "# Go\r\n", true},
+		{"empty frontmatter", "---\n---\n# Go\n", "", "# Go\n", true},
+		{"no frontmatter", "# Go\n---\n", "", "# Go\n---\n", false},
+		{"unclosed frontmatter", "---\nname: go-style\n# Go\n", "", "---\nname: go-style\n# Go\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter, body, ok := Split(tt.content)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantFrontmatter, frontmatter)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	node, err := Parse("---\nname: go-style\ntags: [go]\n---\n# Go\n")
+	require.NoError(t, err)
+	require.Equal(t, yaml.MappingNode, node.Kind)
+	require.Len(t, node.Content, 4)
+	assert.Equal(t, "name", node.Content[0].Value)
+	assert.Equal(t, 2, node.Content[0].Line, "lines count from the start of the file")
+
+	node, err = Parse("# Go\n")
+	require.NoError(t, err)
+	assert.Nil(t, node)
+
+	node, err = Parse("---\n---\n")
+	require.NoError(t, err)
+	assert.Equal(t, yaml.MappingNode, node.Kind)
+	assert.Empty(t, node.Content)
+
+	_, err = Parse("---\n- a\n- b\n---\n")
+	assert.ErrorContains(t, err, "expected key-value pairs")
+
+	_, err = Parse("---\nname: [\n---\n")
+	assert.ErrorContains(t, err, "invalid frontmatter")
+}
@@ -0,0 +1,109 @@
+// Package links finds relative links in guideline and prompt markdown, checks what they point at
+// and rewrites them to upstream repository URLs.
+package links
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/markdown"
+)
+
+var (
+	// inlineLinkRegex matches [text](target "title") and ![alt](target), capturing the target
+	inlineLinkRegex = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+[^)]*)?\)`)
+	// referenceLinkRegex matches reference definitions such as [id]: target "title", capturing the target
+	referenceLinkRegex = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+	// schemeRegex matches targets with a URL scheme such as https: or mailto:
+	schemeRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+	// codeSpanRegex matches inline code, whose content is not markdown
+	codeSpanRegex = regexp.MustCompile("`+[^`]*`+")
+)
+
+// Link is a relative link in a markdown file
+type Link struct {
+	Line   int    // 1-based line number
+	Target string // Target as written, e.g. ../prompts/review.md#usage
+	Path   string // Target without fragment and query, unescaped, e.g. ../prompts/review.md
+
+	start, end int // Byte offsets of Target in the content
+}
+
+// Parse returns the relative links of markdown content
+// Links in code blocks and code spans, absolute URLs, fragments and template expressions are skipped
+func Parse(content string) []Link {
+	var links []Link
+	markdown.Scan(content, func(lineNum, lineStart int, line string) {
+		// Blank out code spans so links inside them aren't matched, keeping offsets intact
+		masked := codeSpanRegex.ReplaceAllStringFunc(line, func(span string) string {
+			return strings.Repeat(" ", len(span))
+		})
+
+		var matches [][]int
+		matches = append(matches, inlineLinkRegex.FindAllStringSubmatchIndex(masked, -1)...)
+		matches = append(matches, referenceLinkRegex.FindAllStringSubmatchIndex(masked, -1)...)
+		sort.Slice(matches, func(a, b int) bool { return matches[a][0] < matches[b][0] })
+		for _, match := range matches {
+			target := line[match[2]:match[3]]
+			linkPath, ok := relativePath(target)
+			if !ok {
+				continue
+			}
+			links = append(links, Link{
+				Line:   lineNum,
+				Target: target,
+				Path:   linkPath,
+				start:  lineStart + match[2],
+				end:    lineStart + match[3],
+			})
+		}
+	})
+
+	return links
+}
+
+// relativePath returns the path of a relative link target, or false for targets that aren't relative file links
+func relativePath(target string) (string, bool) {
+	if target == "" || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") ||
+		schemeRegex.MatchString(target) || strings.Contains(target, "{{") {
+		return "", false
+	}
+
+	linkPath, _, _ := strings.Cut(target, "#")
+	linkPath, _, _ = strings.Cut(linkPath, "?")
+	if unescaped, err := url.PathUnescape(linkPath); err == nil {
+		linkPath = unescaped
+	}
+	return linkPath, linkPath != ""
+}
+
+// Resolve returns the repository path a link in file points at, both slash-separated and relative to the
+// repository root. It returns false when the link leaves the repository.
+func Resolve(file, linkPath string) (string, bool) {
+	resolved := path.Join(path.Dir(file), linkPath)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return "", false
+	}
+	return resolved, true
+}
+
+// Rewrite replaces the targets of links in content
+// replace returns the new target of a link, or false to keep it
+func Rewrite(content string, links []Link, replace func(Link) (string, bool)) string {
+	var sb strings.Builder
+	last := 0
+	for _, link := range links {
+		target, ok := replace(link)
+		if !ok {
+			continue
+		}
+		sb.WriteString(content[last:link.start])
+		sb.WriteString(target)
+		last = link.end
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
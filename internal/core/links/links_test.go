@@ -0,0 +1,95 @@
+package links
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	content := "# Review\n" +
+		"See [the prompt](../prompts/review.md#usage) and ![diagram](img/flow%20chart.png \"Flow\").\n" +
+		"Skip [site](https://example.com), [anchor](#usage), [root](/abs.md) and [var]({{.docs_url}}).\n" +
+		"Inline `[code](code.md)` isn't a link.\n" +
+		"```md\n" +
+		"[fenced](fenced.md)\n" +
+		"```\n" +
+		"[ref]: ./reference.md?plain=1 \"Reference\"\n"
+
+	parsed := Parse(content)
+	require.Len(t, parsed, 3)
+
+	assert.Equal(t, 2, parsed[0].Line)
+	assert.Equal(t, "../prompts/review.md#usage", parsed[0].Target)
+	assert.Equal(t, "../prompts/review.md", parsed[0].Path)
+
+	assert.Equal(t, "img/flow%20chart.png", parsed[1].Target)
+	assert.Equal(t, "img/flow chart.png", parsed[1].Path)
+
+	assert.Equal(t, 8, parsed[2].Line)
+	assert.Equal(t, "./reference.md", parsed[2].Path)
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		file     string
+		linkPath string
+		want     string
+		wantOK   bool
+	}{
+		{"guidelines/go.md", "../prompts/review.md", "prompts/review.md", true},
+		{"guidelines/go.md", "./img/a.png", "guidelines/img/a.png", true},
+		{"guidelines/go.md", "..", ".", true},
+		{"guidelines/go.md", "../../outside.md", "", false},
+		{"README.md", "../outside.md", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.linkPath, func(t *testing.T) {
+			got, ok := Resolve(tt.file, tt.linkPath)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	content := "[a](a.md) and [b](b.md#x)\n[c]: c.md\n"
+	parsed := Parse(content)
+	require.Len(t, parsed, 3)
+
+	rewritten := Rewrite(content, parsed, func(link Link) (string, bool) {
+		if link.Path == "a.md" {
+			return "", false
+		}
+		return "https://example.com/" + link.Target, true
+	})
+	assert.Equal(t, "[a](a.md) and [b](https://example.com/b.md#x)\n[c]: https://example.com/c.md\n", rewritten)
+}
+
+func TestUpstreamURL(t *testing.T) {
+	tests := []struct {
+		repoURL string
+		want    string
+		wantOK  bool
+	}{
+		{"https://github.com/acme/dna.git", "https://github.com/acme/dna/blob/abc123/prompts/review.md", true},
+		{"git@github.com:acme/dna.git", "https://github.com/acme/dna/blob/abc123/prompts/review.md", true},
+		{"ssh://git@gitlab.com/acme/dna", "https://gitlab.com/acme/dna/-/blob/abc123/prompts/review.md", true},
+		{"https://bitbucket.org/acme/dna.git", "https://bitbucket.org/acme/dna/src/abc123/prompts/review.md", true},
+		{"https://git.example.com/acme/dna.git", "", false},
+		{"not a url", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoURL, func(t *testing.T) {
+			got, ok := UpstreamURL(tt.repoURL, "abc123", "prompts/review.md")
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := UpstreamURL("https://github.com/acme/dna.git", "", "prompts/review.md")
+	assert.False(t, ok, "no URL without a pinned commit")
+}
@@ -0,0 +1,45 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// UpstreamURL returns the web URL of a file in a git repository at a commit
+// Supports GitHub, GitLab and Bitbucket remotes over HTTPS or SSH; returns false for other hosts
+func UpstreamURL(repoURL, commit, file string) (string, bool) {
+	host, repoPath, ok := splitRemote(repoURL)
+	if !ok || commit == "" {
+		return "", false
+	}
+
+	base := fmt.Sprintf("https://%s/%s", host, repoPath)
+	switch {
+	case host == "github.com":
+		return fmt.Sprintf("%s/blob/%s/%s", base, commit, file), true
+	case strings.Contains(host, "gitlab"):
+		return fmt.Sprintf("%s/-/blob/%s/%s", base, commit, file), true
+	case host == "bitbucket.org":
+		return fmt.Sprintf("%s/src/%s/%s", base, commit, file), true
+	default:
+		return "", false
+	}
+}
+
+// splitRemote splits a git remote URL (https://host/org/repo.git, git@host:org/repo.git or
+// ssh://git@host/org/repo.git) into its host and repository path
+func splitRemote(repoURL string) (host, repoPath string, ok bool) {
+	if rest, found := strings.CutPrefix(repoURL, "git@"); found {
+		host, repoPath, ok = strings.Cut(rest, ":")
+	} else {
+		parsed, err := url.Parse(repoURL)
+		if err != nil || parsed.Host == "" {
+			return "", "", false
+		}
+		host, repoPath, ok = parsed.Hostname(), parsed.Path, true
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	return host, repoPath, ok && host != "" && repoPath != ""
+}
@@ -0,0 +1,123 @@
+// Package markdown finds the structure of markdown content: headings and fenced code blocks.
+package markdown
+
+import "strings"
+
+// maxHeadingLevel is the deepest ATX heading level in markdown
+const maxHeadingLevel = 6
+
+// Heading is an ATX heading
+type Heading struct {
+	Line   int // 1-based line number
+	Offset int // Byte offset of the heading line in the content
+	Level  int
+	Text   string
+}
+
+// Headings returns the ATX headings of markdown content, skipping fenced and indented code blocks
+func Headings(content string) []Heading {
+	var headings []Heading
+	Scan(content, func(line, offset int, text string) {
+		if h, ok := parseHeading(text); ok {
+			h.Line, h.Offset = line, offset
+			headings = append(headings, h)
+		}
+	})
+	return headings
+}
+
+// UnclosedFence returns the line of a fenced code block that is never closed, or 0 if all fences are closed
+func UnclosedFence(content string) int {
+	return Scan(content, func(int, int, string) {})
+}
+
+// ShiftHeadings moves ATX headings up (negative levels) or down, keeping them between levels 1 and 6
+// Lines inside fenced code blocks are left untouched
+func ShiftHeadings(content string, levels int) string {
+	var sb strings.Builder
+	last := 0
+	Scan(content, func(_, offset int, text string) {
+		h, ok := parseHeading(text)
+		if !ok {
+			return
+		}
+		trimmed := strings.TrimLeft(text, " ")
+		marker := offset + len(text) - len(trimmed)
+		sb.WriteString(content[last:marker])
+		sb.WriteString(strings.Repeat("#", min(max(h.Level+levels, 1), maxHeadingLevel)))
+		last = marker + h.Level
+	})
+	sb.WriteString(content[last:])
+	return sb.String()
+}
+
+// Scan calls visit for each line outside fenced code blocks, with its 1-based number, byte offset and text
+// Returns the line of the fence left open at the end of the content, or 0
+func Scan(content string, visit func(line, offset int, text string)) int {
+	fence, fenceLine := "", 0
+	offset := 0
+
+	for i, line := range strings.SplitAfter(content, "\n") {
+		lineStart := offset
+		offset += len(line)
+		text := strings.TrimRight(line, "\r\n")
+		trimmed := strings.TrimLeft(text, " ")
+		indent := len(text) - len(trimmed)
+
+		// Track fenced code blocks (``` or ~~~)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if marker := fenceMarker(trimmed); marker != "" && indent < 4 {
+			fence, fenceLine = marker, i+1
+			continue
+		}
+
+		visit(i+1, lineStart, text)
+	}
+
+	if fence != "" {
+		return fenceLine
+	}
+	return 0
+}
+
+// parseHeading parses an ATX heading line
+func parseHeading(line string) (Heading, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	// Headings indented by four or more spaces are code
+	if len(line)-len(trimmed) >= 4 {
+		return Heading{}, false
+	}
+
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level == 0 || level > maxHeadingLevel {
+		return Heading{}, false
+	}
+	rest := trimmed[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		// "#hashtag" is not a heading
+		return Heading{}, false
+	}
+
+	// Closing sequences ("## Title ##") aren't part of the text
+	text := strings.TrimSpace(rest)
+	if stripped := strings.TrimRight(text, "#"); stripped != text && (stripped == "" || strings.HasSuffix(stripped, " ")) {
+		text = strings.TrimSpace(stripped)
+	}
+	return Heading{Level: level, Text: text}, true
+}
+
+// fenceMarker returns the opening code fence of a line ("```", "~~~~", ...), or "" if the line doesn't open one
+func fenceMarker(line string) string {
+	for _, ch := range []string{"`", "~"} {
+		marker := line[:len(line)-len(strings.TrimLeft(line, ch))]
+		if len(marker) >= 3 {
+			return marker
+		}
+	}
+	return ""
+}
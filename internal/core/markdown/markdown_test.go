@@ -0,0 +1,46 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadings(t *testing.T) {
+	content := "# Title\n\nIntro\n\n## Testing ##\n\n```go\n# not a heading\n```\n\n    # indented code\n#hashtag\n### Table ###\n"
+
+	assert.Equal(t, []Heading{
+		{Line: 1, Offset: 0, Level: 1, Text: "Title"},
+		{Line: 5, Offset: 16, Level: 2, Text: "Testing"},
+		{Line: 13, Offset: 87, Level: 3, Text: "Table"},
+	}, Headings(content))
+}
+
+func TestUnclosedFence(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{name: "closed", content: "```go\ncode\n```\n", want: 0},
+		{name: "longer closing fence", content: "~~~\ncode\n~~~~\n", want: 0},
+		{name: "unclosed", content: "# Title\n\n```go\ncode\n", want: 3},
+		{name: "info string doesn't close", content: "```\ncode\n```go\n", want: 1},
+		{name: "different marker doesn't close", content: "```\ncode\n~~~\n", want: 1},
+		{name: "no fences", content: "# Title\n", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UnclosedFence(tt.content))
+		})
+	}
+}
+
+func TestShiftHeadings(t *testing.T) {
+	content := "## Testing\n\n### Table tests\n\n```sh\n## comment\n```\n#hashtag\n"
+
+	assert.Equal(t, "# Testing\n\n## Table tests\n\n```sh\n## comment\n```\n#hashtag\n", ShiftHeadings(content, -1))
+	assert.Equal(t, "###### Testing\n\n###### Table tests\n\n```sh\n## comment\n```\n#hashtag\n", ShiftHeadings(content, 5))
+	assert.Equal(t, "~~~\n# comment\n~~~\n    # indented code\n#### Title", ShiftHeadings("~~~\n# comment\n~~~\n    # indented code\n# Title", 3))
+}
@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/paths"
+)
+
+// minPrefixMatchLength is the shortest word matched by prefix; shorter words must match exactly
+const minPrefixMatchLength = 4
+
+// stopWords are common words ignored when matching queries
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "for": true, "how": true, "in": true, "is": true, "of": true,
+	"on": true, "or": true, "the": true, "to": true, "what": true, "when": true, "with": true,
+}
+
+// GuidelineMatch is a guideline found by FindGuidelines
+type GuidelineMatch struct {
+	SourceName string
+	Guideline  config.ProjectGuideline
+	URI        string
+	Reasons    []string // Human-readable explanation of why the guideline matched
+	score      int
+}
+
+// FindGuidelines returns the guidelines matching a task description and/or a file path
+// A guideline matches the query when query words appear in its applicable scenarios, description or name,
+// and matches the file path when one of its file patterns matches
+// Results are ordered by relevance, file path matches first
+func FindGuidelines(cfg *config.ProjectConfig, query, filePath string) []GuidelineMatch {
+	queryWords := splitWords(query)
+	filePath = strings.TrimPrefix(path.Clean(strings.ReplaceAll(strings.TrimSpace(filePath), "\\", "/")), "./")
+
+	var matches []GuidelineMatch
+	for _, source := range cfg.Sources {
+		for _, guideline := range source.Guidelines {
+			match := GuidelineMatch{
+				SourceName: source.Name,
+				Guideline:  guideline,
+				URI:        guidelineURI(source.Name, guideline.Name),
+			}
+			if filePath != "." {
+				matchFilePatterns(&match, filePath)
+			}
+			matchQuery(&match, queryWords)
+
+			if len(match.Reasons) > 0 {
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	return matches
+}
+
+// matchFilePatterns records a match when a file pattern of the guideline matches the file path
+func matchFilePatterns(match *GuidelineMatch, filePath string) {
+	for _, pattern := range match.Guideline.FilePatterns {
+		if paths.MatchGlob(pattern, filePath) {
+			// A file pattern match outranks any number of query word hits
+			match.score += 1000
+			match.Reasons = append(match.Reasons, fmt.Sprintf("file path matches %s", pattern))
+			return
+		}
+	}
+}
+
+// matchQuery records the scenarios and other fields of the guideline that contain query words
+func matchQuery(match *GuidelineMatch, queryWords []string) {
+	if len(queryWords) == 0 {
+		return
+	}
+
+	for _, scenario := range match.Guideline.ApplicableScenarios {
+		if hits := countHits(scenario, queryWords); hits > 0 {
+			// Scenarios describe when to use the guideline, so they weigh more than descriptions
+			match.score += 2 * hits
+			match.Reasons = append(match.Reasons, fmt.Sprintf("scenario: %s", scenario))
+		}
+	}
+
+	if hits := countHits(match.Guideline.Description+" "+match.Guideline.Name, queryWords); hits > 0 {
+		match.score += hits
+		if len(match.Reasons) == 0 {
+			match.Reasons = append(match.Reasons, "description matches query")
+		}
+	}
+}
+
+// countHits counts the query words contained in text
+func countHits(text string, queryWords []string) int {
+	words := splitWords(text)
+	hits := 0
+	for _, queryWord := range queryWords {
+		for _, word := range words {
+			if wordsMatch(word, queryWord) {
+				hits++
+				break
+			}
+		}
+	}
+	return hits
+}
+
+// wordsMatch reports whether two words match
+// Prefix matching lets "test" match "tests" and "testing"
+func wordsMatch(a, b string) bool {
+	if len(a) < minPrefixMatchLength || len(b) < minPrefixMatchLength {
+		return a == b
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// splitWords lowercases text and splits it into words, skipping stop words
+func splitWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var words []string
+	for _, field := range fields {
+		if !stopWords[field] {
+			words = append(words, field)
+		}
+	}
+	return words
+}
@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindGuidelines(t *testing.T) {
+	cfg, _ := setupTestProject(t)
+
+	t.Run("query matches scenarios", func(t *testing.T) {
+		matches := FindGuidelines(cfg, "designing an HTTP endpoint", "")
+		require.Len(t, matches, 1)
+		assert.Equal(t, "rest-api", matches[0].Guideline.Name)
+		assert.Equal(t, []string{"scenario: designing HTTP endpoints"}, matches[0].Reasons)
+	})
+
+	t.Run("short words match exactly", func(t *testing.T) {
+		matches := FindGuidelines(cfg, "go", "")
+		require.Len(t, matches, 1)
+		assert.Equal(t, "go-style", matches[0].Guideline.Name)
+	})
+
+	t.Run("file path matches patterns first", func(t *testing.T) {
+		matches := FindGuidelines(cfg, "designing endpoints", "./cmd/main.go")
+		require.Len(t, matches, 2)
+		assert.Equal(t, "go-style", matches[0].Guideline.Name)
+		assert.Equal(t, "rest-api", matches[1].Guideline.Name)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Empty(t, FindGuidelines(cfg, "kubernetes", "docs/readme.md"))
+	})
+}
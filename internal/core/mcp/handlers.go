@@ -0,0 +1,264 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/render"
+)
+
+// markdownMimeType is the MIME type of guideline and prompt content
+const markdownMimeType = "text/markdown"
+
+// promptInputArgument is the free-form argument accepted by every prompt
+const promptInputArgument = "input"
+
+// findGuidelinesTool is the name of the guideline discovery tool
+const findGuidelinesTool = "find_guidelines"
+
+// guidelineURI returns the resource URI of a guideline
+func guidelineURI(sourceName, guidelineName string) string {
+	return fmt.Sprintf("dnaspec://%s/guidelines/%s", sourceName, guidelineName)
+}
+
+// promptName returns the MCP prompt name of a prompt, matching the generated command names
+func promptName(sourceName, name string) string {
+	return fmt.Sprintf("%s-%s", sourceName, name)
+}
+
+// readSourceFile reads and renders a guideline file of a source
+func (s *Server) readSourceFile(source *config.ProjectSource, file string) (string, error) {
+	return render.ForSource(s.cfg, source, s.baseDir).RenderFile(file)
+}
+
+// handleListResources lists every installed guideline as a resource
+func (s *Server) handleListResources() (any, error) {
+	resources := []resource{}
+	for _, source := range s.cfg.Sources {
+		for _, guideline := range source.Guidelines {
+			resources = append(resources, resource{
+				URI:         guidelineURI(source.Name, guideline.Name),
+				Name:        fmt.Sprintf("%s/%s", source.Name, guideline.Name),
+				Title:       guideline.Description,
+				Description: formatResourceDescription(guideline),
+				MimeType:    markdownMimeType,
+			})
+		}
+	}
+	return map[string]any{"resources": resources}, nil
+}
+
+// formatResourceDescription describes a guideline together with its applicable scenarios
+func formatResourceDescription(guideline config.ProjectGuideline) string {
+	description := guideline.Description
+	if len(guideline.ApplicableScenarios) > 0 {
+		description += "\n\nApplicable scenarios:\n- " + strings.Join(guideline.ApplicableScenarios, "\n- ")
+	}
+	return description
+}
+
+// handleReadResource returns the content of a guideline resource
+func (s *Server) handleReadResource(params json.RawMessage) (any, error) {
+	var p readResourceParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for i := range s.cfg.Sources {
+		source := &s.cfg.Sources[i]
+		for _, guideline := range source.Guidelines {
+			if guidelineURI(source.Name, guideline.Name) != p.URI {
+				continue
+			}
+			content, err := s.readSourceFile(source, guideline.File)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{
+				"contents": []resourceContents{{URI: p.URI, MimeType: markdownMimeType, Text: content}},
+			}, nil
+		}
+	}
+
+	return nil, &rpcError{Code: codeResourceNotFound, Message: fmt.Sprintf("resource not found: %s", p.URI)}
+}
+
+// handleListPrompts lists every installed prompt
+func (s *Server) handleListPrompts() (any, error) {
+	prompts := []prompt{}
+	for _, source := range s.cfg.Sources {
+		for _, p := range source.Prompts {
+			arguments := make([]promptArgument, 0, len(p.Arguments)+1)
+			for _, argument := range p.Arguments {
+				arguments = append(arguments, promptArgument{
+					Name:        argument.Name,
+					Description: argument.Description,
+					Required:    argument.Required,
+				})
+			}
+			if !declaresInputArgument(p.Arguments) {
+				arguments = append(arguments, promptArgument{
+					Name:        promptInputArgument,
+					Description: "Additional instructions or context for the prompt",
+				})
+			}
+
+			prompts = append(prompts, prompt{
+				Name:        promptName(source.Name, p.Name),
+				Description: p.Description,
+				Arguments:   arguments,
+			})
+		}
+	}
+	return map[string]any{"prompts": prompts}, nil
+}
+
+// handleGetPrompt renders a prompt as a single user message
+func (s *Server) handleGetPrompt(params json.RawMessage) (any, error) {
+	var p getPromptParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for i := range s.cfg.Sources {
+		source := &s.cfg.Sources[i]
+		for _, projectPrompt := range source.Prompts {
+			if promptName(source.Name, projectPrompt.Name) != p.Name {
+				continue
+			}
+			content, err := render.ForSource(s.cfg, source, s.baseDir).RenderPrompt(projectPrompt)
+			if err != nil {
+				return nil, err
+			}
+
+			text, err := applyPromptArguments(content, projectPrompt.Arguments, p.Arguments)
+			if err != nil {
+				return nil, err
+			}
+			// A declared "input" argument was already applied like the prompt's other arguments
+			if input := strings.TrimSpace(p.Arguments[promptInputArgument]); input != "" && !declaresInputArgument(projectPrompt.Arguments) {
+				text += "\n\n" + input
+			}
+
+			return getPromptResult{
+				Description: projectPrompt.Description,
+				Messages: []promptMessage{
+					{Role: "user", Content: textContent{Type: "text", Text: text}},
+				},
+			}, nil
+		}
+	}
+
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("prompt not found: %s", p.Name)}
+}
+
+// declaresInputArgument reports whether a prompt declares an argument with the name of the free-form argument
+func declaresInputArgument(arguments []config.PromptArgument) bool {
+	return slices.ContainsFunc(arguments, func(a config.PromptArgument) bool { return a.Name == promptInputArgument })
+}
+
+// applyPromptArguments substitutes the argument values passed by the client into a rendered prompt
+// Values of arguments the prompt doesn't reference are appended after the content
+func applyPromptArguments(content string, declared []config.PromptArgument, values map[string]string) (string, error) {
+	for _, argument := range declared {
+		if argument.Required && strings.TrimSpace(values[argument.Name]) == "" {
+			return "", &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("missing required argument: %s", argument.Name)}
+		}
+	}
+
+	text, unreferenced := render.ExpandArguments(content, declared, func(_ int, argument config.PromptArgument) string {
+		return values[argument.Name]
+	})
+	text = strings.TrimSpace(text)
+
+	for _, argument := range unreferenced {
+		if value := strings.TrimSpace(values[argument.Name]); value != "" {
+			text += fmt.Sprintf("\n\n%s: %s", argument.Name, value)
+		}
+	}
+	return text, nil
+}
+
+// handleListTools lists the tools offered by the server
+func (s *Server) handleListTools() (any, error) {
+	tools := []tool{
+		{
+			Name:  findGuidelinesTool,
+			Title: "Find guidelines",
+			Description: "Find the installed guidelines relevant to a task or file. " +
+				"Matches the query against applicable scenarios and descriptions, and the file path against file patterns.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Description of the task, e.g. \"designing a REST endpoint\"",
+					},
+					"file_path": map[string]any{
+						"type":        "string",
+						"description": "Path of a file relative to the project root, e.g. \"internal/api/handler.go\"",
+					},
+				},
+			},
+		},
+	}
+	return map[string]any{"tools": tools}, nil
+}
+
+// findGuidelinesArgs holds the arguments of the find_guidelines tool
+type findGuidelinesArgs struct {
+	Query    string `json:"query"`
+	FilePath string `json:"file_path"`
+}
+
+// handleCallTool runs a tool
+func (s *Server) handleCallTool(params json.RawMessage) (any, error) {
+	var p callToolParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Name != findGuidelinesTool {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", p.Name)}
+	}
+
+	var args findGuidelinesArgs
+	if err := decodeParams(p.Arguments, &args); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.Query) == "" && strings.TrimSpace(args.FilePath) == "" {
+		return toolError("provide a query, a file_path, or both"), nil
+	}
+
+	matches := FindGuidelines(s.cfg, args.Query, args.FilePath)
+	return callToolResult{
+		Content: []textContent{{Type: "text", Text: formatMatches(matches)}},
+	}, nil
+}
+
+// toolError builds a tool result reporting a failure to the model
+func toolError(message string) callToolResult {
+	return callToolResult{
+		Content: []textContent{{Type: "text", Text: message}},
+		IsError: true,
+	}
+}
+
+// formatMatches renders guideline matches as markdown
+func formatMatches(matches []GuidelineMatch) string {
+	if len(matches) == 0 {
+		return "No matching guidelines found."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Found %d matching guideline(s):\n", len(matches)))
+	for _, match := range matches {
+		sb.WriteString(fmt.Sprintf("\n- %s (%s): %s\n", match.Guideline.Name, match.URI, match.Guideline.Description))
+		for _, reason := range match.Reasons {
+			sb.WriteString(fmt.Sprintf("  - %s\n", reason))
+		}
+	}
+	return sb.String()
+}
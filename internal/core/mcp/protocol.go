@@ -0,0 +1,155 @@
+package mcp
+
+import "encoding/json"
+
+// jsonRPCVersion is the JSON-RPC version used by MCP messages
+const jsonRPCVersion = "2.0"
+
+// latestProtocolVersion is returned when the client asks for a protocol version this server doesn't know
+const latestProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions lists the MCP protocol revisions this server can speak
+var supportedProtocolVersions = []string{"2024-11-05", "2025-03-26", "2025-06-18"}
+
+// JSON-RPC error codes
+const (
+	codeParseError       = -32700
+	codeInvalidRequest   = -32600
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeResourceNotFound = -32002
+)
+
+// request is an incoming JSON-RPC request or notification
+// Notifications carry no ID and never get a response
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is an outgoing JSON-RPC response
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// initializeParams holds the parameters of the initialize request
+type initializeParams struct {
+	ProtocolVersion string `json:"protocolVersion"`
+}
+
+// initializeResult is the result of the initialize request
+type initializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    serverCapabilities `json:"capabilities"`
+	ServerInfo      implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// serverCapabilities advertises the MCP features this server implements
+type serverCapabilities struct {
+	Resources struct{} `json:"resources"`
+	Prompts   struct{} `json:"prompts"`
+	Tools     struct{} `json:"tools"`
+}
+
+// implementation describes the server to the client
+type implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// resource describes a readable guideline
+type resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// resourceContents is the content of a read resource
+type resourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text"`
+}
+
+// readResourceParams holds the parameters of resources/read
+type readResourceParams struct {
+	URI string `json:"uri"`
+}
+
+// prompt describes a prompt template
+type prompt struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []promptArgument `json:"arguments,omitempty"`
+}
+
+// promptArgument describes an argument accepted by a prompt
+type promptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// getPromptParams holds the parameters of prompts/get
+type getPromptParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// getPromptResult is the result of prompts/get
+type getPromptResult struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []promptMessage `json:"messages"`
+}
+
+// promptMessage is a single message of a rendered prompt
+type promptMessage struct {
+	Role    string      `json:"role"`
+	Content textContent `json:"content"`
+}
+
+// textContent is a text content block
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// tool describes a callable tool
+type tool struct {
+	Name        string         `json:"name"`
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// callToolParams holds the parameters of tools/call
+type callToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// callToolResult is the result of tools/call
+// Tool failures are reported in the result with IsError rather than as protocol errors
+type callToolResult struct {
+	Content []textContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
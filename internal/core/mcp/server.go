@@ -0,0 +1,154 @@
+// Package mcp implements a Model Context Protocol server that exposes the
+// guidelines and prompts installed in a project.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// maxMessageSize bounds a single newline-delimited JSON-RPC message
+const maxMessageSize = 10 * 1024 * 1024
+
+// Server serves the guidelines and prompts of a project configuration over MCP
+type Server struct {
+	cfg     *config.ProjectConfig
+	baseDir string // Project root containing the dnaspec/ directory
+	version string
+}
+
+// NewServer creates an MCP server for the given project configuration
+// Guideline and prompt files are read from <baseDir>/dnaspec/<source-name>/
+func NewServer(cfg *config.ProjectConfig, baseDir, version string) *Server {
+	return &Server{
+		cfg:     cfg,
+		baseDir: baseDir,
+		version: version,
+	}
+}
+
+// Serve reads newline-delimited JSON-RPC messages from r and writes responses to w
+// It returns nil when r reaches EOF
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		resp := s.handleMessage(line)
+		if resp == nil {
+			continue
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+// handleMessage processes a single message and returns the response to send, if any
+func (s *Server) handleMessage(data []byte) *response {
+	var req request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return &response{
+			JSONRPC: jsonRPCVersion,
+			ID:      json.RawMessage("null"),
+			Error:   &rpcError{Code: codeParseError, Message: fmt.Sprintf("parse error: %v", err)},
+		}
+	}
+
+	// Notifications (e.g. notifications/initialized) need no response; a null id is treated as a missing one
+	if len(req.ID) == 0 || string(req.ID) == "null" {
+		return nil
+	}
+
+	resp := &response{JSONRPC: jsonRPCVersion, ID: req.ID}
+	if req.JSONRPC != jsonRPCVersion || req.Method == "" {
+		resp.Error = &rpcError{Code: codeInvalidRequest, Message: "invalid request"}
+		return resp
+	}
+
+	result, err := s.dispatch(req.Method, req.Params)
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+
+	resp.Result = result
+	return resp
+}
+
+// dispatch routes a request to its handler
+func (s *Server) dispatch(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return s.handleInitialize(params)
+	case "ping":
+		return struct{}{}, nil
+	case "resources/list":
+		return s.handleListResources()
+	case "resources/read":
+		return s.handleReadResource(params)
+	case "prompts/list":
+		return s.handleListPrompts()
+	case "prompts/get":
+		return s.handleGetPrompt(params)
+	case "tools/list":
+		return s.handleListTools()
+	case "tools/call":
+		return s.handleCallTool(params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", method)}
+	}
+}
+
+// handleInitialize negotiates the protocol version and advertises capabilities
+func (s *Server) handleInitialize(params json.RawMessage) (any, error) {
+	var p initializeParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	protocolVersion := latestProtocolVersion
+	if slices.Contains(supportedProtocolVersions, p.ProtocolVersion) {
+		protocolVersion = p.ProtocolVersion
+	}
+
+	return initializeResult{
+		ProtocolVersion: protocolVersion,
+		ServerInfo:      implementation{Name: "dnaspec", Version: s.version},
+		Instructions: "DNA guidelines installed in this project. Use find_guidelines to discover the guidelines " +
+			"relevant to a task or file, then read them as resources before making changes.",
+	}, nil
+}
+
+// decodeParams unmarshals request params into v, treating missing params as empty
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
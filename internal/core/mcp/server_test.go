@@ -0,0 +1,314 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClient is a minimal MCP client talking to a Server over in-memory pipes
+type testClient struct {
+	t       *testing.T
+	writer  *io.PipeWriter
+	scanner *bufio.Scanner
+	nextID  int
+	done    chan error
+}
+
+// newTestClient starts the server and performs the initialize handshake
+func newTestClient(t *testing.T, server *Server) *testClient {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	client := &testClient{
+		t:       t,
+		writer:  clientWriter,
+		scanner: bufio.NewScanner(clientReader),
+		done:    make(chan error, 1),
+	}
+
+	go func() {
+		err := server.Serve(serverReader, serverWriter)
+		_ = serverWriter.Close()
+		client.done <- err
+	}()
+
+	t.Cleanup(func() {
+		_ = clientWriter.Close()
+		require.NoError(t, <-client.done)
+	})
+
+	var result initializeResult
+	client.call("initialize", map[string]any{"protocolVersion": "2025-03-26"}, &result)
+	assert.Equal(t, "2025-03-26", result.ProtocolVersion)
+	assert.Equal(t, "dnaspec", result.ServerInfo.Name)
+	client.notify("notifications/initialized")
+
+	return client
+}
+
+// send writes a raw JSON-RPC message
+func (c *testClient) send(message map[string]any) {
+	data, err := json.Marshal(message)
+	require.NoError(c.t, err)
+	_, err = c.writer.Write(append(data, '\n'))
+	require.NoError(c.t, err)
+}
+
+// notify sends a notification, which gets no response
+func (c *testClient) notify(method string) {
+	c.send(map[string]any{"jsonrpc": "2.0", "method": method})
+}
+
+// request sends a request and returns the raw response
+func (c *testClient) request(method string, params any) response {
+	c.nextID++
+	c.send(map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+
+	require.True(c.t, c.scanner.Scan(), "expected a response to %s", method)
+	var resp struct {
+		response
+		Result json.RawMessage `json:"result"`
+	}
+	require.NoError(c.t, json.Unmarshal(c.scanner.Bytes(), &resp))
+	assert.JSONEq(c.t, string(mustMarshal(c.t, c.nextID)), string(resp.ID))
+	resp.response.Result = resp.Result
+	return resp.response
+}
+
+// call sends a request that must succeed and decodes its result
+func (c *testClient) call(method string, params any, result any) {
+	resp := c.request(method, params)
+	require.Nil(c.t, resp.Error, "%s failed", method)
+	require.NoError(c.t, json.Unmarshal(resp.Result.(json.RawMessage), result))
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+// setupTestProject creates an installed source and returns its project config
+func setupTestProject(t *testing.T) (*config.ProjectConfig, string) {
+	baseDir := t.TempDir()
+	sourceDir := filepath.Join(baseDir, "dnaspec", "company")
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceDir, "guidelines"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceDir, "prompts"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "guidelines", "go-style.md"), []byte("# Go Style\n\nUse gofmt.\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "guidelines", "rest-api.md"), []byte("# REST API\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "prompts", "code-review.md"), []byte("Review the code.\n"), 0644))
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name: "company",
+				Type: config.SourceTypeLocalPath,
+				Guidelines: []config.ProjectGuideline{
+					{
+						Name:                "go-style",
+						File:                "guidelines/go-style.md",
+						Description:         "Go coding style",
+						ApplicableScenarios: []string{"writing Go code", "reviewing Go code"},
+						FilePatterns:        []string{"**/*.go"},
+					},
+					{
+						Name:                "rest-api",
+						File:                "guidelines/rest-api.md",
+						Description:         "REST API design",
+						ApplicableScenarios: []string{"designing HTTP endpoints"},
+					},
+				},
+				Prompts: []config.ProjectPrompt{
+					{Name: "code-review", File: "prompts/code-review.md", Description: "Review code"},
+				},
+			},
+		},
+	}
+	return cfg, baseDir
+}
+
+func TestServer_Resources(t *testing.T) {
+	cfg, baseDir := setupTestProject(t)
+	client := newTestClient(t, NewServer(cfg, baseDir, "test"))
+
+	var list struct {
+		Resources []resource `json:"resources"`
+	}
+	client.call("resources/list", nil, &list)
+	require.Len(t, list.Resources, 2)
+	assert.Equal(t, "dnaspec://company/guidelines/go-style", list.Resources[0].URI)
+	assert.Equal(t, "company/go-style", list.Resources[0].Name)
+	assert.Contains(t, list.Resources[0].Description, "- writing Go code")
+
+	var read struct {
+		Contents []resourceContents `json:"contents"`
+	}
+	client.call("resources/read", map[string]any{"uri": "dnaspec://company/guidelines/go-style"}, &read)
+	require.Len(t, read.Contents, 1)
+	assert.Equal(t, "# Go Style\n\nUse gofmt.\n", read.Contents[0].Text)
+	assert.Equal(t, "text/markdown", read.Contents[0].MimeType)
+
+	resp := client.request("resources/read", map[string]any{"uri": "dnaspec://company/guidelines/missing"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, codeResourceNotFound, resp.Error.Code)
+}
+
+func TestServer_Prompts(t *testing.T) {
+	cfg, baseDir := setupTestProject(t)
+	client := newTestClient(t, NewServer(cfg, baseDir, "test"))
+
+	var list struct {
+		Prompts []prompt `json:"prompts"`
+	}
+	client.call("prompts/list", nil, &list)
+	require.Len(t, list.Prompts, 1)
+	assert.Equal(t, "company-code-review", list.Prompts[0].Name)
+	require.Len(t, list.Prompts[0].Arguments, 1)
+	assert.Equal(t, "input", list.Prompts[0].Arguments[0].Name)
+
+	var result getPromptResult
+	client.call("prompts/get", map[string]any{
+		"name":      "company-code-review",
+		"arguments": map[string]string{"input": "Focus on error handling."},
+	}, &result)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "user", result.Messages[0].Role)
+	assert.Equal(t, "Review the code.\n\nFocus on error handling.", result.Messages[0].Content.Text)
+
+	resp := client.request("prompts/get", map[string]any{"name": "company-missing"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, codeInvalidParams, resp.Error.Code)
+}
+
+func TestServer_PromptArguments(t *testing.T) {
+	cfg, baseDir := setupTestProject(t)
+	promptPath := filepath.Join(baseDir, "dnaspec", "company", "prompts", "fix-ticket.md")
+	require.NoError(t, os.WriteFile(promptPath, []byte("Fix ticket {{arg \"ticket\"}}.\n"), 0644))
+	cfg.Sources[0].Prompts = append(cfg.Sources[0].Prompts, config.ProjectPrompt{
+		Name:        "fix-ticket",
+		File:        "prompts/fix-ticket.md",
+		Description: "Fix a ticket",
+		Arguments: []config.PromptArgument{
+			{Name: "ticket", Description: "Ticket ID", Required: true},
+			{Name: "scope", Description: "Package to focus on"},
+		},
+	})
+	client := newTestClient(t, NewServer(cfg, baseDir, "test"))
+
+	var list struct {
+		Prompts []prompt `json:"prompts"`
+	}
+	client.call("prompts/list", nil, &list)
+	require.Len(t, list.Prompts, 2)
+	assert.Equal(t, []promptArgument{
+		{Name: "ticket", Description: "Ticket ID", Required: true},
+		{Name: "scope", Description: "Package to focus on"},
+		{Name: "input", Description: "Additional instructions or context for the prompt"},
+	}, list.Prompts[1].Arguments)
+
+	var result getPromptResult
+	client.call("prompts/get", map[string]any{
+		"name":      "company-fix-ticket",
+		"arguments": map[string]string{"ticket": "BILL-42", "scope": "internal/api"},
+	}, &result)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "Fix ticket BILL-42.\n\nscope: internal/api", result.Messages[0].Content.Text)
+
+	resp := client.request("prompts/get", map[string]any{"name": "company-fix-ticket"})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, codeInvalidParams, resp.Error.Code)
+	assert.Contains(t, resp.Error.Message, "missing required argument: ticket")
+}
+
+func TestServer_DeclaredInputArgument(t *testing.T) {
+	cfg, baseDir := setupTestProject(t)
+	promptPath := filepath.Join(baseDir, "dnaspec", "company", "prompts", "explain.md")
+	require.NoError(t, os.WriteFile(promptPath, []byte("Explain the code.\n"), 0644))
+	cfg.Sources[0].Prompts = append(cfg.Sources[0].Prompts, config.ProjectPrompt{
+		Name:        "explain",
+		File:        "prompts/explain.md",
+		Description: "Explain code",
+		Arguments:   []config.PromptArgument{{Name: "input", Description: "Code to explain"}},
+	})
+	client := newTestClient(t, NewServer(cfg, baseDir, "test"))
+
+	var list struct {
+		Prompts []prompt `json:"prompts"`
+	}
+	client.call("prompts/list", nil, &list)
+	require.Len(t, list.Prompts, 2)
+	assert.Equal(t, []promptArgument{{Name: "input", Description: "Code to explain"}}, list.Prompts[1].Arguments)
+
+	var result getPromptResult
+	client.call("prompts/get", map[string]any{
+		"name":      "company-explain",
+		"arguments": map[string]string{"input": "main.go"},
+	}, &result)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "Explain the code.\n\ninput: main.go", result.Messages[0].Content.Text)
+}
+
+func TestServer_FindGuidelinesTool(t *testing.T) {
+	cfg, baseDir := setupTestProject(t)
+	client := newTestClient(t, NewServer(cfg, baseDir, "test"))
+
+	var list struct {
+		Tools []tool `json:"tools"`
+	}
+	client.call("tools/list", nil, &list)
+	require.Len(t, list.Tools, 1)
+	assert.Equal(t, "find_guidelines", list.Tools[0].Name)
+
+	var result callToolResult
+	client.call("tools/call", map[string]any{
+		"name":      "find_guidelines",
+		"arguments": map[string]string{"file_path": "internal/api/handler.go"},
+	}, &result)
+	assert.False(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	assert.Contains(t, result.Content[0].Text, "go-style (dnaspec://company/guidelines/go-style)")
+	assert.Contains(t, result.Content[0].Text, "file path matches **/*.go")
+	assert.NotContains(t, result.Content[0].Text, "rest-api")
+
+	result = callToolResult{}
+	client.call("tools/call", map[string]any{"name": "find_guidelines", "arguments": map[string]string{}}, &result)
+	assert.True(t, result.IsError)
+}
+
+func TestServer_ProtocolErrors(t *testing.T) {
+	cfg, baseDir := setupTestProject(t)
+	client := newTestClient(t, NewServer(cfg, baseDir, "test"))
+
+	resp := client.request("unknown/method", nil)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, codeMethodNotFound, resp.Error.Code)
+
+	resp = client.request("ping", nil)
+	assert.Nil(t, resp.Error)
+}
+
+func TestServer_ParseError(t *testing.T) {
+	server := NewServer(&config.ProjectConfig{Version: 1}, t.TempDir(), "test")
+
+	resp := server.handleMessage([]byte("{not json"))
+	require.NotNil(t, resp)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, codeParseError, resp.Error.Code)
+}
+
+func TestServer_Notifications(t *testing.T) {
+	server := NewServer(&config.ProjectConfig{Version: 1}, t.TempDir(), "test")
+
+	assert.Nil(t, server.handleMessage([]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	assert.Nil(t, server.handleMessage([]byte(`{"jsonrpc":"2.0","id":null,"method":"ping"}`)))
+	assert.NotNil(t, server.handleMessage([]byte(`{"jsonrpc":"2.0","id":0,"method":"ping"}`)))
+}
@@ -0,0 +1,30 @@
+package paths
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "internal/core/mcp/find.go", true},
+		{"*.go", "internal/find.go", false},
+		{"internal/**", "internal/core/find.go", true},
+		{"internal/**/*_test.go", "internal/find_test.go", true},
+		{"internal/**/*_test.go", "internal/find.go", false},
+		{"docs/*.md", "docs/guide.md", true},
+		{"docs/*.md", "docs/sub/guide.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchGlob(tt.pattern, tt.name))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// argumentMarkerRegex matches the {{arg "name"}} markers left in rendered prompts
+var argumentMarkerRegex = regexp.MustCompile(`\{\{arg "([^"]*)"\}\}`)
+
+// RenderPrompt reads and renders a prompt file
+// {{arg "name"}} calls are checked against the prompt's declared arguments and kept as markers,
+// which agent generators replace with their own placeholder syntax using ExpandArguments
+func (r *Renderer) RenderPrompt(prompt config.ProjectPrompt) (string, error) {
+	r.arguments = prompt.Arguments
+	defer func() { r.arguments = nil }()
+	return r.RenderFile(prompt.File)
+}
+
+// arg keeps the marker of a declared prompt argument, for {{arg "name"}}
+func (r *Renderer) arg(name string) (string, error) {
+	if !slices.ContainsFunc(r.arguments, func(a config.PromptArgument) bool { return a.Name == name }) {
+		return "", fmt.Errorf("prompt argument '%s' is not declared", name)
+	}
+	return fmt.Sprintf("{{arg %q}}", name), nil
+}
+
+// ExpandArguments replaces the {{arg "name"}} markers of a rendered prompt with placeholder(index, argument)
+// It returns the expanded content and the declared arguments the prompt doesn't reference
+func ExpandArguments(
+	content string,
+	arguments []config.PromptArgument,
+	placeholder func(index int, argument config.PromptArgument) string,
+) (expanded string, unreferenced []config.PromptArgument) {
+	referenced := make(map[string]bool, len(arguments))
+	expanded = argumentMarkerRegex.ReplaceAllStringFunc(content, func(marker string) string {
+		name := argumentMarkerRegex.FindStringSubmatch(marker)[1]
+		for i, argument := range arguments {
+			if argument.Name == name {
+				referenced[name] = true
+				return placeholder(i, argument)
+			}
+		}
+		return marker
+	})
+
+	for _, argument := range arguments {
+		if !referenced[argument.Name] {
+			unreferenced = append(unreferenced, argument)
+		}
+	}
+	return expanded, unreferenced
+}
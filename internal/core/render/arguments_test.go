@@ -0,0 +1,49 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestRenderer_RenderPrompt(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "prompts"), 0755))
+	content := "Fix {{arg \"ticket\"}} in {{.module_path}}."
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prompts", "fix-ticket.md"), []byte(content), 0644))
+
+	renderer := NewRenderer(dir, nil, map[string]string{"module_path": "github.com/acme/app"}, []string{"prompts/fix-ticket.md"})
+	prompt := config.ProjectPrompt{
+		Name:      "fix-ticket",
+		File:      "prompts/fix-ticket.md",
+		Arguments: []config.PromptArgument{{Name: "ticket", Required: true}},
+	}
+
+	rendered, err := renderer.RenderPrompt(prompt)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix {{arg \"ticket\"}} in github.com/acme/app.", rendered)
+
+	// Outside a prompt with the argument declared, arg is an error
+	_, err = renderer.RenderFile("prompts/fix-ticket.md")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prompt argument 'ticket' is not declared")
+}
+
+func TestExpandArguments(t *testing.T) {
+	arguments := []config.PromptArgument{
+		{Name: "ticket", Required: true},
+		{Name: "scope"},
+	}
+	content := "Fix {{arg \"ticket\"}}, then {{arg \"ticket\"}} again. {{arg \"other\"}}"
+
+	expanded, unreferenced := ExpandArguments(content, arguments, func(i int, argument config.PromptArgument) string {
+		return argument.Name + "#" + string(rune('1'+i))
+	})
+	assert.Equal(t, "Fix ticket#1, then ticket#1 again. {{arg \"other\"}}", expanded)
+	assert.Equal(t, []config.PromptArgument{{Name: "scope"}}, unreferenced)
+}
@@ -0,0 +1,263 @@
+// Package render renders guideline and prompt markdown through text/template,
+// substituting project variables. Only entries marked as templates are rendered;
+// other files are used verbatim.
+package render
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/frontmatter"
+)
+
+// variableNameRegex matches names usable as {{.name}} in templates
+var variableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidVariableName reports whether name can be referenced as {{.name}}
+func ValidVariableName(name string) bool {
+	return variableNameRegex.MatchString(name)
+}
+
+// Vars resolves the variables of a source: manifest defaults overridden by the project's vars
+func Vars(cfg *config.ProjectConfig, source *config.ProjectSource) map[string]string {
+	vars := make(map[string]string, len(source.Variables)+len(cfg.Vars))
+	for _, variable := range source.Variables {
+		if variable.Default != "" {
+			vars[variable.Name] = variable.Default
+		}
+	}
+	maps.Copy(vars, cfg.Vars)
+	return vars
+}
+
+// Renderer renders the guideline and prompt files of a source
+type Renderer struct {
+	dir        string            // Directory the files are relative to
+	guidelines map[string]string // Guideline name -> file, the targets of include
+	templates  map[string]bool   // Files rendered as templates, the others are used verbatim
+	vars       map[string]string
+	including  []string                // Guidelines being included, innermost last
+	arguments  []config.PromptArgument // Arguments of the prompt being rendered
+}
+
+// NewRenderer creates a renderer for the files below dir
+// guidelines maps the guideline names available to include to their files, and templates lists the
+// files rendered as templates
+func NewRenderer(dir string, guidelines, vars map[string]string, templates []string) *Renderer {
+	r := &Renderer{dir: dir, guidelines: guidelines, templates: make(map[string]bool, len(templates)), vars: vars}
+	for _, file := range templates {
+		r.templates[file] = true
+	}
+	return r
+}
+
+// ForSource creates a renderer for an installed source, whose files live in baseDir/dnaspec/<source>
+func ForSource(cfg *config.ProjectConfig, source *config.ProjectSource, baseDir string) *Renderer {
+	guidelines := make(map[string]string, len(source.Guidelines))
+	var templates []string
+	for _, guideline := range source.Guidelines {
+		guidelines[guideline.Name] = guideline.File
+		if guideline.Template {
+			templates = append(templates, guideline.File)
+		}
+	}
+	for _, prompt := range source.Prompts {
+		if prompt.Template {
+			templates = append(templates, prompt.File)
+		}
+	}
+	return NewRenderer(filepath.Join(baseDir, "dnaspec", source.Name), guidelines, Vars(cfg, source), templates)
+}
+
+// Render renders content as a text/template with vars as data
+// Using a variable missing from vars is an error
+func Render(name, content string, vars map[string]string) (string, error) {
+	return NewRenderer("", nil, vars, nil).render(name, content)
+}
+
+// RenderFile reads a file, given relative to the renderer's directory, and renders it if it is a template
+// Frontmatter is left out: it holds the manifest entry (see dnaspec manifest build), not content for agents
+func (r *Renderer) RenderFile(file string) (string, error) {
+	path := filepath.Join(r.dir, file)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	_, body, _ := frontmatter.Split(string(content))
+	if !r.templates[file] {
+		return body, nil
+	}
+	return r.render(filepath.ToSlash(path), body)
+}
+
+// render renders content with the renderer's variables and include targets
+func (r *Renderer) render(name, content string) (string, error) {
+	tmpl, err := parseTemplate(name, content, r.funcs())
+	if err != nil {
+		return "", err
+	}
+	tmpl.Option("missingkey=error")
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, r.vars); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+// funcs returns the template functions available in guidelines and prompts
+func (r *Renderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		// args keeps Gemini CLI's {{args}} placeholder intact
+		"args":    func() string { return "{{args}}" },
+		"arg":     r.arg,
+		"include": r.include,
+	}
+}
+
+// include renders the guideline with the given name, for {{include "name"}}
+func (r *Renderer) include(name string) (string, error) {
+	file, ok := r.guidelines[name]
+	if !ok {
+		return "", fmt.Errorf("guideline '%s' is not installed", name)
+	}
+	if slices.Contains(r.including, name) {
+		return "", fmt.Errorf("include cycle: %s -> %s", strings.Join(r.including, " -> "), name)
+	}
+
+	r.including = append(r.including, name)
+	defer func() { r.including = r.including[:len(r.including)-1] }()
+
+	content, err := r.RenderFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(content, "\n"), nil
+}
+
+// References lists what a template refers to
+type References struct {
+	Variables []string // Top-level variables (.name and $.name), sorted
+	Includes  []string // Guideline names passed to include, in order of first use
+	Arguments []string // Prompt argument names passed to arg, in order of first use
+}
+
+// ParseReferences parses a template and returns the variables and guidelines it references
+func ParseReferences(name, content string) (*References, error) {
+	tmpl, err := parseTemplate(name, content, NewRenderer("", nil, nil, nil).funcs())
+	if err != nil {
+		return nil, err
+	}
+
+	refs := &References{}
+	if tmpl.Tree == nil {
+		return refs, nil
+	}
+
+	collector := &referenceCollector{variables: map[string]bool{}}
+	collector.collect(tmpl.Root, true)
+	for variable := range collector.variables {
+		refs.Variables = append(refs.Variables, variable)
+	}
+	sort.Strings(refs.Variables)
+	refs.Includes = collector.includes
+	refs.Arguments = collector.arguments
+
+	return refs, nil
+}
+
+// parseTemplate parses content with the given template functions
+func parseTemplate(name, content string, funcs template.FuncMap) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// referenceCollector gathers the references of a parsed template
+type referenceCollector struct {
+	variables map[string]bool
+	includes  []string
+	arguments []string
+}
+
+// collect records the references below node
+// atRoot is false inside range and with bodies, where dot no longer refers to the variables
+func (c *referenceCollector) collect(node parse.Node, atRoot bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.collect(child, atRoot)
+		}
+	case *parse.ActionNode:
+		c.collect(n.Pipe, atRoot)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			c.collect(cmd, atRoot)
+		}
+	case *parse.CommandNode:
+		c.collectCall(n)
+		for _, arg := range n.Args {
+			c.collect(arg, atRoot)
+		}
+	case *parse.FieldNode:
+		if atRoot {
+			c.variables[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			c.variables[n.Ident[1]] = true
+		}
+	case *parse.IfNode:
+		c.collectBranch(&n.BranchNode, atRoot, atRoot)
+	case *parse.RangeNode:
+		c.collectBranch(&n.BranchNode, atRoot, false)
+	case *parse.WithNode:
+		c.collectBranch(&n.BranchNode, atRoot, false)
+	case *parse.TemplateNode:
+		c.collect(n.Pipe, atRoot)
+	}
+}
+
+// collectBranch records the references of an if, range or with node
+// The else branch of range and with runs with the original dot
+func (c *referenceCollector) collectBranch(n *parse.BranchNode, atRoot, bodyAtRoot bool) {
+	c.collect(n.Pipe, atRoot)
+	c.collect(n.List, bodyAtRoot)
+	c.collect(n.ElseList, atRoot)
+}
+
+// collectCall records the target of an include "name" or arg "name" call
+func (c *referenceCollector) collectCall(cmd *parse.CommandNode) {
+	if len(cmd.Args) != 2 {
+		return
+	}
+	ident, isIdent := cmd.Args[0].(*parse.IdentifierNode)
+	target, isString := cmd.Args[1].(*parse.StringNode)
+	if !isIdent || !isString {
+		return
+	}
+
+	switch {
+	case ident.Ident == "include" && !slices.Contains(c.includes, target.Text):
+		c.includes = append(c.includes, target.Text)
+	case ident.Ident == "arg" && !slices.Contains(c.arguments, target.Text):
+		c.arguments = append(c.arguments, target.Text)
+	}
+}
@@ -0,0 +1,155 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestRender(t *testing.T) {
+	vars := map[string]string{"module_path": "github.com/acme/app", "test_command": "make test"}
+
+	t.Run("substitutes variables", func(t *testing.T) {
+		content, err := Render("review.md", "Module {{.module_path}}, run `{{.test_command}}`.", vars)
+		require.NoError(t, err)
+		assert.Equal(t, "Module github.com/acme/app, run `make test`.", content)
+	})
+
+	t.Run("keeps Gemini args placeholder", func(t *testing.T) {
+		content, err := Render("review.md", "Review {{args}}", vars)
+		require.NoError(t, err)
+		assert.Equal(t, "Review {{args}}", content)
+	})
+
+	t.Run("undefined variable is an error", func(t *testing.T) {
+		_, err := Render("review.md", "{{.service_name}}", vars)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "service_name")
+	})
+
+	t.Run("invalid template is an error", func(t *testing.T) {
+		_, err := Render("review.md", "{{.module_path", vars)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid template")
+	})
+}
+
+func TestParseReferences(t *testing.T) {
+	content := `{{.module_path}} {{if .service_name}}{{.service_name}}{{else}}{{$.fallback}}{{end}}
+{{range .items}}{{.nested}}{{$.in_range}}{{end}} {{args}} {{"{{literal}}"}}
+{{include "go-style"}} {{with .team}}{{include "rest-api"}}{{end}} {{include "go-style"}} {{arg "ticket"}}`
+
+	refs, err := ParseReferences("test.md", content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fallback", "in_range", "items", "module_path", "service_name", "team"}, refs.Variables)
+	assert.Equal(t, []string{"go-style", "rest-api"}, refs.Includes)
+	assert.Equal(t, []string{"ticket"}, refs.Arguments)
+
+	refs, err = ParseReferences("plain.md", "# Plain markdown\n")
+	require.NoError(t, err)
+	assert.Empty(t, refs.Variables)
+	assert.Empty(t, refs.Includes)
+}
+
+func TestRenderer_Include(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"guidelines/go-style.md":    "# Go Style\n\nUse {{.module_path}}.\n\n{{include \"errors\"}}\n",
+		"guidelines/errors.md":      "## Errors\n\nWrap errors.\n",
+		"guidelines/loop-a.md":      "{{include \"loop-b\"}}",
+		"guidelines/loop-b.md":      "{{include \"loop-a\"}}",
+		"prompts/code-review.md":    "Review against:\n\n{{include \"go-style\"}}\n\nBe specific.",
+		"prompts/missing-target.md": "{{include \"rest-api\"}}",
+		"guidelines/with-frontmatter.md": "---\nname: with-frontmatter\ndescription: Errors\n---\n" +
+			"# Errors\n\nUse {{.module_path}}.\n",
+		"guidelines/ci.md":  "# CI\n\nUse `${{ secrets.TOKEN }}`.\n",
+		"prompts/deploy.md": "Deploy following:\n\n{{include \"ci\"}}",
+	}
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+
+	renderer := NewRenderer(dir, map[string]string{
+		"go-style": "guidelines/go-style.md",
+		"errors":   "guidelines/errors.md",
+		"loop-a":   "guidelines/loop-a.md",
+		"loop-b":   "guidelines/loop-b.md",
+		"ci":       "guidelines/ci.md",
+	}, map[string]string{"module_path": "github.com/acme/app"}, []string{
+		"guidelines/go-style.md",
+		"guidelines/loop-a.md",
+		"guidelines/loop-b.md",
+		"guidelines/with-frontmatter.md",
+		"prompts/code-review.md",
+		"prompts/missing-target.md",
+		"prompts/deploy.md",
+	})
+
+	t.Run("uses files that aren't templates verbatim", func(t *testing.T) {
+		content, err := renderer.RenderFile("guidelines/ci.md")
+		require.NoError(t, err)
+		assert.Equal(t, "# CI\n\nUse `${{ secrets.TOKEN }}`.\n", content)
+
+		content, err = renderer.RenderFile("prompts/deploy.md")
+		require.NoError(t, err)
+		assert.Equal(t, "Deploy following:\n\n# CI\n\nUse `${{ secrets.TOKEN }}`.", content)
+	})
+
+	t.Run("includes guidelines recursively with variables", func(t *testing.T) {
+		content, err := renderer.RenderFile("prompts/code-review.md")
+		require.NoError(t, err)
+		assert.Equal(t,
+			"Review against:\n\n# Go Style\n\nUse github.com/acme/app.\n\n## Errors\n\nWrap errors.\n\nBe specific.",
+			content,
+		)
+	})
+
+	t.Run("leaves out frontmatter", func(t *testing.T) {
+		content, err := renderer.RenderFile("guidelines/with-frontmatter.md")
+		require.NoError(t, err)
+		assert.Equal(t, "# Errors\n\nUse github.com/acme/app.\n", content)
+	})
+
+	t.Run("missing target", func(t *testing.T) {
+		_, err := renderer.RenderFile("prompts/missing-target.md")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "guideline 'rest-api' is not installed")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := renderer.RenderFile("guidelines/loop-a.md")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "include cycle: loop-b -> loop-a -> loop-b")
+	})
+}
+
+func TestVars(t *testing.T) {
+	cfg := &config.ProjectConfig{Vars: map[string]string{"module_path": "github.com/acme/app", "extra": "x"}}
+	source := &config.ProjectSource{
+		Variables: []config.Variable{
+			{Name: "module_path", Default: "example.com/module"},
+			{Name: "test_command", Default: "go test ./..."},
+			{Name: "service_name"},
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		"module_path":  "github.com/acme/app",
+		"test_command": "go test ./...",
+		"extra":        "x",
+	}, Vars(cfg, source))
+}
+
+func TestValidVariableName(t *testing.T) {
+	assert.True(t, ValidVariableName("module_path"))
+	assert.True(t, ValidVariableName("ServiceName"))
+	assert.False(t, ValidVariableName("module-path"))
+	assert.False(t, ValidVariableName("1st"))
+	assert.False(t, ValidVariableName(""))
+}
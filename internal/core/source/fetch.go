@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/aviator5/dnaspec/internal/core/git"
+	"github.com/aviator5/dnaspec/internal/core/render"
 	"github.com/aviator5/dnaspec/internal/core/validate"
 )
 
@@ -45,12 +47,12 @@ func FetchGitSource(url, ref string) (*SourceInfo, func(), error) {
 		return nil, nil, fmt.Errorf("failed to load manifest from repository: %w", err)
 	}
 
-	// Validate manifest
-	validationErrors := validate.ValidateManifest(manifest, tempDir)
-	if len(validationErrors) > 0 {
+	// Validate manifest; warnings don't prevent using the source
+	if validationErrors, _ := validate.ValidateManifest(manifest, tempDir).Partition(); len(validationErrors) > 0 {
 		cleanup()
 		return nil, nil, fmt.Errorf("manifest validation failed: %s", validationErrors.Error())
 	}
+	resolveIncludes(manifest, tempDir)
 
 	info := &SourceInfo{
 		Manifest:   manifest,
@@ -94,11 +96,11 @@ func FetchLocalSource(path string) (*SourceInfo, error) {
 		return nil, fmt.Errorf("failed to load manifest from directory: %w", err)
 	}
 
-	// Validate manifest
-	validationErrors := validate.ValidateManifest(manifest, absPath)
-	if len(validationErrors) > 0 {
+	// Validate manifest; warnings don't prevent using the source
+	if validationErrors, _ := validate.ValidateManifest(manifest, absPath).Partition(); len(validationErrors) > 0 {
 		return nil, fmt.Errorf("manifest validation failed: %s", validationErrors.Error())
 	}
+	resolveIncludes(manifest, absPath)
 
 	info := &SourceInfo{
 		Manifest:   manifest,
@@ -109,3 +111,43 @@ func FetchLocalSource(path string) (*SourceInfo, error) {
 
 	return info, nil
 }
+
+// resolveIncludes records on each guideline the guidelines that its template, and the templates of
+// its prompts, include, so selecting the guideline selects them too
+// Files that can't be read or parsed are skipped since validation already reports them
+func resolveIncludes(manifest *config.Manifest, sourceDir string) {
+	templateIncludes := func(file string) []string {
+		content, err := os.ReadFile(filepath.Join(sourceDir, file))
+		if err != nil {
+			return nil
+		}
+		refs, err := render.ParseReferences(file, string(content))
+		if err != nil {
+			return nil
+		}
+		return refs.Includes
+	}
+
+	promptIncludes := make(map[string][]string, len(manifest.Prompts))
+	for _, prompt := range manifest.Prompts {
+		if prompt.Template {
+			promptIncludes[prompt.Name] = templateIncludes(prompt.File)
+		}
+	}
+
+	for i := range manifest.Guidelines {
+		guideline := &manifest.Guidelines[i]
+		var includes []string
+		if guideline.Template {
+			includes = append(includes, templateIncludes(guideline.File)...)
+		}
+		for _, prompt := range guideline.Prompts {
+			includes = append(includes, promptIncludes[prompt]...)
+		}
+		for _, target := range includes {
+			if target != guideline.Name && !slices.Contains(guideline.Includes, target) {
+				guideline.Includes = append(guideline.Includes, target)
+			}
+		}
+	}
+}
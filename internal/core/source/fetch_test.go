@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
 )
 
 func TestFetchLocalSource(t *testing.T) {
@@ -287,3 +289,34 @@ func getGitHead(t *testing.T, dir string) string {
 	}
 	return strings.TrimSpace(string(out))
 }
+
+func TestResolveIncludes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"guidelines/go-style.md": "# Go Style",
+		"guidelines/testing.md":  "# Testing\n\n{{include \"go-style\"}}",
+		"prompts/review.md":      "Review against:\n\n{{include \"go-style\"}}\n{{include \"testing\"}}",
+		"prompts/plain.md":       "{{include \"testing\"}}",
+	}
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+
+	manifest := &config.Manifest{
+		Guidelines: []config.ManifestGuideline{
+			{Name: "go-style", File: "guidelines/go-style.md", Prompts: []string{"review"}},
+			{Name: "testing", File: "guidelines/testing.md", Template: true, Prompts: []string{"plain"}},
+		},
+		Prompts: []config.ManifestPrompt{
+			{Name: "review", File: "prompts/review.md", Template: true},
+			{Name: "plain", File: "prompts/plain.md"},
+		},
+	}
+
+	resolveIncludes(manifest, dir)
+
+	// A guideline doesn't depend on itself, and prompts that aren't templates include nothing
+	require.Equal(t, []string{"testing"}, manifest.Guidelines[0].Includes)
+	require.Equal(t, []string{"go-style"}, manifest.Guidelines[1].Includes)
+}
@@ -0,0 +1,213 @@
+package stats
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/aviator5/dnaspec/internal/core/agents"
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// Entry kinds
+const (
+	KindGuideline = "guideline"
+	KindPrompt    = "prompt"
+	KindContext   = "context"
+)
+
+// Size is the measured size of some content
+type Size struct {
+	Bytes  int `json:"bytes"`
+	Tokens int `json:"tokens"`
+}
+
+// add accumulates another size
+func (s *Size) add(other Size) {
+	s.Bytes += other.Bytes
+	s.Tokens += other.Tokens
+}
+
+// Entry is the size of a single file
+type Entry struct {
+	Kind   string `json:"kind"`             // guideline, prompt, context or an agent ID
+	Source string `json:"source,omitempty"` // Source name, empty for shared context files
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Size
+}
+
+// Totals sums the sizes per section
+type Totals struct {
+	Guidelines Size `json:"guidelines"`
+	Prompts    Size `json:"prompts"`
+	AgentFiles Size `json:"agent_files"`
+}
+
+// Report holds the sizes of all guidelines, prompts and generated agent files of a project
+type Report struct {
+	Guidelines []Entry `json:"guidelines"`
+	Prompts    []Entry `json:"prompts"`
+	AgentFiles []Entry `json:"agent_files"`
+	Totals     Totals  `json:"totals"`
+}
+
+// Collect measures the files of a project, relative to the current directory
+// Files that don't exist (e.g. agent files that were never generated) are skipped
+func Collect(cfg *config.ProjectConfig) (*Report, error) {
+	report := &Report{
+		Guidelines: []Entry{},
+		Prompts:    []Entry{},
+		AgentFiles: []Entry{},
+	}
+
+	for _, source := range cfg.Sources {
+		sourceDir := filepath.Join("dnaspec", source.Name)
+
+		for _, guideline := range source.Guidelines {
+			entry, err := measure(KindGuideline, source.Name, guideline.Name, filepath.Join(sourceDir, guideline.File))
+			if err != nil {
+				return nil, err
+			}
+			if entry != nil {
+				report.Guidelines = append(report.Guidelines, *entry)
+				report.Totals.Guidelines.add(entry.Size)
+			}
+		}
+
+		for _, prompt := range source.Prompts {
+			entry, err := measure(KindPrompt, source.Name, prompt.Name, filepath.Join(sourceDir, prompt.File))
+			if err != nil {
+				return nil, err
+			}
+			if entry != nil {
+				report.Prompts = append(report.Prompts, *entry)
+				report.Totals.Prompts.add(entry.Size)
+			}
+		}
+	}
+
+	agentFiles, err := collectAgentFiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range agentFiles {
+		report.AgentFiles = append(report.AgentFiles, entry)
+		report.Totals.AgentFiles.add(entry.Size)
+	}
+
+	return report, nil
+}
+
+// collectAgentFiles measures shared context files and the generated files of every source
+func collectAgentFiles(cfg *config.ProjectConfig) ([]Entry, error) {
+	var entries []Entry
+
+	for _, path := range agents.ContextFiles {
+		entry, err := measure(KindContext, "", path, filepath.FromSlash(path))
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+
+	for _, source := range cfg.Sources {
+		var sourceEntries []Entry
+		for _, pattern := range agents.AgentFilePatterns {
+			matches, err := pattern.GetFilesForSource(cfg, source.Name)
+			if err != nil {
+				continue
+			}
+			for _, match := range matches {
+				files, err := listFiles(match)
+				if err != nil {
+					return nil, err
+				}
+				for _, file := range files {
+					entry, err := measure(pattern.AgentID, source.Name, filepath.ToSlash(file), file)
+					if err != nil {
+						return nil, err
+					}
+					if entry != nil {
+						sourceEntries = append(sourceEntries, *entry)
+					}
+				}
+			}
+		}
+		sort.SliceStable(sourceEntries, func(i, j int) bool {
+			return sourceEntries[i].Path < sourceEntries[j].Path
+		})
+		entries = append(entries, sourceEntries...)
+	}
+
+	return entries, nil
+}
+
+// listFiles returns path itself if it is a file, or all files below it if it is a directory
+func listFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", path, err)
+	}
+	return files, nil
+}
+
+// measure reads a file and computes its size, returning nil if the file doesn't exist
+func measure(kind, sourceName, name, path string) (*Entry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return &Entry{
+		Kind:   kind,
+		Source: sourceName,
+		Name:   name,
+		Path:   filepath.ToSlash(path),
+		Size:   Size{Bytes: len(content), Tokens: EstimateTokens(string(content))},
+	}, nil
+}
+
+// Warnings lists the entries whose token estimate exceeds the configured budgets
+func (r *Report) Warnings(budgets config.BudgetOptions) []string {
+	var warnings []string
+	check := func(entries []Entry, limit int, label string) {
+		for _, entry := range entries {
+			if entry.Tokens > limit {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s %s is ~%d tokens (budget %d)", label, entry.Path, entry.Tokens, limit,
+				))
+			}
+		}
+	}
+
+	check(r.Guidelines, budgets.GuidelineLimit(), "Guideline")
+	check(r.Prompts, budgets.PromptLimit(), "Prompt")
+	check(r.AgentFiles, budgets.AgentFileLimit(), "Agent file")
+
+	return warnings
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestCollect(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer func() {
+		err := os.Chdir(originalDir)
+		require.NoError(t, err)
+	}()
+
+	err := os.Chdir(tempDir)
+	require.NoError(t, err)
+
+	sourceDir := filepath.Join("dnaspec", "company")
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceDir, "guidelines"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceDir, "prompts"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "guidelines", "go-style.md"), []byte("# Go Style\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "prompts", "review.md"), []byte(strings.Repeat("word ", 100)), 0644))
+	require.NoError(t, os.WriteFile("AGENTS.md", []byte("agents"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(".claude", "skills", "dnaspec-company-go-style"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(".claude", "skills", "dnaspec-company-go-style", "SKILL.md"), []byte("skill"), 0644))
+
+	cfg := &config.ProjectConfig{
+		Version: 1,
+		Sources: []config.ProjectSource{
+			{
+				Name:       "company",
+				Guidelines: []config.ProjectGuideline{{Name: "go-style", File: "guidelines/go-style.md"}},
+				Prompts:    []config.ProjectPrompt{{Name: "review", File: "prompts/review.md"}},
+			},
+		},
+	}
+
+	report, err := Collect(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, report.Guidelines, 1)
+	assert.Equal(t, Entry{
+		Kind: KindGuideline, Source: "company", Name: "go-style",
+		Path: "dnaspec/company/guidelines/go-style.md", Size: Size{Bytes: 11, Tokens: 4},
+	}, report.Guidelines[0])
+
+	require.Len(t, report.Prompts, 1)
+	assert.Equal(t, 100, report.Prompts[0].Tokens)
+
+	// Missing context files are skipped, skill directories are expanded
+	require.Len(t, report.AgentFiles, 2)
+	assert.Equal(t, "AGENTS.md", report.AgentFiles[0].Path)
+	assert.Equal(t, KindContext, report.AgentFiles[0].Kind)
+	assert.Equal(t, ".claude/skills/dnaspec-company-go-style/SKILL.md", report.AgentFiles[1].Path)
+	assert.Equal(t, "claude-code", report.AgentFiles[1].Kind)
+	assert.Equal(t, Size{Bytes: 11, Tokens: 4}, report.Totals.AgentFiles)
+
+	t.Run("warnings use configured budgets", func(t *testing.T) {
+		assert.Empty(t, report.Warnings(config.BudgetOptions{}))
+
+		warnings := report.Warnings(config.BudgetOptions{PromptTokens: 50})
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "dnaspec/company/prompts/review.md is ~100 tokens (budget 50)")
+	})
+}
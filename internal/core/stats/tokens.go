@@ -0,0 +1,51 @@
+// Package stats estimates the size of guidelines, prompts and generated agent files
+// in bytes and approximate model tokens.
+package stats
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// charsPerToken is the average number of characters of a word per token for English text and code
+const charsPerToken = 4
+
+// EstimateTokens approximates the number of model tokens in text
+// Words count one token per four characters, punctuation and symbols count one token each,
+// whitespace is free and non-Latin letters such as CJK count one token per character.
+// The estimate is tokenizer-agnostic and meant for budgeting, not billing.
+func EstimateTokens(text string) int {
+	tokens := 0
+	wordLength := 0
+
+	flushWord := func() {
+		if wordLength > 0 {
+			tokens += (wordLength + charsPerToken - 1) / charsPerToken
+			wordLength = 0
+		}
+	}
+
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+
+		switch {
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			wordLength++
+		case unicode.IsSpace(r):
+			flushWord()
+		case unicode.IsLetter(r) && !unicode.In(r, unicode.Latin, unicode.Cyrillic, unicode.Greek):
+			// Logographic and syllabic scripts are roughly one token per character
+			flushWord()
+			tokens++
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			wordLength++
+		default:
+			flushWord()
+			tokens++
+		}
+	}
+	flushWord()
+
+	return tokens
+}
@@ -0,0 +1,29 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{name: "empty", text: "", expected: 0},
+		{name: "short words", text: "use the API", expected: 3},
+		{name: "long word", text: "internationalization", expected: 5},
+		{name: "punctuation", text: "foo(bar);", expected: 5},
+		{name: "markdown heading", text: "# Go Style\n", expected: 4},
+		{name: "accented latin", text: "café", expected: 1},
+		{name: "cjk characters", text: "日本語", expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EstimateTokens(tt.text))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// validateBundles validates the bundle entries of a manifest
+func validateBundles(manifest *config.Manifest) ValidationErrors {
+	var errors ValidationErrors
+
+	guidelineNames := make(map[string]bool, len(manifest.Guidelines))
+	for _, g := range manifest.Guidelines {
+		guidelineNames[g.Name] = true
+	}
+
+	bundleNames := make(map[string]bool)
+	for i, bundle := range manifest.Bundles {
+		prefix := fmt.Sprintf("bundles[%d]", i)
+
+		if bundle.Name == "" {
+			errors.Add(prefix+".name", "missing required field: name")
+		} else {
+			if bundleNames[bundle.Name] {
+				errors.Add(prefix+".name", fmt.Sprintf("duplicate bundle name: %s", bundle.Name))
+			}
+			bundleNames[bundle.Name] = true
+
+			if !spinalCaseRegex.MatchString(bundle.Name) {
+				errors.Add(
+					prefix+".name",
+					fmt.Sprintf("invalid naming format: '%s' (expected spinal-case: lowercase letters and hyphens only)", bundle.Name),
+				)
+			}
+		}
+
+		if bundle.Description == "" {
+			errors.Add(prefix+".description", "missing required field: description")
+		}
+
+		if len(bundle.Guidelines) == 0 {
+			errors.Add(prefix+".guidelines", fmt.Sprintf("bundle '%s' has no guidelines", bundle.Name))
+		}
+		for j, name := range bundle.Guidelines {
+			field := fmt.Sprintf("%s.guidelines[%d]", prefix, j)
+			switch {
+			case !guidelineNames[name]:
+				errors.Add(field, fmt.Sprintf("bundle '%s' references non-existent guideline '%s'", bundle.Name, name))
+			case slices.Index(bundle.Guidelines, name) < j:
+				errors.Add(field, fmt.Sprintf("bundle '%s' lists guideline '%s' more than once", bundle.Name, name))
+			}
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestValidator_Bundles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	guidelinePath := "guidelines/test.md"
+	fullPath := filepath.Join(tmpDir, guidelinePath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+	require.NoError(t, os.WriteFile(fullPath, []byte("test"), 0644))
+
+	tests := []struct {
+		name      string
+		bundles   []config.Bundle
+		wantField string
+		wantError string
+	}{
+		{
+			name:    "valid bundle",
+			bundles: []config.Bundle{{Name: "backend-go", Description: "Go services", Guidelines: []string{"go-style"}}},
+		},
+		{
+			name:      "missing name",
+			bundles:   []config.Bundle{{Description: "Go services", Guidelines: []string{"go-style"}}},
+			wantField: "bundles[0].name",
+			wantError: "missing required field: name",
+		},
+		{
+			name:      "invalid name",
+			bundles:   []config.Bundle{{Name: "Backend_Go", Description: "Go services", Guidelines: []string{"go-style"}}},
+			wantField: "bundles[0].name",
+			wantError: "invalid naming format",
+		},
+		{
+			name: "duplicate name",
+			bundles: []config.Bundle{
+				{Name: "backend-go", Description: "Go services", Guidelines: []string{"go-style"}},
+				{Name: "backend-go", Description: "Go services again", Guidelines: []string{"go-style"}},
+			},
+			wantField: "bundles[1].name",
+			wantError: "duplicate bundle name: backend-go",
+		},
+		{
+			name:      "missing description",
+			bundles:   []config.Bundle{{Name: "backend-go", Guidelines: []string{"go-style"}}},
+			wantField: "bundles[0].description",
+			wantError: "missing required field: description",
+		},
+		{
+			name:      "no guidelines",
+			bundles:   []config.Bundle{{Name: "backend-go", Description: "Go services"}},
+			wantField: "bundles[0].guidelines",
+			wantError: "bundle 'backend-go' has no guidelines",
+		},
+		{
+			name:      "unknown guideline",
+			bundles:   []config.Bundle{{Name: "backend-go", Description: "Go services", Guidelines: []string{"go-style", "rust"}}},
+			wantField: "bundles[0].guidelines[1]",
+			wantError: "references non-existent guideline 'rust'",
+		},
+		{
+			name: "duplicate guideline",
+			bundles: []config.Bundle{
+				{Name: "backend-go", Description: "Go services", Guidelines: []string{"go-style", "go-style"}},
+			},
+			wantField: "bundles[0].guidelines[1]",
+			wantError: "lists guideline 'go-style' more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &config.Manifest{
+				Version: 1,
+				Guidelines: []config.ManifestGuideline{
+					{
+						Name:                "go-style",
+						File:                guidelinePath,
+						Description:         "Go style",
+						ApplicableScenarios: []string{"writing Go"},
+					},
+				},
+				Bundles: tt.bundles,
+			}
+
+			errs := ValidateManifest(manifest, tmpDir)
+
+			if tt.wantError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantError)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// validateDeprecations validates guideline aliases and deprecations, and flags deprecated guidelines
+// that bundles or other guidelines still point at
+func validateDeprecations(manifest *config.Manifest) ValidationErrors {
+	var errors ValidationErrors
+
+	byName := make(map[string]*config.ManifestGuideline, len(manifest.Guidelines))
+	for i := range manifest.Guidelines {
+		byName[manifest.Guidelines[i].Name] = &manifest.Guidelines[i]
+	}
+
+	errors = append(errors, validateAliases(manifest.Guidelines, byName)...)
+
+	for i, g := range manifest.Guidelines {
+		if g.Deprecated == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("guidelines[%d].deprecated", i)
+		if g.Deprecated.Message == "" {
+			errors.Add(prefix+".message", "missing required field: message")
+		}
+		if g.Required {
+			errors.Add(prefix, fmt.Sprintf("guideline '%s' is both required and deprecated", g.Name))
+		}
+
+		replacement := g.Deprecated.Replacement
+		switch {
+		case replacement == "":
+		case replacement == g.Name:
+			errors.Add(prefix+".replacement", fmt.Sprintf("guideline '%s' can't replace itself", g.Name))
+		case byName[replacement] == nil:
+			errors.Add(prefix+".replacement", fmt.Sprintf("replacement '%s' is not a guideline in the manifest", replacement))
+		case byName[replacement].Deprecated != nil:
+			errors.Add(prefix+".replacement", fmt.Sprintf("replacement '%s' is deprecated too", replacement))
+		}
+	}
+
+	errors = append(errors, validateDeprecatedReferences(manifest, byName)...)
+	return errors
+}
+
+// validateAliases checks that former guideline names are spinal-case and unambiguous
+func validateAliases(guidelines []config.ManifestGuideline, byName map[string]*config.ManifestGuideline) ValidationErrors {
+	var errors ValidationErrors
+
+	aliasOf := make(map[string]string)
+	for i, g := range guidelines {
+		for j, alias := range g.Aliases {
+			field := fmt.Sprintf("guidelines[%d].aliases[%d]", i, j)
+			switch {
+			case !spinalCaseRegex.MatchString(alias):
+				errors.Add(field, fmt.Sprintf("invalid naming format: '%s' (expected spinal-case: lowercase letters and hyphens only)", alias))
+			case byName[alias] != nil:
+				errors.Add(field, fmt.Sprintf("alias '%s' is the name of a guideline", alias))
+			case aliasOf[alias] != "":
+				errors.Add(field, fmt.Sprintf("alias '%s' is already an alias of '%s'", alias, aliasOf[alias]))
+			default:
+				aliasOf[alias] = g.Name
+			}
+		}
+	}
+
+	return errors
+}
+
+// validateDeprecatedReferences warns about bundles and non-deprecated guidelines that still use deprecated guidelines
+func validateDeprecatedReferences(manifest *config.Manifest, byName map[string]*config.ManifestGuideline) ValidationErrors {
+	var errors ValidationErrors
+
+	deprecated := func(name string) bool {
+		return byName[name] != nil && byName[name].Deprecated != nil
+	}
+
+	for i, bundle := range manifest.Bundles {
+		for j, name := range bundle.Guidelines {
+			if deprecated(name) {
+				errors.AddWarning(
+					fmt.Sprintf("bundles[%d].guidelines[%d]", i, j),
+					fmt.Sprintf("bundle '%s' lists deprecated guideline '%s'%s", bundle.Name, name, replacementHint(byName[name].Deprecated)),
+				)
+			}
+		}
+	}
+	for i, g := range manifest.Guidelines {
+		if g.Deprecated != nil {
+			continue
+		}
+		for j, name := range g.Requires {
+			if deprecated(name) {
+				errors.AddWarning(
+					fmt.Sprintf("guidelines[%d].requires[%d]", i, j),
+					fmt.Sprintf("guideline '%s' requires deprecated guideline '%s'%s", g.Name, name, replacementHint(byName[name].Deprecated)),
+				)
+			}
+		}
+	}
+
+	return errors
+}
+
+// replacementHint suggests the replacement of a deprecated guideline, if it has one
+func replacementHint(deprecation *config.Deprecation) string {
+	if deprecation.Replacement == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (use '%s' instead)", deprecation.Replacement)
+}
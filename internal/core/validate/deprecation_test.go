@@ -0,0 +1,134 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestValidator_Deprecations(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"go-style", "go-conventions"} {
+		path := filepath.Join(tmpDir, "guidelines", name+".md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("# "+name), 0644))
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(m *config.Manifest)
+		wantField   string
+		wantError   string
+		wantWarning bool
+	}{
+		{
+			name: "valid deprecation and alias",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Deprecated = &config.Deprecation{Message: "superseded", Replacement: "go-conventions"}
+				m.Guidelines[1].Aliases = []string{"golang-style"}
+			},
+		},
+		{
+			name: "missing message",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Deprecated = &config.Deprecation{Replacement: "go-conventions"}
+			},
+			wantField: "guidelines[0].deprecated.message",
+			wantError: "missing required field: message",
+		},
+		{
+			name: "unknown replacement",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Deprecated = &config.Deprecation{Message: "superseded", Replacement: "go-idioms"}
+			},
+			wantField: "guidelines[0].deprecated.replacement",
+			wantError: "replacement 'go-idioms' is not a guideline in the manifest",
+		},
+		{
+			name: "deprecated replacement",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Deprecated = &config.Deprecation{Message: "superseded", Replacement: "go-conventions"}
+				m.Guidelines[1].Deprecated = &config.Deprecation{Message: "superseded"}
+			},
+			wantField: "guidelines[0].deprecated.replacement",
+			wantError: "replacement 'go-conventions' is deprecated too",
+		},
+		{
+			name: "required and deprecated",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Deprecated = &config.Deprecation{Message: "superseded"}
+				m.Guidelines[0].Required = true
+			},
+			wantField: "guidelines[0].deprecated",
+			wantError: "guideline 'go-style' is both required and deprecated",
+		},
+		{
+			name: "alias naming a guideline",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[1].Aliases = []string{"go-style"}
+			},
+			wantField: "guidelines[1].aliases[0]",
+			wantError: "alias 'go-style' is the name of a guideline",
+		},
+		{
+			name: "alias used twice",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Aliases = []string{"golang"}
+				m.Guidelines[1].Aliases = []string{"golang"}
+			},
+			wantField: "guidelines[1].aliases[0]",
+			wantError: "alias 'golang' is already an alias of 'go-style'",
+		},
+		{
+			name: "deprecated guideline in bundle",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Deprecated = &config.Deprecation{Message: "superseded", Replacement: "go-conventions"}
+				m.Bundles = []config.Bundle{{Name: "backend-go", Description: "Go services", Guidelines: []string{"go-style"}}}
+			},
+			wantField:   "bundles[0].guidelines[0]",
+			wantError:   "bundle 'backend-go' lists deprecated guideline 'go-style' (use 'go-conventions' instead)",
+			wantWarning: true,
+		},
+		{
+			name: "required by another guideline",
+			modify: func(m *config.Manifest) {
+				m.Guidelines[0].Deprecated = &config.Deprecation{Message: "superseded"}
+				m.Guidelines[1].Requires = []string{"go-style"}
+			},
+			wantField:   "guidelines[1].requires[0]",
+			wantError:   "guideline 'go-conventions' requires deprecated guideline 'go-style'",
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &config.Manifest{Version: 1}
+			for _, name := range []string{"go-style", "go-conventions"} {
+				manifest.Guidelines = append(manifest.Guidelines, config.ManifestGuideline{
+					Name:                name,
+					File:                "guidelines/" + name + ".md",
+					Description:         name,
+					ApplicableScenarios: []string{"writing Go"},
+				})
+			}
+			tt.modify(manifest)
+
+			errs := ValidateManifest(manifest, tmpDir)
+
+			if tt.wantError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantError)
+			assert.Equal(t, tt.wantWarning, errs[0].IsWarning())
+		})
+	}
+}
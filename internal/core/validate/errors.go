@@ -2,22 +2,42 @@ package validate
 
 import (
 	"fmt"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
 )
 
 // ValidationError represents a single validation error
 type ValidationError struct {
-	Field   string
-	Message string
+	File     string // Partial manifest the field is defined in, empty for the main manifest
+	Field    string
+	Message  string
+	Severity string // config.SeverityError or config.SeverityWarning; empty means error
+	Rule     string // Lint rule that reported the problem, if any
+	Line     int    // Position of the field in File, 0 when unknown
+	Column   int
 }
 
 // Error implements the error interface
 func (e ValidationError) Error() string {
 	if e.Field != "" {
-		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+		return fmt.Sprintf("%s: %s", e.Location(), e.Message)
 	}
 	return e.Message
 }
 
+// IsWarning reports whether the problem is a warning rather than an error
+func (e ValidationError) IsWarning() bool {
+	return e.Severity == config.SeverityWarning
+}
+
+// Location returns the field, prefixed with the partial manifest defining it
+func (e ValidationError) Location() string {
+	if e.File != "" {
+		return e.File + ": " + e.Field
+	}
+	return e.Field
+}
+
 // ValidationErrors represents a collection of validation errors
 type ValidationErrors []ValidationError
 
@@ -37,6 +57,33 @@ func (errs *ValidationErrors) Add(field, message string) {
 	*errs = append(*errs, ValidationError{Field: field, Message: message})
 }
 
+// AddWarning appends a new validation warning
+func (errs *ValidationErrors) AddWarning(field, message string) {
+	*errs = append(*errs, ValidationError{Field: field, Message: message, Severity: config.SeverityWarning})
+}
+
+// locate points errors at the file defining their field, which is a partial manifest for entries merged
+// from one, and at the field's line in that file
+func (errs ValidationErrors) locate(manifest *config.Manifest) ValidationErrors {
+	for i := range errs {
+		errs[i].File, errs[i].Field = manifest.Locate(errs[i].Field)
+		errs[i].Line, errs[i].Column = manifest.Position(errs[i].File, errs[i].Field)
+	}
+	return errs
+}
+
+// Partition separates warnings from errors
+func (errs ValidationErrors) Partition() (errors, warnings ValidationErrors) {
+	for _, err := range errs {
+		if err.IsWarning() {
+			warnings = append(warnings, err)
+		} else {
+			errors = append(errors, err)
+		}
+	}
+	return errors, warnings
+}
+
 // IsEmpty returns true if there are no errors
 func (errs ValidationErrors) IsEmpty() bool {
 	return len(errs) == 0
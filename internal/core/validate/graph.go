@@ -0,0 +1,41 @@
+package validate
+
+import "slices"
+
+// findCycles returns the cycles of a directed graph, each as a path starting and ending at the same node
+// Nodes are visited in the given order so results are deterministic; each cycle is reported once
+func findCycles(nodes []string, edges map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var cycles [][]string
+	state := make(map[string]int, len(nodes))
+	var path []string
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = visiting
+		path = append(path, node)
+		for _, next := range edges[node] {
+			switch state[next] {
+			case visiting:
+				start := slices.Index(path, next)
+				cycles = append(cycles, append(slices.Clone(path[start:]), next))
+			case unvisited:
+				visit(next)
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = visited
+	}
+
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/links"
+)
+
+// validateLinks checks that relative links in guideline and prompt files point at files in the repository
+func validateLinks(manifest *config.Manifest, baseDir string) ValidationErrors {
+	var errors ValidationErrors
+
+	for i, guideline := range manifest.Guidelines {
+		errors = append(errors, validateFileLinks(guideline.File, fmt.Sprintf("guidelines[%d].file", i), baseDir)...)
+	}
+	for i, prompt := range manifest.Prompts {
+		errors = append(errors, validateFileLinks(prompt.File, fmt.Sprintf("prompts[%d].file", i), baseDir)...)
+	}
+
+	return errors
+}
+
+// validateFileLinks checks the relative links of a single file
+// Unreadable files are skipped since validateFilePath already reports them
+func validateFileLinks(file, field, baseDir string) ValidationErrors {
+	var errors ValidationErrors
+
+	if file == "" || len(validateFilePath(file, field, baseDir, "")) > 0 {
+		return errors
+	}
+	content, err := os.ReadFile(filepath.Join(baseDir, file))
+	if err != nil {
+		return errors
+	}
+
+	for _, link := range links.Parse(string(content)) {
+		target, ok := links.Resolve(file, link.Path)
+		if !ok {
+			errors.Add(field, fmt.Sprintf("link to '%s' leaves the repository (line %d)", link.Target, link.Line))
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(target))); err != nil {
+			errors.Add(field, fmt.Sprintf("broken link to '%s' (line %d)", link.Target, link.Line))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestValidator_Links(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantField string
+		wantError string
+	}{
+		{
+			name:    "valid links",
+			content: "See [review](../prompts/review.md#usage), [images](img/) and [site](https://example.com).",
+		},
+		{
+			name:      "broken link",
+			content:   "# Go\n\nSee [lint](../prompts/lint.md).",
+			wantField: "guidelines[0].file",
+			wantError: "broken link to '../prompts/lint.md' (line 3)",
+		},
+		{
+			name:      "link leaving the repository",
+			content:   "See [parent](../../README.md).",
+			wantField: "guidelines[0].file",
+			wantError: "link to '../../README.md' leaves the repository (line 1)",
+		},
+		{
+			name:    "link in code block",
+			content: "```\n[lint](../prompts/lint.md)\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for file, content := range map[string]string{
+				"guidelines/go.md":     tt.content,
+				"guidelines/img/a.png": "png",
+				"prompts/review.md":    "Review",
+			} {
+				path := filepath.Join(tmpDir, filepath.FromSlash(file))
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+
+			manifest := &config.Manifest{
+				Version: 1,
+				Guidelines: []config.ManifestGuideline{
+					{Name: "go-style", File: "guidelines/go.md", Description: "Go style", ApplicableScenarios: []string{"writing Go"}},
+				},
+				Prompts: []config.ManifestPrompt{
+					{Name: "review", File: "prompts/review.md", Description: "Review code"},
+				},
+			}
+
+			errs := ValidateManifest(manifest, tmpDir)
+
+			if tt.wantError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Equal(t, tt.wantError, errs[0].Message)
+		})
+	}
+}
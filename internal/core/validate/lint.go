@@ -0,0 +1,148 @@
+package validate
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aviator5/dnaspec/internal/core/agents"
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
+	"github.com/aviator5/dnaspec/internal/core/frontmatter"
+	"github.com/aviator5/dnaspec/internal/core/markdown"
+)
+
+// LintManifest checks the content of guideline and prompt files against the lint rules of the manifest
+// Problems get the severity configured for their rule, and rules set to off aren't checked
+func LintManifest(manifest *config.Manifest, baseDir string) ValidationErrors {
+	errors := validateLintConfig(manifest.Lint)
+
+	guidelineKeys := config.EntryKeys(config.ManifestGuideline{})
+	for i, guideline := range manifest.Guidelines {
+		field := fmt.Sprintf("guidelines[%d].file", i)
+		errors = append(errors, lintFile(manifest.Lint, guideline.File, field, agents.EntryGuideline, guidelineKeys, baseDir)...)
+	}
+	promptKeys := config.EntryKeys(config.ManifestPrompt{})
+	for i, prompt := range manifest.Prompts {
+		field := fmt.Sprintf("prompts[%d].file", i)
+		errors = append(errors, lintFile(manifest.Lint, prompt.File, field, agents.EntryPrompt, promptKeys, baseDir)...)
+	}
+
+	return errors.locate(manifest)
+}
+
+// validateLintConfig checks the rule names and severities of the lint section
+func validateLintConfig(lint *config.LintConfig) ValidationErrors {
+	var errors ValidationErrors
+	if lint == nil {
+		return errors
+	}
+
+	if lint.MaxFileSizeKB < 0 {
+		errors.Add("lint.max_file_size_kb", "must be a positive number of kilobytes")
+	}
+	known := slices.Sorted(maps.Keys(config.DefaultLintSeverities))
+	for _, rule := range slices.Sorted(maps.Keys(lint.Rules)) {
+		field := "lint.rules." + rule
+		switch severity := lint.Rules[rule]; {
+		case !slices.Contains(known, rule):
+			errors.Add(field, fmt.Sprintf("unknown lint rule '%s' (known rules: %s)", rule, strings.Join(known, ", ")))
+		case severity != config.SeverityError && severity != config.SeverityWarning && severity != config.SeverityOff:
+			errors.Add(field, fmt.Sprintf("invalid severity '%s' (expected error, warning or off)", severity))
+		}
+	}
+
+	return errors
+}
+
+// lintFile checks the content of a single guideline or prompt file
+// Unreadable files are skipped since validateFilePath already reports them
+func lintFile(lint *config.LintConfig, file, field, kind string, entryKeys []string, baseDir string) ValidationErrors {
+	var errors ValidationErrors
+
+	if file == "" || len(validateFilePath(file, field, baseDir, "")) > 0 {
+		return errors
+	}
+	data, err := os.ReadFile(filepath.Join(baseDir, file))
+	if err != nil {
+		return errors
+	}
+	content := string(data)
+
+	report := func(rule, message string) {
+		if severity := lint.Severity(rule); severity != config.SeverityOff {
+			errors = append(errors, ValidationError{Field: field, Message: message, Severity: severity, Rule: rule})
+		}
+	}
+
+	if size, limit := len(data), lint.MaxFileSize(); size > limit {
+		report(config.LintOversizedFile, fmt.Sprintf("file is %d KB, over the limit of %d KB", (size+1023)/1024, limit/1024))
+	}
+
+	_, body, _ := frontmatter.Split(content)
+	bodyLine := strings.Count(content[:len(content)-len(body)], "\n") // Lines before the body
+
+	for _, key := range collidingFrontmatterKeys(content, kind, entryKeys) {
+		report(config.LintFrontmatterCollision, fmt.Sprintf(
+			"frontmatter key '%s' is ignored since generated agent files leave the frontmatter out (line %d); set it under agents in the manifest",
+			key.Value, key.Line,
+		))
+	}
+	for i, line := range strings.Split(content, "\n") {
+		for _, marker := range []string{files.ManagedBlockStart, files.ManagedBlockEnd} {
+			if strings.Contains(line, marker) {
+				report(config.LintManagedBlockMarker, fmt.Sprintf(
+					"contains the marker %s (line %d), which breaks the managed blocks of generated files", marker, i+1,
+				))
+			}
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		report(config.LintEmptyFile, "file is empty")
+		return errors
+	}
+	if kind == agents.EntryGuideline && !hasTopLevelHeading(body) {
+		report(config.LintMissingHeading, "missing top-level heading (# Title)")
+	}
+	if line := markdown.UnclosedFence(body); line > 0 {
+		report(config.LintUnclosedCodeFence, fmt.Sprintf("unclosed code fence (line %d)", bodyLine+line))
+	}
+
+	return errors
+}
+
+// collidingFrontmatterKeys returns the agent frontmatter keys a file sets in its own frontmatter, which
+// generated files leave out, so the keys have no effect
+// Keys of the manifest entry are fine: dnaspec manifest build copies them into the manifest
+func collidingFrontmatterKeys(content, kind string, entryKeys []string) []*yaml.Node {
+	fields, err := frontmatter.Parse(content)
+	if err != nil || fields == nil {
+		return nil
+	}
+
+	generated := agents.GeneratedFrontmatterKeys(kind)
+	var keys []*yaml.Node
+	for i := 0; i+1 < len(fields.Content); i += 2 {
+		key := fields.Content[i]
+		if slices.Contains(generated, key.Value) && !slices.Contains(entryKeys, key.Value) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// hasTopLevelHeading reports whether markdown content has a # heading
+func hasTopLevelHeading(content string) bool {
+	for _, h := range markdown.Headings(content) {
+		if h.Level == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// lintManifest writes a guideline and a prompt file and lints a manifest referencing them
+func lintManifest(t *testing.T, guideline, prompt string, lint *config.LintConfig) ValidationErrors {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "guidelines"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "prompts"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "guidelines", "go-style.md"), []byte(guideline), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "prompts", "review.md"), []byte(prompt), 0644))
+
+	manifest := &config.Manifest{
+		Version: 1,
+		Guidelines: []config.ManifestGuideline{
+			{Name: "go-style", File: "guidelines/go-style.md", Description: "Go style", ApplicableScenarios: []string{"Go"}},
+		},
+		Prompts: []config.ManifestPrompt{
+			{Name: "review", File: "prompts/review.md", Description: "Review"},
+		},
+		Lint: lint,
+	}
+	return LintManifest(manifest, dir)
+}
+
+func TestLintManifest(t *testing.T) {
+	tests := []struct {
+		name      string
+		guideline string
+		prompt    string
+		want      []ValidationError
+	}{
+		{
+			name:      "clean files",
+			guideline: "---\ndescription: Go style\ntags: [go]\n---\n# Go Style\n\n```go\nfunc main() {}\n```\n",
+			prompt:    "---\ndescription: Review\n---\nReview the code.\n",
+		},
+		{
+			name:      "empty files",
+			guideline: "---\ndescription: Go style\n---\n\n",
+			prompt:    "",
+			want: []ValidationError{
+				{Field: "guidelines[0].file", Message: "file is empty", Severity: "error", Rule: "empty-file"},
+				{Field: "prompts[0].file", Message: "file is empty", Severity: "error", Rule: "empty-file"},
+			},
+		},
+		{
+			name:      "missing heading",
+			guideline: "Use gofmt.\n\n## Naming\n",
+			prompt:    "Review the code.\n",
+			want: []ValidationError{
+				{Field: "guidelines[0].file", Message: "missing top-level heading (# Title)", Severity: "warning", Rule: "missing-heading"},
+			},
+		},
+		{
+			name:      "unclosed code fence",
+			guideline: "---\ndescription: Go style\n---\n# Go Style\n\n```go\nfunc main() {}\n",
+			prompt:    "Review the code.\n",
+			want: []ValidationError{
+				{Field: "guidelines[0].file", Message: "unclosed code fence (line 6)", Severity: "error", Rule: "unclosed-code-fence"},
+			},
+		},
+		{
+			name:      "colliding frontmatter",
+			guideline: "---\napplyTo: \"**/*.go\"\n---\n# Go Style\n",
+			prompt:    "---\ndescription: Review\nmodel: fast\ntags: [review]\n---\nReview the code.\n",
+			want: []ValidationError{
+				{
+					Field:    "guidelines[0].file",
+					Message:  "frontmatter key 'applyTo' is ignored since generated agent files leave the frontmatter out (line 2); set it under agents in the manifest",
+					Severity: "warning",
+					Rule:     "frontmatter-collision",
+				},
+				{
+					Field:    "prompts[0].file",
+					Message:  "frontmatter key 'model' is ignored since generated agent files leave the frontmatter out (line 3); set it under agents in the manifest",
+					Severity: "warning",
+					Rule:     "frontmatter-collision",
+				},
+				{
+					Field:    "prompts[0].file",
+					Message:  "frontmatter key 'tags' is ignored since generated agent files leave the frontmatter out (line 4); set it under agents in the manifest",
+					Severity: "warning",
+					Rule:     "frontmatter-collision",
+				},
+			},
+		},
+		{
+			name:      "managed block markers",
+			guideline: "# Go Style\n",
+			prompt:    "Review the code.\n\n<!-- DNASPEC:START -->\n",
+			want: []ValidationError{
+				{
+					Field:    "prompts[0].file",
+					Message:  "contains the marker <!-- DNASPEC:START --> (line 3), which breaks the managed blocks of generated files",
+					Severity: "error",
+					Rule:     "managed-block-marker",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := lintManifest(t, tt.guideline, tt.prompt, nil)
+			assert.Equal(t, ValidationErrors(tt.want), errors)
+		})
+	}
+}
+
+func TestLintManifest_OversizedFile(t *testing.T) {
+	guideline := "# Go Style\n\n" + strings.Repeat("Use gofmt.\n", 200) // Over 2 KB
+
+	errors := lintManifest(t, guideline, "Review.\n", &config.LintConfig{MaxFileSizeKB: 1})
+	assert.Equal(t, ValidationErrors{
+		{Field: "guidelines[0].file", Message: "file is 3 KB, over the limit of 1 KB", Severity: "warning", Rule: "oversized-file"},
+	}, errors)
+
+	errors = lintManifest(t, guideline, "Review.\n", nil)
+	assert.Empty(t, errors, "default limit is higher")
+}
+
+func TestLintManifest_ConfiguredSeverity(t *testing.T) {
+	lint := &config.LintConfig{Rules: map[string]string{
+		config.LintMissingHeading: config.SeverityError,
+		config.LintEmptyFile:      config.SeverityOff,
+	}}
+
+	errors := lintManifest(t, "Use gofmt.\n", "", lint)
+	assert.Equal(t, ValidationErrors{
+		{Field: "guidelines[0].file", Message: "missing top-level heading (# Title)", Severity: "error", Rule: "missing-heading"},
+	}, errors)
+
+	failures, warnings := errors.Partition()
+	assert.Len(t, failures, 1)
+	assert.Empty(t, warnings)
+}
+
+func TestLintManifest_InvalidConfig(t *testing.T) {
+	lint := &config.LintConfig{
+		MaxFileSizeKB: -1,
+		Rules: map[string]string{
+			"line-length":            "error",
+			config.LintOversizedFile: "fatal",
+		},
+	}
+
+	errors := lintManifest(t, "# Go Style\n", "Review.\n", lint)
+	require.Len(t, errors, 3)
+	assert.Equal(t, "lint.max_file_size_kb", errors[0].Field)
+	assert.Equal(t, "lint.rules.line-length", errors[1].Field)
+	assert.Contains(t, errors[1].Message, "unknown lint rule 'line-length' (known rules: empty-file, frontmatter-collision")
+	assert.Equal(t, "lint.rules.oversized-file", errors[2].Field)
+	assert.Equal(t, "invalid severity 'fatal' (expected error, warning or off)", errors[2].Message)
+}
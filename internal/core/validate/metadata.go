@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// validateMetadata validates the optional tags, owners and last_reviewed fields of a guideline
+func validateMetadata(g config.ManifestGuideline, prefix string) ValidationErrors {
+	var errors ValidationErrors
+
+	seenTags := make(map[string]bool)
+	for i, tag := range g.Tags {
+		field := fmt.Sprintf("%s.tags[%d]", prefix, i)
+		switch {
+		case !spinalCaseRegex.MatchString(tag):
+			errors.Add(field, fmt.Sprintf("invalid tag format: '%s' (expected spinal-case: lowercase letters and hyphens only)", tag))
+		case seenTags[tag]:
+			errors.Add(field, fmt.Sprintf("duplicate tag: %s", tag))
+		}
+		seenTags[tag] = true
+	}
+
+	for i, owner := range g.Owners {
+		if strings.TrimSpace(owner) == "" {
+			errors.Add(fmt.Sprintf("%s.owners[%d]", prefix, i), "owner must not be empty")
+		}
+	}
+
+	if g.LastReviewed != "" {
+		if _, err := time.Parse(time.DateOnly, g.LastReviewed); err != nil {
+			errors.Add(prefix+".last_reviewed", fmt.Sprintf("invalid date: '%s' (expected YYYY-MM-DD)", g.LastReviewed))
+		}
+	}
+
+	return errors
+}
+
+// ValidateReviewAge flags guidelines that haven't been reviewed within maxAgeDays of now
+// Guidelines without a valid last_reviewed date are flagged too
+func ValidateReviewAge(manifest *config.Manifest, maxAgeDays int, now time.Time) ValidationErrors {
+	var errors ValidationErrors
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	for i, g := range manifest.Guidelines {
+		field := fmt.Sprintf("guidelines[%d].last_reviewed", i)
+		if g.LastReviewed == "" {
+			errors.Add(field, fmt.Sprintf("guideline '%s' has no last_reviewed date", g.Name))
+			continue
+		}
+		reviewed, err := time.Parse(time.DateOnly, g.LastReviewed)
+		if err != nil {
+			continue // Reported by ValidateManifest
+		}
+		if age := int(today.Sub(reviewed).Hours() / 24); age > maxAgeDays {
+			errors.Add(field, fmt.Sprintf(
+				"guideline '%s' was last reviewed %d days ago, on %s (max %d days)", g.Name, age, g.LastReviewed, maxAgeDays,
+			))
+		}
+	}
+
+	return errors.locate(manifest)
+}
@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestValidator_Metadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	guidelinePath := filepath.Join(tmpDir, "guidelines", "go-style.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(guidelinePath), 0755))
+	require.NoError(t, os.WriteFile(guidelinePath, []byte("# Go style"), 0644))
+
+	tests := []struct {
+		name      string
+		modify    func(g *config.ManifestGuideline)
+		wantField string
+		wantError string
+	}{
+		{
+			name: "valid metadata",
+			modify: func(g *config.ManifestGuideline) {
+				g.Tags = []string{"go", "backend"}
+				g.Owners = []string{"@platform-team"}
+				g.Version = "1.2"
+				g.LastReviewed = "2026-03-01"
+			},
+		},
+		{
+			name:      "invalid tag",
+			modify:    func(g *config.ManifestGuideline) { g.Tags = []string{"Go"} },
+			wantField: "guidelines[0].tags[0]",
+			wantError: "invalid tag format: 'Go'",
+		},
+		{
+			name:      "duplicate tag",
+			modify:    func(g *config.ManifestGuideline) { g.Tags = []string{"go", "go"} },
+			wantField: "guidelines[0].tags[1]",
+			wantError: "duplicate tag: go",
+		},
+		{
+			name:      "empty owner",
+			modify:    func(g *config.ManifestGuideline) { g.Owners = []string{" "} },
+			wantField: "guidelines[0].owners[0]",
+			wantError: "owner must not be empty",
+		},
+		{
+			name:      "invalid review date",
+			modify:    func(g *config.ManifestGuideline) { g.LastReviewed = "03/01/2026" },
+			wantField: "guidelines[0].last_reviewed",
+			wantError: "invalid date: '03/01/2026' (expected YYYY-MM-DD)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &config.Manifest{
+				Version: 1,
+				Guidelines: []config.ManifestGuideline{
+					{Name: "go-style", File: "guidelines/go-style.md", Description: "Go style", ApplicableScenarios: []string{"writing Go"}},
+				},
+			}
+			tt.modify(&manifest.Guidelines[0])
+
+			errs := ValidateManifest(manifest, tmpDir)
+
+			if tt.wantError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantError)
+		})
+	}
+}
+
+func TestValidateReviewAge(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	manifest := &config.Manifest{
+		Guidelines: []config.ManifestGuideline{
+			{Name: "go-style", LastReviewed: "2026-09-18"},
+			{Name: "rest-api", LastReviewed: "2026-04-18"},
+			{Name: "sql"},
+			{Name: "grpc", LastReviewed: "not a date"},
+		},
+	}
+
+	errs := ValidateReviewAge(manifest, 90, now)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "guidelines[1].last_reviewed", errs[0].Field)
+	assert.Equal(t, "guideline 'rest-api' was last reviewed 183 days ago, on 2026-04-18 (max 90 days)", errs[0].Message)
+	assert.Equal(t, "guidelines[2].last_reviewed", errs[1].Field)
+	assert.Equal(t, "guideline 'sql' has no last_reviewed date", errs[1].Message)
+
+	assert.Len(t, ValidateReviewAge(manifest, 365, now), 1)
+}
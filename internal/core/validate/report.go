@@ -0,0 +1,195 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// Output formats of validation results
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatText, FormatJSON, FormatSARIF}
+
+// genericRule is the SARIF rule of problems that no lint rule reported
+const genericRule = "validation"
+
+// jsonReport is the JSON output of validation results
+type jsonReport struct {
+	Valid    bool          `json:"valid"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Problems []jsonProblem `json:"problems"`
+}
+
+// jsonProblem is a validation error or warning in the JSON output
+type jsonProblem struct {
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Rule     string `json:"rule,omitempty"`
+	Message  string `json:"message"`
+}
+
+// WriteReport writes validation results in a machine-readable format (json or sarif)
+// Problems without a file are reported against defaultFile, the file that was validated
+func WriteReport(w io.Writer, format string, errs ValidationErrors, defaultFile string) error {
+	var report any
+	switch format {
+	case FormatJSON:
+		report = newJSONReport(errs, defaultFile)
+	case FormatSARIF:
+		report = newSARIFLog(errs, defaultFile)
+	default:
+		return fmt.Errorf("unsupported format '%s'", format)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// severity returns the severity of a problem, defaulting to error
+func (e ValidationError) severity() string {
+	if e.IsWarning() {
+		return config.SeverityWarning
+	}
+	return config.SeverityError
+}
+
+func newJSONReport(errs ValidationErrors, defaultFile string) jsonReport {
+	failures, warnings := errs.Partition()
+	report := jsonReport{
+		Valid:    len(failures) == 0,
+		Errors:   len(failures),
+		Warnings: len(warnings),
+		Problems: []jsonProblem{},
+	}
+	for _, e := range errs {
+		report.Problems = append(report.Problems, jsonProblem{
+			Severity: e.severity(),
+			File:     fileOrDefault(e.File, defaultFile),
+			Line:     e.Line,
+			Column:   e.Column,
+			Field:    e.Field,
+			Rule:     e.Rule,
+			Message:  e.Message,
+		})
+	}
+	return report
+}
+
+// SARIF 2.1.0 log, limited to the properties code scanning tools use
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID string `json:"id"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+func newSARIFLog(errs ValidationErrors, defaultFile string) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "dnaspec",
+			InformationURI: "https://github.com/aviator5/dnaspec",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	var ruleIDs []string
+	for _, e := range errs {
+		ruleID := e.Rule
+		if ruleID == "" {
+			ruleID = genericRule
+		}
+		if !slices.Contains(ruleIDs, ruleID) {
+			ruleIDs = append(ruleIDs, ruleID)
+		}
+
+		text := e.Message
+		if e.Field != "" {
+			text = e.Field + ": " + e.Message
+		}
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: fileOrDefault(e.File, defaultFile), URIBaseID: "%SRCROOT%"},
+		}
+		if e.Line > 0 {
+			location.Region = &sarifRegion{StartLine: e.Line, StartColumn: e.Column}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    ruleID,
+			Level:     e.severity(),
+			Message:   sarifMessage{Text: text},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+	for _, id := range ruleIDs {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
+
+// fileOrDefault returns file, or defaultFile when file is empty
+func fileOrDefault(file, defaultFile string) string {
+	if file == "" {
+		return defaultFile
+	}
+	return file
+}
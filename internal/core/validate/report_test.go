@@ -0,0 +1,85 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// reportProblems are an error located in a partial manifest and a lint warning in the main manifest
+var reportProblems = ValidationErrors{
+	{File: "manifests/go.yaml", Field: "guidelines[0].file", Message: "file not found", Line: 3, Column: 5},
+	{Field: "guidelines[1].file", Message: "file is empty", Severity: config.SeverityWarning, Rule: config.LintEmptyFile, Line: 8, Column: 5},
+	{Message: "manifest has no entries"},
+}
+
+func TestWriteReport_JSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteReport(&out, FormatJSON, reportProblems, "dnaspec-manifest.yaml"))
+
+	var report jsonReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.False(t, report.Valid)
+	assert.Equal(t, 2, report.Errors)
+	assert.Equal(t, 1, report.Warnings)
+	assert.Equal(t, []jsonProblem{
+		{Severity: "error", File: "manifests/go.yaml", Line: 3, Column: 5, Field: "guidelines[0].file", Message: "file not found"},
+		{
+			Severity: "warning", File: "dnaspec-manifest.yaml", Line: 8, Column: 5,
+			Field: "guidelines[1].file", Rule: config.LintEmptyFile, Message: "file is empty",
+		},
+		{Severity: "error", File: "dnaspec-manifest.yaml", Message: "manifest has no entries"},
+	}, report.Problems)
+}
+
+func TestWriteReport_JSONValid(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteReport(&out, FormatJSON, nil, "dnaspec-manifest.yaml"))
+
+	var report jsonReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.True(t, report.Valid)
+	// Problems is an empty list rather than null for consumers
+	assert.Contains(t, out.String(), `"problems": []`)
+}
+
+func TestWriteReport_SARIF(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteReport(&out, FormatSARIF, reportProblems, "dnaspec-manifest.yaml"))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	assert.Equal(t, "dnaspec", run.Tool.Driver.Name)
+	assert.Equal(t, []sarifRule{{ID: "validation"}, {ID: config.LintEmptyFile}}, run.Tool.Driver.Rules)
+	require.Len(t, run.Results, 3)
+
+	first := run.Results[0]
+	assert.Equal(t, "validation", first.RuleID)
+	assert.Equal(t, "error", first.Level)
+	assert.Equal(t, "guidelines[0].file: file not found", first.Message.Text)
+	assert.Equal(t, "manifests/go.yaml", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, &sarifRegion{StartLine: 3, StartColumn: 5}, first.Locations[0].PhysicalLocation.Region)
+
+	assert.Equal(t, config.LintEmptyFile, run.Results[1].RuleID)
+	assert.Equal(t, "warning", run.Results[1].Level)
+
+	// Problems without a position are reported against the whole file
+	last := run.Results[2].Locations[0].PhysicalLocation
+	assert.Equal(t, "dnaspec-manifest.yaml", last.ArtifactLocation.URI)
+	assert.Nil(t, last.Region)
+}
+
+func TestWriteReport_UnsupportedFormat(t *testing.T) {
+	err := WriteReport(&bytes.Buffer{}, FormatText, nil, "dnaspec-manifest.yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported format 'text'")
+}
@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+// validateRequires validates the requires lists of guidelines: targets must exist and must not form cycles
+func validateRequires(manifest *config.Manifest) ValidationErrors {
+	var errors ValidationErrors
+
+	guidelineIndex := make(map[string]int, len(manifest.Guidelines))
+	guidelineNames := make([]string, 0, len(manifest.Guidelines))
+	for i, guideline := range manifest.Guidelines {
+		if _, exists := guidelineIndex[guideline.Name]; !exists {
+			guidelineIndex[guideline.Name] = i
+			guidelineNames = append(guidelineNames, guideline.Name)
+		}
+	}
+
+	requires := make(map[string][]string, len(manifest.Guidelines))
+	for i, guideline := range manifest.Guidelines {
+		for j, required := range guideline.Requires {
+			if _, exists := guidelineIndex[required]; !exists {
+				errors.Add(
+					fmt.Sprintf("guidelines[%d].requires[%d]", i, j),
+					fmt.Sprintf("guideline '%s' requires non-existent guideline '%s'", guideline.Name, required),
+				)
+				continue
+			}
+			requires[guideline.Name] = append(requires[guideline.Name], required)
+		}
+	}
+
+	for _, cycle := range findCycles(guidelineNames, requires) {
+		field := fmt.Sprintf("guidelines[%d].requires", guidelineIndex[cycle[0]])
+		errors.Add(field, fmt.Sprintf("requires cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
+	return errors
+}
@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestValidator_Requires(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	guidelinePath := "guidelines/test.md"
+	fullPath := filepath.Join(tmpDir, guidelinePath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+	require.NoError(t, os.WriteFile(fullPath, []byte("test"), 0644))
+
+	guideline := func(name string, requires ...string) config.ManifestGuideline {
+		return config.ManifestGuideline{
+			Name:                name,
+			File:                guidelinePath,
+			Description:         "Test guideline",
+			ApplicableScenarios: []string{"testing"},
+			Requires:            requires,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		guidelines []config.ManifestGuideline
+		wantField  string
+		wantError  string
+	}{
+		{
+			name:       "valid dependencies",
+			guidelines: []config.ManifestGuideline{guideline("rest-api", "error-handling"), guideline("error-handling")},
+		},
+		{
+			name:       "unknown guideline",
+			guidelines: []config.ManifestGuideline{guideline("rest-api", "error-handling")},
+			wantField:  "guidelines[0].requires[0]",
+			wantError:  "guideline 'rest-api' requires non-existent guideline 'error-handling'",
+		},
+		{
+			name:       "requires itself",
+			guidelines: []config.ManifestGuideline{guideline("rest-api", "rest-api")},
+			wantField:  "guidelines[0].requires",
+			wantError:  "requires cycle: rest-api -> rest-api",
+		},
+		{
+			name: "cycle",
+			guidelines: []config.ManifestGuideline{
+				guideline("go-style"),
+				guideline("rest-api", "error-handling"),
+				guideline("error-handling", "logging"),
+				guideline("logging", "rest-api", "go-style"),
+			},
+			wantField: "guidelines[1].requires",
+			wantError: "requires cycle: rest-api -> error-handling -> logging -> rest-api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &config.Manifest{Version: 1, Guidelines: tt.guidelines}
+
+			errs := ValidateManifest(manifest, tmpDir)
+
+			if tt.wantError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantError)
+		})
+	}
+}
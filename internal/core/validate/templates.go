@@ -0,0 +1,196 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/render"
+)
+
+// Kinds of template files, used in error messages
+const (
+	kindGuideline = "guideline"
+	kindPrompt    = "prompt"
+)
+
+// validateVariables validates the template variable declarations of a manifest
+func validateVariables(variables []config.Variable) ValidationErrors {
+	var errors ValidationErrors
+
+	seenNames := make(map[string]bool)
+	for i, variable := range variables {
+		field := fmt.Sprintf("variables[%d].name", i)
+		switch {
+		case variable.Name == "":
+			errors.Add(field, "missing required field: name")
+		case !render.ValidVariableName(variable.Name):
+			errors.Add(field, fmt.Sprintf(
+				"invalid variable name: '%s' (expected letters, digits and underscores, usable as {{.%s}})",
+				variable.Name, variable.Name,
+			))
+		case seenNames[variable.Name]:
+			errors.Add(field, fmt.Sprintf("duplicate variable name: %s", variable.Name))
+		}
+		seenNames[variable.Name] = true
+	}
+
+	return errors
+}
+
+// validatePromptArguments validates the argument declarations of a prompt
+// Required arguments must come first since some agents pass arguments by position
+func validatePromptArguments(arguments []config.PromptArgument, prefix string) ValidationErrors {
+	var errors ValidationErrors
+
+	seenNames := make(map[string]bool)
+	optional := ""
+	for i, argument := range arguments {
+		field := fmt.Sprintf("%s.arguments[%d]", prefix, i)
+		switch {
+		case argument.Name == "":
+			errors.Add(field+".name", "missing required field: name")
+		case !render.ValidVariableName(argument.Name):
+			errors.Add(field+".name", fmt.Sprintf(
+				"invalid argument name: '%s' (expected letters, digits and underscores)", argument.Name,
+			))
+		case seenNames[argument.Name]:
+			errors.Add(field+".name", fmt.Sprintf("duplicate argument name: %s", argument.Name))
+		}
+		seenNames[argument.Name] = true
+
+		if !argument.Required && optional == "" {
+			optional = argument.Name
+		} else if argument.Required && optional != "" {
+			errors.Add(field+".required", fmt.Sprintf(
+				"required argument '%s' must come before optional argument '%s'", argument.Name, optional,
+			))
+		}
+	}
+
+	return errors
+}
+
+// validateTemplates checks that the guideline and prompt files marked as templates are valid templates
+// using only declared variables and including existing guidelines without cycles
+func validateTemplates(manifest *config.Manifest, baseDir string) ValidationErrors {
+	var errors ValidationErrors
+
+	declared := make(map[string]bool, len(manifest.Variables))
+	for _, variable := range manifest.Variables {
+		declared[variable.Name] = true
+	}
+	guidelineIndex := make(map[string]int, len(manifest.Guidelines))
+	guidelineNames := make([]string, 0, len(manifest.Guidelines))
+	for i, guideline := range manifest.Guidelines {
+		if _, exists := guidelineIndex[guideline.Name]; !exists {
+			guidelineIndex[guideline.Name] = i
+			guidelineNames = append(guidelineNames, guideline.Name)
+		}
+	}
+
+	check := func(file, field, kind, name string, arguments []config.PromptArgument) []string {
+		refs, fileErrors := templateReferences(file, field, kind, name, baseDir, declared)
+		errors = append(errors, fileErrors...)
+		if refs == nil {
+			return nil
+		}
+		for _, argument := range refs.Arguments {
+			switch {
+			case kind == kindGuideline:
+				errors.Add(field, fmt.Sprintf("guideline '%s' uses argument '%s' (arguments are only available in prompts)", name, argument))
+			case !slices.ContainsFunc(arguments, func(a config.PromptArgument) bool { return a.Name == argument }):
+				errors.Add(field, fmt.Sprintf("prompt '%s' uses undeclared argument '%s' (declare it under arguments)", name, argument))
+			}
+		}
+		for _, target := range refs.Includes {
+			if _, exists := guidelineIndex[target]; !exists {
+				errors.Add(field, fmt.Sprintf("%s '%s' includes unknown guideline '%s'", kind, name, target))
+			}
+		}
+		return refs.Includes
+	}
+
+	includes := make(map[string][]string, len(manifest.Guidelines))
+	for i, guideline := range manifest.Guidelines {
+		field := fmt.Sprintf("guidelines[%d].file", i)
+		if !guideline.Template {
+			errors = append(errors, literalIncludes(guideline.File, field, kindGuideline, guideline.Name, baseDir)...)
+			continue
+		}
+		includes[guideline.Name] = append(includes[guideline.Name], check(guideline.File, field, kindGuideline, guideline.Name, nil)...)
+	}
+	for i, prompt := range manifest.Prompts {
+		field := fmt.Sprintf("prompts[%d].file", i)
+		if !prompt.Template {
+			errors = append(errors, literalIncludes(prompt.File, field, kindPrompt, prompt.Name, baseDir)...)
+			continue
+		}
+		check(prompt.File, field, kindPrompt, prompt.Name, prompt.Arguments)
+	}
+
+	for _, cycle := range findCycles(guidelineNames, includes) {
+		field := fmt.Sprintf("guidelines[%d].file", guidelineIndex[cycle[0]])
+		errors.Add(field, fmt.Sprintf("include cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
+	return errors
+}
+
+// literalIncludes warns about include calls in a file that isn't marked as a template,
+// since they are written out literally instead of being rendered
+func literalIncludes(file, field, kind, name, baseDir string) ValidationErrors {
+	var errors ValidationErrors
+
+	if file == "" || len(validateFilePath(file, field, baseDir, "")) > 0 {
+		return errors
+	}
+	content, err := os.ReadFile(filepath.Join(baseDir, file))
+	if err != nil {
+		return errors
+	}
+
+	if strings.Contains(string(content), "{{include") {
+		errors.AddWarning(field, fmt.Sprintf(
+			"%s '%s' uses include but isn't a template, so it's written out literally (set template: true)", kind, name,
+		))
+	}
+
+	return errors
+}
+
+// templateReferences parses a template file and checks the variables it uses are declared
+// Unreadable files are skipped since validateFilePath already reports them
+func templateReferences(
+	file, field, kind, name, baseDir string,
+	declared map[string]bool,
+) (*render.References, ValidationErrors) {
+	var errors ValidationErrors
+
+	if file == "" || len(validateFilePath(file, field, baseDir, "")) > 0 {
+		return nil, errors
+	}
+	content, err := os.ReadFile(filepath.Join(baseDir, file))
+	if err != nil {
+		return nil, errors
+	}
+
+	refs, err := render.ParseReferences(file, string(content))
+	if err != nil {
+		errors.Add(field, fmt.Sprintf("%s '%s' has an %v", kind, name, err))
+		return nil, errors
+	}
+
+	for _, variable := range refs.Variables {
+		if !declared[variable] {
+			errors.Add(field, fmt.Sprintf(
+				"%s '%s' uses undeclared variable '%s' (declare it under variables)", kind, name, variable,
+			))
+		}
+	}
+
+	return refs, errors
+}
@@ -0,0 +1,245 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aviator5/dnaspec/internal/core/config"
+)
+
+func TestValidator_Templates(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"guidelines/go-style.md":  "# Go Style\n\nModule: {{.module_path}}",
+		"guidelines/rest-api.md":  "# REST API\n\nService: {{.service_name}}",
+		"guidelines/broken.md":    "# Broken\n\n{{.module_path",
+		"prompts/code-review.md":  "Run `{{.test_command}}` and review {{args}}.",
+		"prompts/plain-review.md": "Review the code.",
+		"prompts/go-review.md":    "Review against:\n\n{{include \"go-style\"}}",
+		"guidelines/loop-a.md":    "{{include \"loop-b\"}}",
+		"guidelines/uses-arg.md":  "Ticket {{arg \"ticket\"}}",
+		"prompts/fix-ticket.md":   "Fix {{arg \"ticket\"}}.",
+		"guidelines/loop-b.md":    "{{include \"loop-a\"}}",
+		"guidelines/ci.md":        "# CI\n\nUse `${{ secrets.TOKEN }}`.",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	guideline := func(name, file string) config.ManifestGuideline {
+		return config.ManifestGuideline{
+			Name:                name,
+			File:                file,
+			Description:         "Description",
+			ApplicableScenarios: []string{"Scenario"},
+			Template:            true,
+		}
+	}
+	prompt := func(name, file string) config.ManifestPrompt {
+		return config.ManifestPrompt{Name: name, File: file, Description: "Description", Template: true}
+	}
+
+	withArguments := func(p config.ManifestPrompt, arguments ...config.PromptArgument) config.ManifestPrompt {
+		p.Arguments = arguments
+		return p
+	}
+
+	tests := []struct {
+		name      string
+		manifest  *config.Manifest
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name: "declared variables",
+			manifest: &config.Manifest{
+				Version: 1,
+				Variables: []config.Variable{
+					{Name: "module_path", Default: "example.com/module"},
+					{Name: "test_command"},
+				},
+				Guidelines: []config.ManifestGuideline{guideline("go-style", "guidelines/go-style.md")},
+				Prompts: []config.ManifestPrompt{
+					prompt("code-review", "prompts/code-review.md"),
+					prompt("plain-review", "prompts/plain-review.md"),
+				},
+			},
+		},
+		{
+			name: "undeclared variable",
+			manifest: &config.Manifest{
+				Version:    1,
+				Variables:  []config.Variable{{Name: "module_path"}},
+				Guidelines: []config.ManifestGuideline{guideline("rest-api", "guidelines/rest-api.md")},
+			},
+			wantField: "guidelines[0].file",
+			wantMsg:   "uses undeclared variable 'service_name'",
+		},
+		{
+			name: "invalid template",
+			manifest: &config.Manifest{
+				Version:    1,
+				Variables:  []config.Variable{{Name: "module_path"}},
+				Guidelines: []config.ManifestGuideline{guideline("broken", "guidelines/broken.md")},
+			},
+			wantField: "guidelines[0].file",
+			wantMsg:   "invalid template",
+		},
+		{
+			name: "include",
+			manifest: &config.Manifest{
+				Version:    1,
+				Variables:  []config.Variable{{Name: "module_path"}},
+				Guidelines: []config.ManifestGuideline{guideline("go-style", "guidelines/go-style.md")},
+				Prompts:    []config.ManifestPrompt{prompt("go-review", "prompts/go-review.md")},
+			},
+		},
+		{
+			name: "include of unknown guideline",
+			manifest: &config.Manifest{
+				Version: 1,
+				Prompts: []config.ManifestPrompt{prompt("go-review", "prompts/go-review.md")},
+			},
+			wantField: "prompts[0].file",
+			wantMsg:   "prompt 'go-review' includes unknown guideline 'go-style'",
+		},
+		{
+			name: "include cycle",
+			manifest: &config.Manifest{
+				Version: 1,
+				Guidelines: []config.ManifestGuideline{
+					guideline("loop-a", "guidelines/loop-a.md"),
+					guideline("loop-b", "guidelines/loop-b.md"),
+				},
+			},
+			wantField: "guidelines[0].file",
+			wantMsg:   "include cycle: loop-a -> loop-b -> loop-a",
+		},
+		{
+			name: "not a template",
+			manifest: &config.Manifest{
+				Version: 1,
+				Guidelines: []config.ManifestGuideline{{
+					Name: "ci", File: "guidelines/ci.md", Description: "Description", ApplicableScenarios: []string{"Scenario"},
+				}},
+			},
+		},
+		{
+			name: "include in a prompt that isn't a template",
+			manifest: &config.Manifest{
+				Version:    1,
+				Guidelines: []config.ManifestGuideline{guideline("go-style", "guidelines/go-style.md")},
+				Prompts:    []config.ManifestPrompt{{Name: "go-review", File: "prompts/go-review.md", Description: "Description"}},
+				Variables:  []config.Variable{{Name: "module_path"}},
+			},
+			wantField: "prompts[0].file",
+			wantMsg:   "prompt 'go-review' uses include but isn't a template, so it's written out literally",
+		},
+		{
+			name: "prompt arguments",
+			manifest: &config.Manifest{
+				Version: 1,
+				Prompts: []config.ManifestPrompt{withArguments(prompt("fix-ticket", "prompts/fix-ticket.md"),
+					config.PromptArgument{Name: "ticket", Required: true},
+					config.PromptArgument{Name: "scope"},
+				)},
+			},
+		},
+		{
+			name: "undeclared prompt argument",
+			manifest: &config.Manifest{
+				Version: 1,
+				Prompts: []config.ManifestPrompt{prompt("fix-ticket", "prompts/fix-ticket.md")},
+			},
+			wantField: "prompts[0].file",
+			wantMsg:   "prompt 'fix-ticket' uses undeclared argument 'ticket'",
+		},
+		{
+			name: "argument in guideline",
+			manifest: &config.Manifest{
+				Version:    1,
+				Guidelines: []config.ManifestGuideline{guideline("uses-arg", "guidelines/uses-arg.md")},
+			},
+			wantField: "guidelines[0].file",
+			wantMsg:   "arguments are only available in prompts",
+		},
+		{
+			name: "required argument after optional",
+			manifest: &config.Manifest{
+				Version: 1,
+				Prompts: []config.ManifestPrompt{withArguments(prompt("fix-ticket", "prompts/fix-ticket.md"),
+					config.PromptArgument{Name: "scope"},
+					config.PromptArgument{Name: "ticket", Required: true},
+				)},
+			},
+			wantField: "prompts[0].arguments[1].required",
+			wantMsg:   "required argument 'ticket' must come before optional argument 'scope'",
+		},
+		{
+			name: "invalid argument name",
+			manifest: &config.Manifest{
+				Version: 1,
+				Prompts: []config.ManifestPrompt{withArguments(prompt("plain-review", "prompts/plain-review.md"),
+					config.PromptArgument{Name: "ticket-id"},
+				)},
+			},
+			wantField: "prompts[0].arguments[0].name",
+			wantMsg:   "invalid argument name",
+		},
+		{
+			name: "invalid variable name",
+			manifest: &config.Manifest{
+				Version:   1,
+				Variables: []config.Variable{{Name: "module-path"}},
+				Prompts:   []config.ManifestPrompt{prompt("plain-review", "prompts/plain-review.md")},
+			},
+			wantField: "variables[0].name",
+			wantMsg:   "invalid variable name",
+		},
+		{
+			name: "duplicate variable name",
+			manifest: &config.Manifest{
+				Version:   1,
+				Variables: []config.Variable{{Name: "module_path"}, {Name: "module_path"}},
+				Prompts:   []config.ManifestPrompt{prompt("plain-review", "prompts/plain-review.md")},
+			},
+			wantField: "variables[1].name",
+			wantMsg:   "duplicate variable name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateManifest(tt.manifest, tmpDir)
+			if tt.wantMsg == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1, errs.Error())
+			assert.Equal(t, tt.wantField, errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantMsg)
+		})
+	}
+}
+
+func TestFindCycles(t *testing.T) {
+	edges := map[string][]string{
+		"a": {"b"},
+		"b": {"c", "d"},
+		"c": {"a"},
+		"d": {"d"},
+		"e": {"a"},
+	}
+
+	cycles := findCycles([]string{"a", "b", "c", "d", "e"}, edges)
+	assert.Equal(t, [][]string{{"a", "b", "c", "a"}, {"d", "d"}}, cycles)
+
+	assert.Empty(t, findCycles([]string{"a", "b"}, map[string][]string{"a": {"b"}}))
+}
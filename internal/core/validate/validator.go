@@ -2,17 +2,27 @@ package validate
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/aviator5/dnaspec/internal/core/agents"
 	"github.com/aviator5/dnaspec/internal/core/config"
+	"github.com/aviator5/dnaspec/internal/core/files"
 )
 
 // spinalCaseRegex matches valid spinal-case names (lowercase letters and hyphens)
 var spinalCaseRegex = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
 
+// IsSpinalCase reports whether a name uses spinal-case, as guideline, prompt and bundle names must
+func IsSpinalCase(name string) bool {
+	return spinalCaseRegex.MatchString(name)
+}
+
 // ValidateManifest validates a manifest and returns all validation errors
 func ValidateManifest(manifest *config.Manifest, baseDir string) ValidationErrors {
 	var errors ValidationErrors
@@ -22,11 +32,12 @@ func ValidateManifest(manifest *config.Manifest, baseDir string) ValidationError
 		errors.Add("version", "missing required field: version")
 	}
 
-	// Validate guidelines
+	// Validate guidelines, matching the asset patterns of all guidelines in one walk of the repository
+	matchedAssets := matchAssetPatterns(manifest.Guidelines, baseDir)
 	guidelineNames := make(map[string]bool)
 	for i, guideline := range manifest.Guidelines {
 		prefix := fmt.Sprintf("guidelines[%d]", i)
-		errors = append(errors, validateGuideline(guideline, prefix, baseDir, guidelineNames)...)
+		errors = append(errors, validateGuideline(guideline, prefix, baseDir, guidelineNames, matchedAssets)...)
 	}
 
 	// Validate prompts
@@ -36,6 +47,22 @@ func ValidateManifest(manifest *config.Manifest, baseDir string) ValidationError
 		errors = append(errors, validatePrompt(prompt, prefix, baseDir, promptNames)...)
 	}
 
+	// Validate template variables and their use in guideline and prompt files
+	errors = append(errors, validateVariables(manifest.Variables)...)
+	errors = append(errors, validateTemplates(manifest, baseDir)...)
+
+	// Validate relative links in guideline and prompt files
+	errors = append(errors, validateLinks(manifest, baseDir)...)
+
+	// Validate guideline dependencies (targets exist, no cycles)
+	errors = append(errors, validateRequires(manifest)...)
+
+	// Validate bundles (names and guideline references)
+	errors = append(errors, validateBundles(manifest)...)
+
+	// Validate aliases and deprecations, flagging deprecated guidelines still in use
+	errors = append(errors, validateDeprecations(manifest)...)
+
 	// Validate cross-references (guideline prompts must exist)
 	for i, guideline := range manifest.Guidelines {
 		for _, promptName := range guideline.Prompts {
@@ -48,11 +75,13 @@ func ValidateManifest(manifest *config.Manifest, baseDir string) ValidationError
 		}
 	}
 
-	return errors
+	return errors.locate(manifest)
 }
 
 // validateGuideline validates a single guideline entry
-func validateGuideline(g config.ManifestGuideline, prefix string, baseDir string, seenNames map[string]bool) ValidationErrors {
+func validateGuideline(
+	g config.ManifestGuideline, prefix string, baseDir string, seenNames, matchedAssets map[string]bool,
+) ValidationErrors {
 	var errors ValidationErrors
 
 	// Check required fields
@@ -92,9 +121,84 @@ func validateGuideline(g config.ManifestGuideline, prefix string, baseDir string
 		)
 	}
 
+	errors = append(errors, validateFilePatterns(g, prefix+".file_patterns")...)
+	errors = append(errors, validateAssets(g, prefix+".assets", matchedAssets)...)
+	errors = append(errors, validateMetadata(g, prefix)...)
+	errors = append(errors, validateAgentFrontmatter(g.Agents, agents.EntryGuideline, prefix+".agents")...)
+
+	return errors
+}
+
+// validateFilePatterns validates the file glob patterns of a guideline
+func validateFilePatterns(g config.ManifestGuideline, field string) ValidationErrors {
+	var errors ValidationErrors
+
+	if g.ManualOnly && len(g.FilePatterns) > 0 {
+		errors.Add(field, fmt.Sprintf("guideline '%s' cannot set both manual_only and file_patterns", g.Name))
+	}
+
+	for i, pattern := range g.FilePatterns {
+		patternField := fmt.Sprintf("%s[%d]", field, i)
+		if strings.TrimSpace(pattern) == "" {
+			errors.Add(patternField, "file pattern must not be empty")
+			continue
+		}
+		if path.IsAbs(pattern) || filepath.IsAbs(pattern) {
+			errors.Add(patternField, fmt.Sprintf("file pattern must be relative to the project root: %s", pattern))
+			continue
+		}
+		// path.Match reports malformed patterns (e.g. unclosed brackets) regardless of the name
+		if _, err := path.Match(pattern, ""); err != nil {
+			errors.Add(patternField, fmt.Sprintf("invalid file pattern: %s", pattern))
+		}
+	}
+
 	return errors
 }
 
+// validateAssets validates the asset patterns of a guideline with the file path rules
+// Each pattern must match at least one file, as recorded in matchedAssets
+func validateAssets(g config.ManifestGuideline, field string, matchedAssets map[string]bool) ValidationErrors {
+	var errors ValidationErrors
+
+	for i, pattern := range g.Assets {
+		patternField := fmt.Sprintf("%s[%d]", field, i)
+		if strings.TrimSpace(pattern) == "" {
+			errors.Add(patternField, "asset pattern must not be empty")
+			continue
+		}
+		if pathErrors := validatePathSecurity(pattern, patternField, "guidelines/"); len(pathErrors) > 0 {
+			errors = append(errors, pathErrors...)
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			errors.Add(patternField, fmt.Sprintf("invalid asset pattern: %s", pattern))
+			continue
+		}
+
+		if matchedAssets != nil && !matchedAssets[pattern] {
+			errors.Add(patternField, fmt.Sprintf("asset pattern matches no files: %s", pattern))
+		}
+	}
+
+	return errors
+}
+
+// matchAssetPatterns finds which asset patterns of the guidelines match files below baseDir
+// Returns nil when the repository can't be walked, in which case unmatched patterns aren't reported
+func matchAssetPatterns(guidelines []config.ManifestGuideline, baseDir string) map[string]bool {
+	var patterns []string
+	for _, g := range guidelines {
+		patterns = append(patterns, g.Assets...)
+	}
+
+	matched, err := files.MatchedAssetPatterns(baseDir, patterns)
+	if err != nil {
+		return nil
+	}
+	return matched
+}
+
 // validatePrompt validates a single prompt entry
 func validatePrompt(p config.ManifestPrompt, prefix string, baseDir string, seenNames map[string]bool) ValidationErrors {
 	var errors ValidationErrors
@@ -129,11 +233,48 @@ func validatePrompt(p config.ManifestPrompt, prefix string, baseDir string, seen
 		errors.Add(prefix+".description", "missing required field: description")
 	}
 
+	errors = append(errors, validatePromptArguments(p.Arguments, prefix)...)
+	errors = append(errors, validateAgentFrontmatter(p.Agents, agents.EntryPrompt, prefix+".agents")...)
+
+	return errors
+}
+
+// validateAgentFrontmatter validates the agent-specific frontmatter of a guideline or prompt
+func validateAgentFrontmatter(frontmatter config.AgentFrontmatter, kind, field string) ValidationErrors {
+	var errors ValidationErrors
+
+	for _, agentID := range slices.Sorted(maps.Keys(frontmatter)) {
+		agentField := fmt.Sprintf("%s.%s", field, agentID)
+		if !agents.IsValidAgent(agentID) {
+			errors.Add(agentField, fmt.Sprintf("unknown agent ID: '%s'", agentID))
+			continue
+		}
+		for _, problem := range agents.CheckFrontmatter(agentID, kind, frontmatter[agentID]) {
+			errors.Add(agentField, problem)
+		}
+	}
+
 	return errors
 }
 
 // validateFilePath validates a file path for security and existence
 func validateFilePath(path, field, baseDir, expectedPrefix string) ValidationErrors {
+	errors := validatePathSecurity(path, field, expectedPrefix)
+	if len(errors) > 0 {
+		return errors
+	}
+
+	// Check if file exists
+	fullPath := filepath.Join(baseDir, path)
+	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+		errors.Add(field, fmt.Sprintf("file not found: %s", path))
+	}
+
+	return errors
+}
+
+// validatePathSecurity checks that a path is relative, stays within the repository and starts with expectedPrefix
+func validatePathSecurity(path, field, expectedPrefix string) ValidationErrors {
 	var errors ValidationErrors
 
 	// Check for absolute paths
@@ -151,13 +292,6 @@ func validateFilePath(path, field, baseDir, expectedPrefix string) ValidationErr
 	// Check directory prefix
 	if !strings.HasPrefix(path, expectedPrefix) {
 		errors.Add(field, fmt.Sprintf("path must be within %s: %s", expectedPrefix, path))
-		return errors
-	}
-
-	// Check if file exists
-	fullPath := filepath.Join(baseDir, path)
-	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-		errors.Add(field, fmt.Sprintf("file not found: %s", path))
 	}
 
 	return errors
@@ -9,6 +9,7 @@ import (
 	"github.com/aviator5/dnaspec/internal/core/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestValidator_Validate(t *testing.T) {
@@ -632,6 +633,154 @@ func TestValidator_EmptyApplicableScenarios(t *testing.T) {
 	assert.True(t, hasApplicableError, "Expected applicable_scenarios error")
 }
 
+func TestValidator_FilePatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	guidelinePath := "guidelines/test.md"
+	fullPath := filepath.Join(tmpDir, guidelinePath)
+	err := os.MkdirAll(filepath.Dir(fullPath), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(fullPath, []byte("test"), 0644)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		filePatterns []string
+		manualOnly   bool
+		wantError    string
+	}{
+		{name: "valid patterns", filePatterns: []string{"**/*.go", "cmd/**"}},
+		{name: "manual only", manualOnly: true},
+		{name: "manual only with patterns", filePatterns: []string{"**/*.go"}, manualOnly: true, wantError: "cannot set both"},
+		{name: "empty pattern", filePatterns: []string{" "}, wantError: "must not be empty"},
+		{name: "absolute pattern", filePatterns: []string{"/src/*.go"}, wantError: "relative to the project root"},
+		{name: "malformed pattern", filePatterns: []string{"src/[a-z.go"}, wantError: "invalid file pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &config.Manifest{
+				Version: 1,
+				Guidelines: []config.ManifestGuideline{
+					{
+						Name:                "test-guideline",
+						File:                guidelinePath,
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing"},
+						FilePatterns:        tt.filePatterns,
+						ManualOnly:          tt.manualOnly,
+					},
+				},
+			}
+
+			errs := ValidateManifest(manifest, tmpDir)
+
+			if tt.wantError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Field, "file_patterns")
+			assert.Contains(t, errs[0].Message, tt.wantError)
+		})
+	}
+}
+
+func TestValidator_Assets(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, file := range []string{"guidelines/test.md", "guidelines/test/example.go", "prompts/example.go"} {
+		fullPath := filepath.Join(tmpDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte("test"), 0644))
+	}
+
+	tests := []struct {
+		name      string
+		assets    []string
+		wantError string
+	}{
+		{name: "valid patterns", assets: []string{"guidelines/test/*.go", "guidelines/**/example.go"}},
+		{name: "empty pattern", assets: []string{" "}, wantError: "must not be empty"},
+		{name: "absolute path", assets: []string{"/guidelines/test/*.go"}, wantError: "absolute paths not allowed"},
+		{name: "path traversal", assets: []string{"guidelines/../prompts/*.go"}, wantError: "path traversal not allowed"},
+		{name: "outside guidelines", assets: []string{"prompts/*.go"}, wantError: "path must be within guidelines/"},
+		{name: "malformed pattern", assets: []string{"guidelines/[a-z.go"}, wantError: "invalid asset pattern"},
+		{name: "no matches", assets: []string{"guidelines/test/*.png"}, wantError: "asset pattern matches no files"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &config.Manifest{
+				Version: 1,
+				Guidelines: []config.ManifestGuideline{
+					{
+						Name:                "test-guideline",
+						File:                "guidelines/test.md",
+						Description:         "Test guideline",
+						ApplicableScenarios: []string{"testing"},
+						Assets:              tt.assets,
+					},
+				},
+			}
+
+			errs := ValidateManifest(manifest, tmpDir)
+
+			if tt.wantError == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, "guidelines[0].assets[0]", errs[0].Field)
+			assert.Contains(t, errs[0].Message, tt.wantError)
+		})
+	}
+}
+
+func TestValidator_AgentFrontmatter(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "guidelines"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "prompts"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "guidelines", "go-style.md"), []byte("# Go Style"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "prompts", "code-review.md"), []byte("Review"), 0644))
+
+	manifestYAML := `version: 1
+guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go style
+    applicable_scenarios: ["Writing Go code"]
+    agents:
+      github-copilot:
+        applyTo: "**/*.go"
+      cursor:
+        alwaysApply: true
+prompts:
+  - name: code-review
+    file: prompts/code-review.md
+    description: Review code
+    agents:
+      claude-code:
+        allowed-tools: [Read, Grep]
+        model: opus
+      github-copilot:
+        mode: review
+      unknown-agent:
+        model: x
+`
+	var manifest config.Manifest
+	require.NoError(t, yaml.Unmarshal([]byte(manifestYAML), &manifest))
+
+	errs := ValidateManifest(&manifest, tmpDir)
+	require.Len(t, errs, 3, errs.Error())
+	assert.Equal(t, "guidelines[0].agents.cursor", errs[0].Field)
+	assert.Contains(t, errs[0].Message, "cursor has no frontmatter for guidelines")
+	assert.Equal(t, "prompts[0].agents.github-copilot", errs[1].Field)
+	assert.Contains(t, errs[1].Message, "'mode' must be one of ask, edit, agent")
+	assert.Equal(t, "prompts[0].agents.unknown-agent", errs[2].Field)
+	assert.Contains(t, errs[2].Message, "unknown agent ID")
+}
+
 func TestValidator_MissingVersion(t *testing.T) {
 	manifest := &config.Manifest{
 		Version:    0, // Missing/zero version
@@ -714,3 +863,34 @@ func TestValidator_ComplexScenario(t *testing.T) {
 	errs := ValidateManifest(manifest, tmpDir)
 	assert.Empty(t, errs, "Valid complex manifest should have no errors")
 }
+
+func TestValidator_IncludedManifestErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	for file, content := range map[string]string{
+		"dnaspec-manifest.yaml": "version: 1\ninclude: [manifests/*.yaml]\nguidelines: []\nprompts: []\n",
+		"manifests/go.yaml": `guidelines:
+  - name: go-style
+    file: guidelines/go-style.md
+    description: Go style
+    applicable_scenarios: [writing Go]
+  - name: Go_Testing
+    file: guidelines/go-style.md
+    description: Go testing
+    applicable_scenarios: [testing Go]
+`,
+		"guidelines/go-style.md": "# Go style",
+	} {
+		path := filepath.Join(tmpDir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	manifest, err := config.LoadManifest(filepath.Join(tmpDir, "dnaspec-manifest.yaml"))
+	require.NoError(t, err)
+
+	errs := ValidateManifest(manifest, tmpDir)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "manifests/go.yaml", errs[0].File)
+	assert.Equal(t, "guidelines[1].name", errs[0].Field)
+	assert.Contains(t, errs[0].Error(), "manifests/go.yaml: guidelines[1].name: invalid naming format")
+}
@@ -2,34 +2,64 @@ package ui
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
 )
 
-// SelectGuidelines presents an interactive multi-select form for choosing guidelines
-// Returns the selected guidelines or an error
-func SelectGuidelines(available []config.ManifestGuideline) ([]config.ManifestGuideline, error) {
-	if len(available) == 0 {
-		return nil, fmt.Errorf("no guidelines available")
+// SelectBundles presents an interactive multi-select form for choosing guideline bundles
+// Returns the names of the selected bundles, which may be empty
+func SelectBundles(bundles []config.Bundle) ([]string, error) {
+	if len(bundles) == 0 {
+		return nil, fmt.Errorf("no bundles available")
 	}
 
-	// Build options
 	var options []huh.Option[string]
-	for _, g := range available {
-		label := fmt.Sprintf("%s - %s", g.Name, g.Description)
-		options = append(options, huh.NewOption(label, g.Name))
+	for _, b := range bundles {
+		label := fmt.Sprintf("%s - %s (%d guidelines)", b.Name, b.Description, len(b.Guidelines))
+		options = append(options, huh.NewOption(label, b.Name))
 	}
 
-	// Create multi-select form
 	var selected []string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Select bundles to add:").
+				Description("Use space to select/deselect, enter to confirm. Select none to pick guidelines individually").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
+
+// SelectGuidelines presents an interactive multi-select form for choosing guidelines
+// preSelected names (e.g. the guidelines of chosen bundles) start out selected
+// Returns the selected guidelines, together with the guidelines they require, or an error
+func SelectGuidelines(available []config.ManifestGuideline, preSelected []string) ([]config.ManifestGuideline, error) {
+	if len(available) == 0 {
+		return nil, fmt.Errorf("no guidelines available")
+	}
+
+	options := guidelineOptions(available)
+
+	// Create multi-select form, with the guidelines the source requires selected and locked
+	selected := appendMissing(preSelected, config.RequiredGuidelineNames(available))
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewMultiSelect[string]().
 				Title("Select guidelines to add:").
-				Description("Use space to select/deselect, enter to confirm").
+				Description("Use space to select/deselect, / to filter by name or tag, enter to confirm. 🔒 = required by the source").
 				Options(options...).
+				Validate(keepRequired(available)).
 				Value(&selected),
 		),
 	)
@@ -43,9 +73,67 @@ func SelectGuidelines(available []config.ManifestGuideline) ([]config.ManifestGu
 		return []config.ManifestGuideline{}, nil
 	}
 
-	// Filter to selected guidelines
+	return guidelinesByName(available, AddRequiredGuidelines(available, selected, preSelected)), nil
+}
+
+// guidelineOptions builds selection options for guidelines, grouped by their first tag
+func guidelineOptions(available []config.ManifestGuideline) []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, group := range config.GroupByTag(available) {
+		for _, g := range group.Guidelines {
+			options = append(options, huh.NewOption(guidelineLabel(g), g.Name))
+		}
+	}
+	return options
+}
+
+// guidelineLabel formats a guideline as a selection option, listing its tags and the guidelines it requires
+// Tags are part of the label so the selection can be filtered by them
+func guidelineLabel(g config.ManifestGuideline) string {
+	label := fmt.Sprintf("%s - %s", g.Name, g.Description)
+	if len(g.Tags) > 0 {
+		label += fmt.Sprintf(" [%s]", strings.Join(g.Tags, ", "))
+	}
+	if len(g.Requires) > 0 {
+		label += fmt.Sprintf(" (requires %s)", strings.Join(g.Requires, ", "))
+	}
+	if g.Deprecated != nil {
+		label += " (deprecated)"
+	}
+	if g.Required {
+		label += " 🔒"
+	}
+	return label
+}
+
+// keepRequired returns a validation func that rejects selections leaving out guidelines the source requires
+func keepRequired(available []config.ManifestGuideline) func([]string) error {
+	required := config.RequiredGuidelineNames(available)
+	return func(selected []string) error {
+		for _, name := range required {
+			if !slices.Contains(selected, name) {
+				return fmt.Errorf("'%s' is required by the source and can't be deselected", name)
+			}
+		}
+		return nil
+	}
+}
+
+// appendMissing returns names followed by the extra names it doesn't contain yet
+func appendMissing(names, extra []string) []string {
+	result := slices.Clone(names)
+	for _, name := range extra {
+		if !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// guidelinesByName returns the guidelines with the given names, in the order of names
+func guidelinesByName(available []config.ManifestGuideline, names []string) []config.ManifestGuideline {
 	var result []config.ManifestGuideline
-	for _, name := range selected {
+	for _, name := range names {
 		for _, g := range available {
 			if g.Name == name {
 				result = append(result, g)
@@ -53,8 +141,32 @@ func SelectGuidelines(available []config.ManifestGuideline) ([]config.ManifestGu
 			}
 		}
 	}
+	return result
+}
 
-	return result, nil
+// AddRequiredGuidelines extends selected guideline names with the guidelines the source marks as required
+// and the guidelines the selection requires, and reports why each was added
+// Required guidelines in previouslySelected were deselected by the user; they are kept with a warning
+func AddRequiredGuidelines(available []config.ManifestGuideline, selected, previouslySelected []string) []string {
+	result, dependencies := config.AddRequiredGuidelines(available, selected)
+	for _, dep := range dependencies {
+		if dep.RequiredBy == "" {
+			fmt.Println(InfoStyle.Render("ℹ"), fmt.Sprintf("Adding '%s' (required by the source)", dep.Name))
+			continue
+		}
+		reason := "required by"
+		if dep.Included {
+			reason = "included by"
+		}
+		if slices.Contains(previouslySelected, dep.Name) {
+			fmt.Println(WarningStyle.Render("⚠"), fmt.Sprintf(
+				"Keeping '%s' (%s '%s'), deselect '%s' too to remove it", dep.Name, reason, dep.RequiredBy, dep.RequiredBy,
+			))
+			continue
+		}
+		fmt.Println(InfoStyle.Render("ℹ"), fmt.Sprintf("Adding '%s' (%s '%s')", dep.Name, reason, dep.RequiredBy))
+	}
+	return result
 }
 
 // testMockSelection allows tests to bypass interactive UI
@@ -99,12 +211,8 @@ func SelectGuidelinesWithStatus(
 		existingMap[name] = true
 	}
 
-	// Build options list with available guidelines
-	var options []huh.Option[string]
-	for _, g := range available {
-		label := fmt.Sprintf("%s - %s", g.Name, g.Description)
-		options = append(options, huh.NewOption(label, g.Name))
-	}
+	// Build options list with available guidelines, grouped by tag
+	options := guidelineOptions(available)
 
 	// Add orphaned guidelines at the end with warning icon
 	for _, g := range orphaned {
@@ -112,9 +220,8 @@ func SelectGuidelinesWithStatus(
 		options = append(options, huh.NewOption(label, g.Name))
 	}
 
-	// Pre-select existing and orphaned guidelines
-	var preSelected []string
-	preSelected = append(preSelected, existing...)
+	// Pre-select existing, required and orphaned guidelines
+	preSelected := appendMissing(existing, config.RequiredGuidelineNames(available))
 	for _, g := range orphaned {
 		preSelected = append(preSelected, g.Name)
 	}
@@ -125,8 +232,9 @@ func SelectGuidelinesWithStatus(
 		huh.NewGroup(
 			huh.NewMultiSelect[string]().
 				Title("Select guidelines to keep or add:").
-				Description("Use space to select/deselect, enter to confirm. ⚠️ = missing from source").
+				Description("Use space to select/deselect, / to filter, enter to confirm. ⚠️ = missing from source, 🔒 = required by the source").
 				Options(options...).
+				Validate(keepRequired(available)).
 				Value(&selected),
 		),
 	)
@@ -152,7 +260,7 @@ func SelectGuidelinesWithStatus(
 	return result, nil
 }
 
-// SelectGuidelinesByName selects guidelines by their names
+// SelectGuidelinesByName selects guidelines by their names, together with the guidelines they require
 // Validates that all requested names exist in the available guidelines
 func SelectGuidelinesByName(available []config.ManifestGuideline, names []string) ([]config.ManifestGuideline, error) {
 	if len(names) == 0 {
@@ -165,14 +273,10 @@ func SelectGuidelinesByName(available []config.ManifestGuideline, names []string
 		availableMap[g.Name] = g
 	}
 
-	// Validate all names exist and collect selected guidelines
-	var result []config.ManifestGuideline
+	// Validate all names exist
 	var missing []string
-
 	for _, name := range names {
-		if g, ok := availableMap[name]; ok {
-			result = append(result, g)
-		} else {
+		if _, ok := availableMap[name]; !ok {
 			missing = append(missing, name)
 		}
 	}
@@ -187,5 +291,5 @@ func SelectGuidelinesByName(available []config.ManifestGuideline, names []string
 		return nil, fmt.Errorf("guidelines not found: %v (available: %v)", missing, availableNames)
 	}
 
-	return result, nil
+	return guidelinesByName(available, AddRequiredGuidelines(available, names, nil)), nil
 }
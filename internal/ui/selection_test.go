@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/aviator5/dnaspec/internal/core/config"
@@ -151,3 +152,82 @@ func TestSelectGuidelinesByName_ErrorMessage(t *testing.T) {
 	// (This is a basic check - the actual error format may vary)
 	t.Logf("Error message: %s", errMsg)
 }
+
+func TestSelectGuidelinesByName_Requires(t *testing.T) {
+	available := []config.ManifestGuideline{
+		{Name: "rest-api", File: "guidelines/rest-api.md", Requires: []string{"error-handling"}},
+		{Name: "error-handling", File: "guidelines/error-handling.md", Requires: []string{"logging"}},
+		{Name: "logging", File: "guidelines/logging.md"},
+		{Name: "security", File: "guidelines/security.md"},
+	}
+
+	result, err := SelectGuidelinesByName(available, []string{"rest-api"})
+	if err != nil {
+		t.Fatalf("SelectGuidelinesByName() error = %v", err)
+	}
+
+	var names []string
+	for _, g := range result {
+		names = append(names, g.Name)
+	}
+	expected := []string{"rest-api", "error-handling", "logging"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
+
+func TestGuidelineLabel(t *testing.T) {
+	g := config.ManifestGuideline{Name: "rest-api", Description: "REST API guide", Requires: []string{"error-handling", "logging"}}
+	expected := "rest-api - REST API guide (requires error-handling, logging)"
+	if label := guidelineLabel(g); label != expected {
+		t.Errorf("Expected %q, got %q", expected, label)
+	}
+
+	g = config.ManifestGuideline{Name: "security", Description: "Security baseline", Required: true}
+	expected = "security - Security baseline 🔒"
+	if label := guidelineLabel(g); label != expected {
+		t.Errorf("Expected %q, got %q", expected, label)
+	}
+
+	g = config.ManifestGuideline{Name: "go-style", Description: "Go style", Deprecated: &config.Deprecation{Message: "superseded"}}
+	expected = "go-style - Go style (deprecated)"
+	if label := guidelineLabel(g); label != expected {
+		t.Errorf("Expected %q, got %q", expected, label)
+	}
+
+	g = config.ManifestGuideline{Name: "sql", Description: "SQL style", Tags: []string{"data", "style"}}
+	expected = "sql - SQL style [data, style]"
+	if label := guidelineLabel(g); label != expected {
+		t.Errorf("Expected %q, got %q", expected, label)
+	}
+}
+
+func TestSelectGuidelinesByName_RequiredBySource(t *testing.T) {
+	available := []config.ManifestGuideline{
+		{Name: "go-style", File: "guidelines/go-style.md"},
+		{Name: "security", File: "guidelines/security.md", Required: true},
+	}
+
+	result, err := SelectGuidelinesByName(available, []string{"go-style"})
+	if err != nil {
+		t.Fatalf("SelectGuidelinesByName() error = %v", err)
+	}
+	if len(result) != 2 || result[1].Name != "security" {
+		t.Errorf("Expected go-style and security, got %v", result)
+	}
+}
+
+func TestKeepRequired(t *testing.T) {
+	validate := keepRequired([]config.ManifestGuideline{
+		{Name: "go-style"},
+		{Name: "security", Required: true},
+	})
+
+	if err := validate([]string{"security"}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	err := validate([]string{"go-style"})
+	if err == nil || err.Error() != "'security' is required by the source and can't be deselected" {
+		t.Errorf("Expected locked guideline error, got %v", err)
+	}
+}